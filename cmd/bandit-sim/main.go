@@ -0,0 +1,188 @@
+// Команда bandit-sim сравнивает стратегии выбора баннера офлайн: проигрывает через них
+// синтетический сценарий с известным CTR или записанные показы и выводит накопленную награду,
+// regret и долю показов каждого баннера.
+//
+//	bandit-sim --scenario=configs/bandit_sim_scenario.yaml --strategies=ucb1,thompson --seed=42
+//	bandit-sim --history=history.csv --slot=1 --state=state.jsonl --format=csv --out=report.csv
+//
+// Журнал показов выгружается из базы сервиса:
+//
+//	\copy (SELECT i.slot_id, i.banner_id, i.usergroup_id,
+//	       EXISTS (SELECT 1 FROM clicks c WHERE c.impression_id = i.id) AS clicked
+//	       FROM impressions i ORDER BY i.created_at, i.id) TO 'history.csv' CSV HEADER
+//
+// Клики без ссылки на показ в журнал не попадают. Признаки групп для linucb берутся из снимка
+// banner export (--state).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/banditsim"
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/dianapovarnitsina/banners-rotation/internal/statefile"
+	"github.com/pkg/errors"
+)
+
+var (
+	scenarioFile   string
+	historyFile    string
+	slotID         int
+	stateFile      string
+	stateFormat    string
+	rounds         int
+	strategies     string
+	epsilon        float64
+	temperature    float64
+	alpha          float64
+	prior          string
+	priorAlpha     float64
+	priorBeta      float64
+	priorStrength  float64
+	minImpressions int64
+	seed           int64
+	format         string
+	out            string
+)
+
+func init() {
+	flag.StringVar(&scenarioFile, "scenario", "", "Synthetic scenario file (YAML)")
+	flag.StringVar(&historyFile, "history", "", "Recorded impressions (CSV: slot_id,banner_id,usergroup_id,clicked)")
+	flag.IntVar(&slotID, "slot", 0, "Slot to replay from history; required if history has several slots")
+	flag.StringVar(&stateFile, "state", "", "State exported by 'banner export' with usergroup features for linucb")
+	flag.StringVar(&stateFormat, "state-format", statefile.FormatJSONL, "State format: jsonl | csv")
+	flag.IntVar(&rounds, "rounds", 0, "Override the number of scenario rounds")
+	flag.StringVar(&strategies, "strategies",
+		strings.Join([]string{
+			multiarmedbandit.StrategyUCB1,
+			multiarmedbandit.StrategyEpsilonGreedy,
+			multiarmedbandit.StrategyThompson,
+			multiarmedbandit.StrategySoftmax,
+			multiarmedbandit.StrategyLinUCB,
+		}, ","),
+		"Comma-separated strategies to compare")
+	flag.Float64Var(&epsilon, "epsilon", 0.1, "epsilon-greedy: exploration probability")
+	flag.Float64Var(&temperature, "temperature", 0.1, "softmax: temperature")
+	flag.Float64Var(&alpha, "alpha", 1.0, "linucb: confidence bound width")
+	flag.StringVar(&prior, "prior", multiarmedbandit.PriorNone, "Cold start prior: slot | fixed; none if empty")
+	flag.Float64Var(&priorAlpha, "prior-alpha", 0, "Fixed prior: Beta alpha")
+	flag.Float64Var(&priorBeta, "prior-beta", 0, "Fixed prior: Beta beta")
+	flag.Float64Var(&priorStrength, "prior-strength", 10, "Slot prior: weight in impressions")
+	flag.Int64Var(&minImpressions, "min-impressions", 0, "Cold start: forced impressions of every banner")
+	flag.Int64Var(&seed, "seed", 1, "Random seed of scenario rounds, strategies and selector draws; must be non-zero")
+	flag.StringVar(&format, "format", banditsim.FormatText, "Report format: text | csv")
+	flag.StringVar(&out, "out", "", "Report file; stdout if empty")
+}
+
+func main() {
+	if err := mainImpl(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func mainImpl() error {
+	flag.Parse()
+
+	if (scenarioFile == "") == (historyFile == "") {
+		return fmt.Errorf("please set one of: '--scenario=<scenario file>', '--history=<history file>'")
+	}
+	// Нулевой seed стратегии заменяют текущим временем, и запуски перестают повторяться
+	if seed == 0 {
+		return fmt.Errorf("seed must be non-zero")
+	}
+	coldStart, err := multiarmedbandit.NewColdStart(prior, priorAlpha, priorBeta, priorStrength, minImpressions)
+	if err != nil {
+		return errors.Wrap(err, "invalid cold start")
+	}
+
+	env, err := newEnvironment()
+	if err != nil {
+		return err
+	}
+
+	var results []banditsim.Result
+	for _, name := range strings.Split(strategies, ",") {
+		strategy, err := multiarmedbandit.NewStrategy(strings.TrimSpace(name), multiarmedbandit.Params{
+			Epsilon:     epsilon,
+			Temperature: temperature,
+			Alpha:       alpha,
+			Seed:        seed,
+		})
+		if err != nil {
+			return err
+		}
+		selector := multiarmedbandit.NewSelectorWithSource(strategy, rand.NewSource(seed))
+		selector.SetDefaultColdStart(coldStart)
+		results = append(results, banditsim.Run(env, selector))
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return banditsim.WriteReport(w, format, results)
+}
+
+func newEnvironment() (banditsim.Environment, error) {
+	if scenarioFile != "" {
+		scenario, err := banditsim.LoadScenario(scenarioFile)
+		if err != nil {
+			return nil, err
+		}
+		if rounds > 0 {
+			scenario.Rounds = rounds
+		}
+		return banditsim.NewScenarioEnvironment(scenario, seed)
+	}
+
+	f, err := os.Open(historyFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	impressions, err := banditsim.ReadHistory(f)
+	if err != nil {
+		return nil, err
+	}
+
+	features, err := readFeatures()
+	if err != nil {
+		return nil, err
+	}
+	return banditsim.NewHistoryEnvironment(impressions, slotID, features)
+}
+
+// readFeatures возвращает признаки групп из снимка --state или nil без него.
+func readFeatures() (map[int]map[string]float64, error) {
+	if stateFile == "" {
+		return nil, nil
+	}
+	f, err := os.Open(stateFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	state, err := statefile.Read(f, stateFormat)
+	if err != nil {
+		return nil, errors.Wrap(err, "read state failed")
+	}
+	features := make(map[int]map[string]float64, len(state.UserGroups))
+	for _, group := range state.UserGroups {
+		if len(group.Features) > 0 {
+			features[group.ID] = group.Features
+		}
+	}
+	return features, nil
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/dianapovarnitsina/banners-rotation/internal/app/banner"
 	"github.com/dianapovarnitsina/banners-rotation/internal/config"
@@ -16,6 +17,8 @@ import (
 
 var bannerConfigFile string
 
+const shutdownTimeout = 10 * time.Second
+
 func init() {
 	flag.StringVar(&bannerConfigFile, "config", "banner_config.yaml", "Path to configuration file")
 }
@@ -30,6 +33,16 @@ func mainImpl() error {
 	ctx, cancel := context.WithCancel(context.TODO())
 	defer cancel()
 
+	// Подкоманды переноса состояния работают с хранилищем без запуска сервиса.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			return runExport(ctx, os.Args[2:])
+		case "import":
+			return runImport(ctx, os.Args[2:])
+		}
+	}
+
 	flag.Parse()
 
 	if bannerConfigFile == "" {
@@ -41,7 +54,7 @@ func mainImpl() error {
 		return errors.Wrap(err, "init config failed")
 	}
 
-	_, err := banner.NewApp(ctx, conf)
+	app, err := banner.NewApp(ctx, conf)
 	if err != nil {
 		return fmt.Errorf("failed to create bannerApp: %w", err)
 	}
@@ -50,5 +63,11 @@ func mainImpl() error {
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	<-sigChan
 
+	// Получен сигнал завершения - останавливаем фоновые задачи, затем серверы и хранилище.
+	cancel()
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+	app.Stop(shutdownCtx)
+
 	return nil
 }
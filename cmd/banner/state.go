@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dianapovarnitsina/banners-rotation/interfaces"
+	"github.com/dianapovarnitsina/banners-rotation/internal/app/banner"
+	"github.com/dianapovarnitsina/banners-rotation/internal/config"
+	"github.com/dianapovarnitsina/banners-rotation/internal/statefile"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/pkg/errors"
+)
+
+// runExport выгружает слоты, баннеры, группы, ротации и статистику:
+//
+//	banner export --config=banner_config.yaml --format=jsonl --out=state.jsonl
+func runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	configFile := fs.String("config", "banner_config.yaml", "Path to configuration file")
+	format := fs.String("format", statefile.FormatJSONL, "State format: jsonl | csv")
+	out := fs.String("out", "", "Output file; stdout if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := statefile.CheckFormat(*format); err != nil {
+		return err
+	}
+
+	stor, err := openStorage(ctx, *configFile)
+	if err != nil {
+		return err
+	}
+	defer stor.Close(ctx)
+
+	state, err := stor.ExportState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "export state failed")
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := statefile.Write(w, *format, state); err != nil {
+		return errors.Wrap(err, "write state failed")
+	}
+
+	fmt.Fprintf(os.Stderr, "exported %d slots, %d banners, %d usergroups, %d rotations, %d stats\n",
+		len(state.Slots), len(state.Banners), len(state.UserGroups), len(state.Rotations), len(state.Stats))
+	return nil
+}
+
+// runImport загружает снимок, выгруженный runExport:
+//
+//	banner import --config=banner_config.yaml --format=jsonl --in=state.jsonl --on-conflict=skip --dry-run
+func runImport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	configFile := fs.String("config", "banner_config.yaml", "Path to configuration file")
+	format := fs.String("format", statefile.FormatJSONL, "State format: jsonl | csv")
+	in := fs.String("in", "", "Input file; stdin if empty")
+	onConflict := fs.String("on-conflict", storage.ConflictFail, "Existing records: skip | overwrite | fail")
+	dryRun := fs.Bool("dry-run", false, "Validate the state and count changes without writing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	opts := storage.ImportOptions{OnConflict: *onConflict, DryRun: *dryRun}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	state, err := statefile.Read(r, *format)
+	if err != nil {
+		return errors.Wrap(err, "read state failed")
+	}
+
+	stor, err := openStorage(ctx, *configFile)
+	if err != nil {
+		return err
+	}
+	defer stor.Close(ctx)
+
+	result, err := stor.ImportState(ctx, state, opts)
+	if err != nil {
+		return errors.Wrap(err, "import state failed")
+	}
+
+	if opts.DryRun {
+		fmt.Println("dry run, nothing is written")
+	}
+	for _, c := range []struct {
+		kind   string
+		counts storage.ImportCounts
+	}{
+		{"slots", result.Slots},
+		{"banners", result.Banners},
+		{"usergroups", result.UserGroups},
+		{"rotations", result.Rotations},
+		{"stats", result.Stats},
+	} {
+		fmt.Printf("%s: %d created, %d updated, %d skipped\n",
+			c.kind, c.counts.Created, c.counts.Updated, c.counts.Skipped)
+	}
+	return nil
+}
+
+func openStorage(ctx context.Context, configFile string) (interfaces.Storage, error) {
+	conf := new(config.BannerConfig)
+	if err := conf.Init(configFile); err != nil {
+		return nil, errors.Wrap(err, "init config failed")
+	}
+	return banner.OpenStorage(ctx, conf)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/app/statsconsumer"
+	"github.com/dianapovarnitsina/banners-rotation/internal/config"
+	"github.com/pkg/errors"
+)
+
+var statsConsumerConfigFile string
+
+func init() {
+	flag.StringVar(&statsConsumerConfigFile, "config", "stats_consumer_config.yaml", "Path to configuration file")
+}
+
+func main() {
+	if err := mainImpl(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func mainImpl() error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	flag.Parse()
+
+	if statsConsumerConfigFile == "" {
+		return fmt.Errorf("please set: '--config=<Path to configuration file>'")
+	}
+
+	conf := new(config.StatsConsumerConfig)
+	if err := conf.Init(statsConsumerConfigFile); err != nil {
+		return errors.Wrap(err, "init config failed")
+	}
+
+	app, err := statsconsumer.NewApp(ctx, conf)
+	if err != nil {
+		return fmt.Errorf("failed to create statsConsumerApp: %w", err)
+	}
+
+	return app.Run(ctx)
+}
@@ -2,20 +2,77 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
 )
 
+// Outbox - уведомления, записанные в одной транзакции с событиями и ожидающие публикации.
+type Outbox interface {
+	ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]storage.OutboxMessage, error)
+	MarkOutboxSent(ctx context.Context, ids []int64) error
+}
+
+// Rollups - почасовые агрегаты уведомлений для отчетов.
+type Rollups interface {
+	// AddHourlyRollup учитывает уведомление; false - сообщение с таким messageID уже было учтено.
+	AddHourlyRollup(ctx context.Context, messageID string, notification storage.Notification) (bool, error)
+	// PurgeRollupMessages забывает сообщения, учтенные раньше before, и возвращает их число.
+	// Повторная доставка такого сообщения будет учтена снова.
+	PurgeRollupMessages(ctx context.Context, before time.Time) (int64, error)
+	HourlyRollups(ctx context.Context, filter storage.StatsFilter) ([]storage.HourlyRollup, error)
+}
+
 type Storage interface {
+	Outbox
+	Rollups
+
 	Connect(ctx context.Context, dbPort int, dbHost, dbUser, dbPassword, dbName string) error
 	Close(ctx context.Context) error
 	Migrate(ctx context.Context, migrate string) error
-	AddBanner(ctx context.Context, bannerID, slotID int) error
+	AddBanner(ctx context.Context, bannerID, slotID int, settings storage.RotationSettings) error
+	// UpdateRotation заменяет условия ротации, сохраняя набранные показы и переходы.
+	UpdateRotation(ctx context.Context, bannerID, slotID int, settings storage.RotationSettings) error
+	SetBannerPaused(ctx context.Context, bannerID, slotID int, paused bool) error
 	RemoveBanner(ctx context.Context, bannerID, slotID int) error
-	ClickBanner(ctx context.Context, bannerID, slotID, userGroupID int) (*storage.Click, error)
-	PickBanner(ctx context.Context, slotID, usergroupID int) (*storage.Impress, int, error)
+	ClickBanner(ctx context.Context, attempt storage.ClickAttempt, guard storage.ClickGuard) (*storage.Click, error)
+	PickBanner(ctx context.Context, slotID, usergroupID int, excluded map[int]struct{}) (int, error)
+	PickBanners(
+		ctx context.Context,
+		slotIDs []int,
+		usergroupID int,
+		excluded map[int]struct{},
+		uniqueBanners bool,
+	) ([]int, error)
+	RecordImpression(ctx context.Context, tokenID string, bannerID, slotID, userGroupID int) (*storage.Impress, error)
 	IsBannerAssignedToSlot(ctx context.Context, bannerID, slotID int) (bool, error)
 	BannerExists(ctx context.Context, bannerID int) bool
 	SlotExists(ctx context.Context, slotID int) bool
 	UserGroupExists(ctx context.Context, userGroupID int) bool
+	BannerStats(ctx context.Context, filter storage.StatsFilter) ([]storage.BannerStatistics, error)
+
+	CreateSlot(ctx context.Context, name string) (*storage.Slot, error)
+	GetSlot(ctx context.Context, slotID int) (*storage.Slot, error)
+	ListSlots(ctx context.Context) ([]storage.Slot, error)
+	UpdateSlot(ctx context.Context, slotID int, name string) (*storage.Slot, error)
+	DeleteSlot(ctx context.Context, slotID int) error
+
+	CreateBanner(ctx context.Context, name string) (*storage.Banner, error)
+	GetBanner(ctx context.Context, bannerID int) (*storage.Banner, error)
+	ListBanners(ctx context.Context) ([]storage.Banner, error)
+	UpdateBanner(ctx context.Context, bannerID int, name string) (*storage.Banner, error)
+	DeleteBanner(ctx context.Context, bannerID int) error
+
+	CreateUserGroup(ctx context.Context, name string) (*storage.UserGroup, error)
+	GetUserGroup(ctx context.Context, userGroupID int) (*storage.UserGroup, error)
+	ListUserGroups(ctx context.Context) ([]storage.UserGroup, error)
+	UpdateUserGroup(ctx context.Context, userGroupID int, name string) (*storage.UserGroup, error)
+	DeleteUserGroup(ctx context.Context, userGroupID int) error
+	// SetUserGroupFeatures заменяет признаки группы для контекстной стратегии.
+	SetUserGroupFeatures(ctx context.Context, userGroupID int, features storage.Features) error
+	UserGroupFeatures(ctx context.Context, userGroupID int) (storage.Features, error)
+
+	// ExportState и ImportState переносят справочники, ротации и статистику между окружениями.
+	ExportState(ctx context.Context) (*storage.State, error)
+	ImportState(ctx context.Context, state *storage.State, opts storage.ImportOptions) (*storage.ImportResult, error)
 }
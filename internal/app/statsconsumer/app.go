@@ -0,0 +1,101 @@
+package statsconsumer
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/config"
+	"github.com/dianapovarnitsina/banners-rotation/internal/logger"
+	"github.com/dianapovarnitsina/banners-rotation/internal/rmq"
+	"github.com/dianapovarnitsina/banners-rotation/internal/statsconsumer"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage/sql"
+)
+
+type App struct {
+	logger   *logger.Logger
+	storage  *sql.Storage
+	mq       *rmq.Rmq
+	consumer *statsconsumer.Consumer
+}
+
+func NewApp(ctx context.Context, conf *config.StatsConsumerConfig) (*App, error) {
+	app := &App{}
+
+	logger := logger.New(conf.Logger.Level, os.Stdout)
+	app.logger = logger
+
+	// Инициализация хранилища данных. Миграции применяет сервис banner.
+	app.storage = sql.New(nil)
+	if err := app.storage.Connect(
+		ctx,
+		conf.Database.Port,
+		conf.Database.Host,
+		conf.Database.Username,
+		conf.Database.Password,
+		conf.Database.Dbname,
+	); err != nil {
+		return nil, fmt.Errorf("cannot connect to storage: %w", err)
+	}
+
+	// Инициализация RMQ.
+	URI := fmt.Sprintf("%s://%s:%s@%s:%d/",
+		conf.RMQ.RabbitmqProtocol,
+		conf.RMQ.RabbitmqUsername,
+		conf.RMQ.RabbitmqPassword,
+		conf.RMQ.RabbitmqHost,
+		conf.RMQ.RabbitmqPort,
+	)
+
+	var err error
+	app.mq, err = rmq.New(
+		URI,
+		conf.Queues.Events.ExchangeName,
+		conf.Queues.Events.ExchangeType,
+		conf.Queues.Events.QueueName,
+		conf.Queues.Events.BindingKey,
+		conf.RMQ.ReConnect.MaxElapsedTime,
+		conf.RMQ.ReConnect.InitialInterval,
+		conf.RMQ.ReConnect.Multiplier,
+		conf.RMQ.ReConnect.MaxInterval,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize RMQ for stats consumer: %w", err)
+	}
+
+	if err := app.mq.Init(ctx); err != nil {
+		return nil, fmt.Errorf("RMQ initialization failed: %w", err)
+	}
+
+	app.consumer, err = statsconsumer.NewConsumer(
+		app.storage,
+		app.mq,
+		logger,
+		conf.Consumer.ConsumerTag,
+		int(conf.Consumer.QosPrefetchCount),
+		int(conf.Consumer.Threads),
+		conf.Consumer.DedupTTL,
+		conf.Consumer.DedupPurgeInterval,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize stats consumer: %w", err)
+	}
+
+	return app, nil
+}
+
+// Run обрабатывает уведомления до отмены контекста и закрывает соединения.
+func (a *App) Run(ctx context.Context) error {
+	a.logger.Info("Stats consumer started")
+	err := a.consumer.Run(ctx)
+
+	if closeErr := a.mq.Close(); closeErr != nil {
+		a.logger.Error("RMQ close failed: %v", closeErr)
+	}
+	if closeErr := a.storage.Close(ctx); closeErr != nil {
+		a.logger.Error("Storage close failed: %v", closeErr)
+	}
+	a.logger.Info("Stats consumer stopped")
+
+	return err
+}
@@ -0,0 +1,157 @@
+package banditsim
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+var ErrInvalidHistory = errors.New("invalid history")
+
+// historyColumns - обязательные столбцы журнала показов; остальные столбцы пропускаются.
+var historyColumns = []string{"slot_id", "banner_id", "usergroup_id", "clicked"}
+
+// Impression - записанный показ баннера и был ли по нему клик.
+type Impression struct {
+	SlotID      int
+	BannerID    int
+	UserGroupID int
+	Clicked     bool
+}
+
+// ReadHistory читает журнал показов в CSV с заголовком. Показы воспроизводятся в порядке строк.
+// clicked принимает значения strconv.ParseBool, в том числе t/f из выгрузки PostgreSQL.
+func ReadHistory(r io.Reader) ([]Impression, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: read header: %w", ErrInvalidHistory, err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[name] = i
+	}
+	columns := make([]int, len(historyColumns))
+	for i, name := range historyColumns {
+		column, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidHistory, name)
+		}
+		columns[i] = column
+	}
+
+	var impressions []Impression
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return impressions, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidHistory, line, err)
+		}
+
+		var (
+			impression Impression
+			ids        = []*int{&impression.SlotID, &impression.BannerID, &impression.UserGroupID}
+		)
+		for i, id := range ids {
+			if *id, err = strconv.Atoi(row[columns[i]]); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %s: %w", ErrInvalidHistory, line, historyColumns[i], err)
+			}
+		}
+		if impression.Clicked, err = strconv.ParseBool(row[columns[3]]); err != nil {
+			return nil, fmt.Errorf("%w: line %d: clicked: %w", ErrInvalidHistory, line, err)
+		}
+		impressions = append(impressions, impression)
+	}
+}
+
+// historyEnv воспроизводит журнал методом replay: раунд засчитывается, только если стратегия
+// выбрала тот же баннер, что был показан, и тогда ее награда - записанный клик. Ожидаемый CTR
+// для regret - CTR баннера в группе по всему журналу.
+type historyEnv struct {
+	banners     []int
+	features    map[int]map[string]float64
+	impressions []Impression
+	ctr         map[int]map[int]float64
+}
+
+// NewHistoryEnvironment создает окружение из показов слота slotID; 0 - журнал должен содержать
+// один слот. features - признаки групп для контекстной стратегии, может быть nil.
+func NewHistoryEnvironment(
+	impressions []Impression,
+	slotID int,
+	features map[int]map[string]float64,
+) (Environment, error) {
+	env := &historyEnv{features: features, ctr: make(map[int]map[int]float64)}
+	type counts struct{ impressions, clicks float64 }
+	stats := make(map[[2]int]*counts)
+	banners := make(map[int]struct{})
+	if slotID == 0 && len(impressions) > 0 {
+		slotID = impressions[0].SlotID
+		for _, imp := range impressions {
+			if imp.SlotID != slotID {
+				return nil, fmt.Errorf("%w: several slots in history, choose one", ErrInvalidHistory)
+			}
+		}
+	}
+	for _, imp := range impressions {
+		if imp.SlotID != slotID {
+			continue
+		}
+		env.impressions = append(env.impressions, imp)
+		banners[imp.BannerID] = struct{}{}
+
+		key := [2]int{imp.UserGroupID, imp.BannerID}
+		if stats[key] == nil {
+			stats[key] = &counts{}
+		}
+		stats[key].impressions++
+		if imp.Clicked {
+			stats[key].clicks++
+		}
+	}
+	if len(env.impressions) == 0 {
+		return nil, fmt.Errorf("%w: no impressions of slot %d", ErrInvalidHistory, slotID)
+	}
+
+	for id := range banners {
+		env.banners = append(env.banners, id)
+	}
+	sort.Ints(env.banners)
+	for key, c := range stats {
+		if env.ctr[key[0]] == nil {
+			env.ctr[key[0]] = make(map[int]float64)
+		}
+		env.ctr[key[0]][key[1]] = c.clicks / c.impressions
+	}
+	return env, nil
+}
+
+func (e *historyEnv) Banners() []int {
+	return e.banners
+}
+
+func (e *historyEnv) Features() map[int]map[string]float64 {
+	return e.features
+}
+
+func (e *historyEnv) Len() int {
+	return len(e.impressions)
+}
+
+func (e *historyEnv) Group(i int) int {
+	return e.impressions[i].UserGroupID
+}
+
+func (e *historyEnv) Outcome(i, bannerID int) (bool, bool) {
+	imp := e.impressions[i]
+	return imp.Clicked, imp.BannerID == bannerID
+}
+
+func (e *historyEnv) CTR(userGroupID, bannerID int) float64 {
+	return e.ctr[userGroupID][bannerID]
+}
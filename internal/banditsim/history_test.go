@@ -0,0 +1,65 @@
+package banditsim
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadHistory(t *testing.T) {
+	data := "id,slot_id,banner_id,usergroup_id,clicked,created_at\n" +
+		"1,1,2,3,t,2024-01-10 12:00:00\n" +
+		"2,1,4,3,false,2024-01-10 12:00:01\n"
+	impressions, err := ReadHistory(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, []Impression{
+		{SlotID: 1, BannerID: 2, UserGroupID: 3, Clicked: true},
+		{SlotID: 1, BannerID: 4, UserGroupID: 3},
+	}, impressions)
+
+	for _, invalid := range []string{
+		"",
+		"slot_id,banner_id,usergroup_id\n1,2,3\n",
+		"slot_id,banner_id,usergroup_id,clicked\nx,2,3,t\n",
+		"slot_id,banner_id,usergroup_id,clicked\n1,2,3,maybe\n",
+		"slot_id,banner_id,usergroup_id,clicked\n1,2,3\n",
+	} {
+		_, err := ReadHistory(strings.NewReader(invalid))
+		require.ErrorIs(t, err, ErrInvalidHistory, invalid)
+	}
+}
+
+func TestRunHistory(t *testing.T) {
+	var impressions []Impression
+	for i := 0; i < 200; i++ {
+		impressions = append(impressions,
+			Impression{SlotID: 1, BannerID: 1, UserGroupID: 1, Clicked: i%10 == 0},
+			Impression{SlotID: 1, BannerID: 2, UserGroupID: 1, Clicked: i%2 == 0},
+			Impression{SlotID: 2, BannerID: 3, UserGroupID: 1, Clicked: true},
+		)
+	}
+
+	_, err := NewHistoryEnvironment(impressions, 0, nil)
+	require.ErrorIs(t, err, ErrInvalidHistory)
+	_, err = NewHistoryEnvironment(impressions, 5, nil)
+	require.ErrorIs(t, err, ErrInvalidHistory)
+
+	env, err := NewHistoryEnvironment(impressions, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, env.Banners())
+	require.Equal(t, 400, env.Len())
+	require.InDelta(t, 0.1, env.CTR(1, 1), 1e-9)
+	require.InDelta(t, 0.5, env.CTR(1, 2), 1e-9)
+
+	// Засчитываются только показы баннера, который выбрала стратегия
+	strategy, err := multiarmedbandit.NewStrategy(multiarmedbandit.StrategyThompson, multiarmedbandit.Params{Seed: 1})
+	require.NoError(t, err)
+	result := Run(env, multiarmedbandit.NewSelector(strategy))
+	require.Equal(t, env.Len(), result.Rounds+result.Skipped)
+	require.Positive(t, result.Skipped)
+	require.Equal(t, result.Rounds, result.Arms[0].Picks+result.Arms[1].Picks)
+	require.Greater(t, result.Arms[1].Picks, result.Arms[0].Picks)
+	require.InDelta(t, 0.4*float64(result.Arms[0].Picks), result.Regret, 1e-9)
+}
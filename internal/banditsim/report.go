@@ -0,0 +1,88 @@
+package banditsim
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Форматы отчета.
+const (
+	FormatText = "text"
+	FormatCSV  = "csv"
+)
+
+var ErrUnknownFormat = errors.New("report format must be text or csv")
+
+// csvHeader - столбцы CSV-отчета: строка на баннер, итоги стратегии повторяются в каждой строке.
+var csvHeader = []string{
+	"strategy", "rounds", "skipped", "reward", "ctr", "regret",
+	"banner_id", "picks", "clicks", "share",
+}
+
+// WriteReport пишет итоги стратегий в формате format.
+func WriteReport(w io.Writer, format string, results []Result) error {
+	switch format {
+	case FormatText:
+		return writeText(w, results)
+	case FormatCSV:
+		return writeCSV(w, results)
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+func writeText(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STRATEGY\tROUNDS\tSKIPPED\tREWARD\tCTR\tREGRET")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.4f\t%.2f\n", r.Strategy, r.Rounds, r.Skipped, r.Reward, r.ctr(), r.Regret)
+	}
+
+	fmt.Fprintln(tw, "\nSTRATEGY\tBANNER\tPICKS\tCLICKS\tSHARE")
+	for _, r := range results {
+		for _, arm := range r.Arms {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.1f%%\n", r.Strategy, arm.BannerID, arm.Picks, arm.Clicks, arm.Share*100)
+		}
+	}
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, results []Result) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range results {
+		for _, arm := range r.Arms {
+			row := []string{
+				r.Strategy,
+				strconv.Itoa(r.Rounds),
+				strconv.Itoa(r.Skipped),
+				strconv.Itoa(r.Reward),
+				strconv.FormatFloat(r.ctr(), 'f', -1, 64),
+				strconv.FormatFloat(r.Regret, 'f', 6, 64),
+				strconv.Itoa(arm.BannerID),
+				strconv.Itoa(arm.Picks),
+				strconv.Itoa(arm.Clicks),
+				strconv.FormatFloat(arm.Share, 'f', -1, 64),
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ctr - средняя награда за засчитанный раунд.
+func (r Result) ctr() float64 {
+	if r.Rounds == 0 {
+		return 0
+	}
+	return float64(r.Reward) / float64(r.Rounds)
+}
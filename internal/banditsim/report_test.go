@@ -0,0 +1,39 @@
+package banditsim
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReport(t *testing.T) {
+	results := []Result{{
+		Strategy: "ucb1",
+		Rounds:   4,
+		Skipped:  1,
+		Reward:   1,
+		Regret:   0.5,
+		Arms: []ArmResult{
+			{BannerID: 1, Picks: 3, Clicks: 1, Share: 0.75},
+			{BannerID: 2, Picks: 1, Share: 0.25},
+		},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteReport(&buf, FormatCSV, results))
+	require.Equal(t, strings.Join([]string{
+		"strategy,rounds,skipped,reward,ctr,regret,banner_id,picks,clicks,share",
+		"ucb1,4,1,1,0.25,0.500000,1,3,1,0.75",
+		"ucb1,4,1,1,0.25,0.500000,2,1,0,0.25",
+		"",
+	}, "\n"), buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteReport(&buf, FormatText, results))
+	require.Contains(t, buf.String(), "ucb1      4       1        1       0.2500  0.50")
+	require.Contains(t, buf.String(), "75.0%")
+
+	require.ErrorIs(t, WriteReport(&buf, "xml", results), ErrUnknownFormat)
+}
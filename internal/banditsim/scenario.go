@@ -0,0 +1,187 @@
+package banditsim
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/spf13/viper"
+)
+
+var ErrInvalidScenario = errors.New("invalid scenario")
+
+// Scenario - синтетический слот с известным CTR каждого баннера в каждой группе.
+type Scenario struct {
+	// Rounds - число выборов баннера.
+	Rounds int             `json:"rounds"`
+	Groups []ScenarioGroup `json:"groups"`
+}
+
+// ScenarioGroup - группа пользователей сценария.
+type ScenarioGroup struct {
+	ID int `json:"id"`
+	// Weight - доля трафика группы относительно остальных групп; 0 означает 1.
+	Weight float64 `json:"weight"`
+	// Features - признаки группы для контекстной стратегии.
+	Features map[string]float64 `json:"features"`
+	CTR      []BannerCTR        `json:"ctr"`
+}
+
+// BannerCTR - истинный CTR баннера в группе.
+type BannerCTR struct {
+	BannerID int     `json:"bannerID"`
+	Value    float64 `json:"value"`
+}
+
+// LoadScenario читает сценарий из YAML-файла. Ключи, в том числе названия признаков,
+// приводятся к нижнему регистру.
+func LoadScenario(file string) (*Scenario, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("open scenario file failed: %w", err)
+	}
+
+	scenario := new(Scenario)
+	if err := v.Unmarshal(scenario); err != nil {
+		return nil, fmt.Errorf("unmarshal scenario file failed: %w", err)
+	}
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// Validate проверяет сценарий: у каждой группы CTR задан для одних и тех же баннеров.
+func (s *Scenario) Validate() error {
+	if s.Rounds <= 0 {
+		return fmt.Errorf("%w: rounds must be positive, got %d", ErrInvalidScenario, s.Rounds)
+	}
+	if len(s.Groups) == 0 {
+		return fmt.Errorf("%w: no groups", ErrInvalidScenario)
+	}
+
+	var banners map[int]struct{}
+	groups := make(map[int]struct{}, len(s.Groups))
+	for _, g := range s.Groups {
+		if _, ok := groups[g.ID]; ok {
+			return fmt.Errorf("%w: duplicate group %d", ErrInvalidScenario, g.ID)
+		}
+		groups[g.ID] = struct{}{}
+		if g.Weight < 0 {
+			return fmt.Errorf("%w: group %d: negative weight", ErrInvalidScenario, g.ID)
+		}
+
+		ctr := make(map[int]struct{}, len(g.CTR))
+		for _, c := range g.CTR {
+			if _, ok := ctr[c.BannerID]; ok {
+				return fmt.Errorf("%w: group %d: duplicate banner %d", ErrInvalidScenario, g.ID, c.BannerID)
+			}
+			ctr[c.BannerID] = struct{}{}
+			if c.Value < 0 || c.Value > 1 {
+				return fmt.Errorf("%w: group %d: banner %d: ctr must be in [0, 1]", ErrInvalidScenario, g.ID, c.BannerID)
+			}
+		}
+		if len(ctr) == 0 {
+			return fmt.Errorf("%w: group %d: no banners", ErrInvalidScenario, g.ID)
+		}
+		if banners == nil {
+			banners = ctr
+			continue
+		}
+		if len(ctr) != len(banners) {
+			return fmt.Errorf("%w: group %d: banners differ from other groups", ErrInvalidScenario, g.ID)
+		}
+		for id := range ctr {
+			if _, ok := banners[id]; !ok {
+				return fmt.Errorf("%w: group %d: banners differ from other groups", ErrInvalidScenario, g.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// scenarioEnv разыгрывает раунды сценария заранее: группа и случайное число u раунда
+// одинаковы для всех стратегий, поэтому стратегии сравниваются на одних и тех же пользователях.
+// Показ баннера с CTR p в раунде заканчивается кликом, если u < p.
+type scenarioEnv struct {
+	banners  []int
+	features map[int]map[string]float64
+	ctr      map[int]map[int]float64
+	groups   []int
+	uniforms []float64
+}
+
+// NewScenarioEnvironment создает окружение сценария; одинаковый seed дает одинаковые раунды.
+func NewScenarioEnvironment(s *Scenario, seed int64) (Environment, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	env := &scenarioEnv{
+		features: make(map[int]map[string]float64, len(s.Groups)),
+		ctr:      make(map[int]map[int]float64, len(s.Groups)),
+		groups:   make([]int, s.Rounds),
+		uniforms: make([]float64, s.Rounds),
+	}
+	var total float64
+	weights := make([]float64, len(s.Groups))
+	for i, g := range s.Groups {
+		weights[i] = g.Weight
+		if weights[i] == 0 {
+			weights[i] = 1
+		}
+		total += weights[i]
+		if len(g.Features) > 0 {
+			env.features[g.ID] = g.Features
+		}
+		env.ctr[g.ID] = make(map[int]float64, len(g.CTR))
+		for _, c := range g.CTR {
+			env.ctr[g.ID][c.BannerID] = c.Value
+		}
+	}
+	for _, c := range s.Groups[0].CTR {
+		env.banners = append(env.banners, c.BannerID)
+	}
+	sort.Ints(env.banners)
+
+	rnd := rand.New(rand.NewSource(seed)) //nolint:gosec
+	for i := range env.groups {
+		u := rnd.Float64() * total
+		env.groups[i] = s.Groups[len(s.Groups)-1].ID
+		for j, w := range weights {
+			if u < w {
+				env.groups[i] = s.Groups[j].ID
+				break
+			}
+			u -= w
+		}
+		env.uniforms[i] = rnd.Float64()
+	}
+	return env, nil
+}
+
+func (e *scenarioEnv) Banners() []int {
+	return e.banners
+}
+
+func (e *scenarioEnv) Features() map[int]map[string]float64 {
+	return e.features
+}
+
+func (e *scenarioEnv) Len() int {
+	return len(e.groups)
+}
+
+func (e *scenarioEnv) Group(i int) int {
+	return e.groups[i]
+}
+
+func (e *scenarioEnv) Outcome(i, bannerID int) (bool, bool) {
+	return e.uniforms[i] < e.ctr[e.groups[i]][bannerID], true
+}
+
+func (e *scenarioEnv) CTR(userGroupID, bannerID int) float64 {
+	return e.ctr[userGroupID][bannerID]
+}
@@ -0,0 +1,138 @@
+package banditsim
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+)
+
+// simSlotID - слот, для которого селектор выбирает баннер; в симуляции слот один.
+const simSlotID = 0
+
+// Environment - источник раундов симуляции: в каждом раунде пользователь группы Group(i)
+// ждет баннер, и стратегия выбирает его среди Banners().
+type Environment interface {
+	Banners() []int
+	// Features - признаки групп для контекстной стратегии; nil - признаков нет.
+	Features() map[int]map[string]float64
+	Len() int
+	Group(i int) int
+	// Outcome возвращает, кликнул ли пользователь i-го раунда по баннеру; false вторым
+	// значением - исход неизвестен, и раунд не засчитывается.
+	Outcome(i, bannerID int) (clicked, ok bool)
+	// CTR - ожидаемый CTR баннера в группе, по которому считается regret.
+	CTR(userGroupID, bannerID int) float64
+}
+
+// Result - итог симуляции одной стратегии.
+type Result struct {
+	Strategy string
+	// Rounds - засчитанные раунды; Skipped - раунды с неизвестным исходом.
+	Rounds  int
+	Skipped int
+	// Reward - накопленная награда (клики).
+	Reward int
+	// Regret - накопленная разница ожидаемого CTR лучшего для группы баннера и выбранного.
+	Regret float64
+	Arms   []ArmResult
+}
+
+// ArmResult - показы и клики одного баннера.
+type ArmResult struct {
+	BannerID int
+	Picks    int
+	Clicks   int
+	// Share - доля засчитанных раундов, в которых выбран баннер.
+	Share float64
+}
+
+// Run проигрывает раунды окружения через selector. Выбор делается, как в сервисе: по счетчикам
+// баннеров в группе пользователя, с подтвержденными показами в слоте для холодного старта и
+// статистикой всех групп для контекстной стратегии. Счетчики обновляются только засчитанными раундами.
+func Run(env Environment, selector *multiarmedbandit.Selector) Result {
+	bannerIDs := env.Banners()
+	result := Result{Strategy: selector.ForSlot(simSlotID).Name(), Arms: make([]ArmResult, len(bannerIDs))}
+	arms := make(map[int]*ArmResult, len(bannerIDs))
+	for i, id := range bannerIDs {
+		result.Arms[i].BannerID = id
+		arms[id] = &result.Arms[i]
+	}
+
+	stats := make(map[int]map[int]*multiarmedbandit.GroupStats)
+	groupStats := func(groupID int) map[int]*multiarmedbandit.GroupStats {
+		if stats[groupID] == nil {
+			stats[groupID] = make(map[int]*multiarmedbandit.GroupStats, len(bannerIDs))
+			for _, id := range bannerIDs {
+				stats[groupID][id] = &multiarmedbandit.GroupStats{UserGroupID: groupID}
+			}
+		}
+		return stats[groupID]
+	}
+	needsContext := selector.NeedsContext(simSlotID) && len(env.Features()) > 0
+
+	banners := make([]multiarmedbandit.Banner, len(bannerIDs))
+	for i := 0; i < env.Len(); i++ {
+		groupID := env.Group(i)
+		group := groupStats(groupID)
+		for j, id := range bannerIDs {
+			banners[j] = &multiarmedbandit.Counts{
+				BannerID:    id,
+				Impressions: group[id].Impressions,
+				Clicks:      group[id].Clicks,
+				Served:      int64(arms[id].Picks),
+			}
+		}
+		if needsContext {
+			multiarmedbandit.AttachContext(banners, groupID, env.Features(), contextStats(stats))
+		}
+
+		bannerID := selector.Pick(simSlotID, banners)
+		clicked, ok := env.Outcome(i, bannerID)
+		if !ok {
+			result.Skipped++
+			continue
+		}
+
+		result.Rounds++
+		arms[bannerID].Picks++
+		group[bannerID].Impressions++
+		if clicked {
+			result.Reward++
+			arms[bannerID].Clicks++
+			group[bannerID].Clicks++
+		}
+		best := 0.0
+		for _, id := range bannerIDs {
+			best = math.Max(best, env.CTR(groupID, id))
+		}
+		result.Regret += best - env.CTR(groupID, bannerID)
+	}
+
+	if result.Rounds > 0 {
+		for i := range result.Arms {
+			result.Arms[i].Share = float64(result.Arms[i].Picks) / float64(result.Rounds)
+		}
+	}
+	return result
+}
+
+// contextStats приводит счетчики групп к виду, который принимает AttachContext. Группы идут
+// по возрастанию ID, чтобы модель LinUCB считалась одинаково от запуска к запуску.
+func contextStats(stats map[int]map[int]*multiarmedbandit.GroupStats) map[int][]multiarmedbandit.GroupStats {
+	groupIDs := make([]int, 0, len(stats))
+	for id := range stats {
+		groupIDs = append(groupIDs, id)
+	}
+	sort.Ints(groupIDs)
+
+	byBanner := make(map[int][]multiarmedbandit.GroupStats)
+	for _, groupID := range groupIDs {
+		for bannerID, st := range stats[groupID] {
+			if st.Impressions > 0 {
+				byBanner[bannerID] = append(byBanner[bannerID], *st)
+			}
+		}
+	}
+	return byBanner
+}
@@ -0,0 +1,194 @@
+package banditsim
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/stretchr/testify/require"
+)
+
+func testScenario() *Scenario {
+	return &Scenario{
+		Rounds: 5000,
+		Groups: []ScenarioGroup{
+			{
+				ID: 1, Weight: 2, Features: map[string]float64{"young": 1},
+				CTR: []BannerCTR{{BannerID: 1, Value: 0.02}, {BannerID: 2, Value: 0.2}},
+			},
+			{
+				ID: 2, Features: map[string]float64{"old": 1},
+				CTR: []BannerCTR{{BannerID: 1, Value: 0.2}, {BannerID: 2, Value: 0.02}},
+			},
+		},
+	}
+}
+
+func newSelector(t *testing.T, name string, seed int64) *multiarmedbandit.Selector {
+	t.Helper()
+	strategy, err := multiarmedbandit.NewStrategy(name, multiarmedbandit.Params{Epsilon: 0.1, Seed: seed})
+	require.NoError(t, err)
+	return multiarmedbandit.NewSelectorWithSource(strategy, rand.NewSource(seed))
+}
+
+func TestRunScenario(t *testing.T) {
+	env, err := NewScenarioEnvironment(testScenario(), 7)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, env.Banners())
+
+	for _, name := range []string{
+		multiarmedbandit.StrategyUCB1,
+		multiarmedbandit.StrategyEpsilonGreedy,
+		multiarmedbandit.StrategyThompson,
+		multiarmedbandit.StrategySoftmax,
+		multiarmedbandit.StrategyLinUCB,
+	} {
+		result := Run(env, newSelector(t, name, 7))
+		require.Equal(t, name, result.Strategy)
+		require.Equal(t, 5000, result.Rounds)
+		require.Zero(t, result.Skipped)
+
+		var picks, clicks int
+		var share float64
+		for _, arm := range result.Arms {
+			picks += arm.Picks
+			clicks += arm.Clicks
+			share += arm.Share
+		}
+		require.Equal(t, result.Rounds, picks, name)
+		require.Equal(t, result.Reward, clicks, name)
+		require.InDelta(t, 1, share, 1e-9, name)
+		// Регрет ограничен случайным выбором: 0.18 за раунд
+		require.Greater(t, result.Regret, 0.0, name)
+		require.Less(t, result.Regret, 0.18*5000/2, name)
+
+		// Одинаковые seed повторяют запуск
+		again, err := NewScenarioEnvironment(testScenario(), 7)
+		require.NoError(t, err)
+		require.Equal(t, result, Run(again, newSelector(t, name, 7)), name)
+	}
+}
+
+func TestRunColdStart(t *testing.T) {
+	env, err := NewScenarioEnvironment(testScenario(), 1)
+	require.NoError(t, err)
+
+	// Каждый баннер сначала получает обязательные показы
+	selector := newSelector(t, multiarmedbandit.StrategyEpsilonGreedy, 1)
+	selector.SetDefaultColdStart(multiarmedbandit.ColdStart{MinImpressions: 1000})
+	result := Run(env, selector)
+	for _, arm := range result.Arms {
+		require.GreaterOrEqual(t, arm.Picks, 1000)
+	}
+}
+
+func TestRunReproducible(t *testing.T) {
+	// Один seed задает и сценарий, и стратегию, и розыгрыши селектора: запуски совпадают
+	run := func(name string, seed int64) Result {
+		env, err := NewScenarioEnvironment(testScenario(), seed)
+		require.NoError(t, err)
+		selector := newSelector(t, name, seed)
+		selector.SetDefaultColdStart(multiarmedbandit.ColdStart{MinImpressions: 500})
+		return Run(env, selector)
+	}
+
+	for _, name := range []string{
+		multiarmedbandit.StrategyUCB1,
+		multiarmedbandit.StrategyEpsilonGreedy,
+		multiarmedbandit.StrategyThompson,
+		multiarmedbandit.StrategySoftmax,
+		multiarmedbandit.StrategyLinUCB,
+	} {
+		require.Equal(t, run(name, 7), run(name, 7), name)
+	}
+}
+
+func TestRunSingleBanner(t *testing.T) {
+	scenario := &Scenario{Rounds: 100, Groups: []ScenarioGroup{{ID: 1, CTR: []BannerCTR{{BannerID: 5, Value: 0.5}}}}}
+	env, err := NewScenarioEnvironment(scenario, 3)
+	require.NoError(t, err)
+
+	result := Run(env, newSelector(t, multiarmedbandit.StrategyThompson, 3))
+	require.Zero(t, result.Regret)
+	require.Equal(t, []ArmResult{{BannerID: 5, Picks: 100, Clicks: result.Reward, Share: 1}}, result.Arms)
+	require.InDelta(t, 50, result.Reward, 20)
+}
+
+func TestScenarioEnvironmentGroups(t *testing.T) {
+	env, err := NewScenarioEnvironment(testScenario(), 11)
+	require.NoError(t, err)
+
+	// Группа 1 получает вдвое больше трафика
+	counts := map[int]int{}
+	for i := 0; i < env.Len(); i++ {
+		counts[env.Group(i)]++
+	}
+	require.InDelta(t, 2.0/3, float64(counts[1])/float64(env.Len()), 0.03)
+
+	// Исход раунда задан одним случайным числом: клик по баннеру с меньшим CTR означает
+	// клик и по баннеру с большим
+	for i := 0; i < env.Len(); i++ {
+		low, high := 1, 2
+		if env.Group(i) == 2 {
+			low, high = 2, 1
+		}
+		clickedLow, ok := env.Outcome(i, low)
+		require.True(t, ok)
+		clickedHigh, _ := env.Outcome(i, high)
+		require.True(t, !clickedLow || clickedHigh)
+	}
+}
+
+func TestScenarioValidate(t *testing.T) {
+	require.NoError(t, testScenario().Validate())
+
+	for name, mutate := range map[string]func(s *Scenario){
+		"no rounds":        func(s *Scenario) { s.Rounds = 0 },
+		"no groups":        func(s *Scenario) { s.Groups = nil },
+		"duplicate group":  func(s *Scenario) { s.Groups[1].ID = 1 },
+		"negative weight":  func(s *Scenario) { s.Groups[0].Weight = -1 },
+		"ctr out of range": func(s *Scenario) { s.Groups[0].CTR[0].Value = 1.5 },
+		"duplicate banner": func(s *Scenario) { s.Groups[0].CTR[1].BannerID = 1 },
+		"other banners":    func(s *Scenario) { s.Groups[1].CTR[1].BannerID = 3 },
+		"missing banner":   func(s *Scenario) { s.Groups[1].CTR = s.Groups[1].CTR[:1] },
+	} {
+		scenario := testScenario()
+		mutate(scenario)
+		require.ErrorIs(t, scenario.Validate(), ErrInvalidScenario, name)
+	}
+}
+
+func TestLoadScenario(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "scenario.yaml")
+	content := `
+rounds: 10
+groups:
+  - id: 1
+    weight: 2
+    features:
+      Sport: 1
+    ctr:
+      - bannerID: 1
+        value: 0.1
+      - bannerID: 2
+        value: 0.2
+`
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	scenario, err := LoadScenario(file)
+	require.NoError(t, err)
+	require.Equal(t, &Scenario{
+		Rounds: 10,
+		Groups: []ScenarioGroup{{
+			ID:       1,
+			Weight:   2,
+			Features: map[string]float64{"sport": 1},
+			CTR:      []BannerCTR{{BannerID: 1, Value: 0.1}, {BannerID: 2, Value: 0.2}},
+		}},
+	}, scenario)
+
+	_, err = LoadScenario(filepath.Join("..", "..", "configs", "bandit_sim_scenario.yaml"))
+	require.NoError(t, err)
+}
@@ -7,13 +7,21 @@ import (
 var _ Configure = (*BannerConfig)(nil)
 
 type BannerConfig struct {
-	Logger   LoggerConf   `json:"logger"`
-	FilePath string       `json:"file_path"` //nolint:tagliatelle
-	Database DataBaseConf `json:"database"`
-	GRPC     GRPC         `json:"grpc"`
-	Storage  StorageConf  `json:"storage"`
-	RMQ      RMQ          `json:"rmq"`
-	Queues   struct {
+	Logger      LoggerConf      `json:"logger"`
+	FilePath    string          `json:"file_path"` //nolint:tagliatelle
+	Database    DataBaseConf    `json:"database"`
+	GRPC        GRPC            `json:"grpc"`
+	HTTP        HTTP            `json:"http"`
+	Storage     StorageConf     `json:"storage"`
+	StatsCache  StatsCacheConf  `json:"statsCache"`
+	Bandit      BanditConf      `json:"bandit"`
+	RMQ         RMQ             `json:"rmq"`
+	Outbox      OutboxConf      `json:"outbox"`
+	EventFeed   EventFeedConf   `json:"eventFeed"`
+	Impressions ImpressionsConf `json:"impressions"`
+	Clicks      ClicksConf      `json:"clicks"`
+	Frequency   FrequencyConf   `json:"frequency"`
+	Queues      struct {
 		Events Queue
 	}
 	Consumer Consumer
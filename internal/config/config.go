@@ -17,9 +17,18 @@ type LoggerConf struct {
 }
 
 type StorageConf struct {
+	Type      string `json:"type"` // memory | sql
 	Migration string `json:"migration"`
 }
 
+type StatsCacheConf struct {
+	Enabled        bool   `json:"enabled"`
+	Shards         int    `json:"shards"`
+	FlushInterval  string `json:"flushInterval"`
+	Fanout         bool   `json:"fanout"`
+	ResyncInterval string `json:"resyncInterval"`
+}
+
 type DataBaseConf struct {
 	Host     string `json:"host"`
 	Port     int    `json:"port"`
@@ -33,12 +42,45 @@ type GRPC struct {
 	Port int    `json:"port"`
 }
 
+type HTTP struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type BanditConf struct {
+	Strategy    string           `json:"strategy"`
+	Epsilon     float64          `json:"epsilon"`
+	Temperature float64          `json:"temperature"`
+	Alpha       float64          `json:"alpha"`
+	HalfLife    string           `json:"halfLife"`
+	Window      string           `json:"window"`
+	ColdStart   ColdStartConf    `json:"coldStart"`
+	Slots       []SlotBanditConf `json:"slots"`
+}
+
+type SlotBanditConf struct {
+	SlotID    int            `json:"slotID"`
+	Strategy  string         `json:"strategy"`
+	HalfLife  string         `json:"halfLife"`
+	Window    string         `json:"window"`
+	ColdStart *ColdStartConf `json:"coldStart"`
+}
+
+type ColdStartConf struct {
+	Prior          string  `json:"prior"` // slot | fixed
+	PriorAlpha     float64 `json:"priorAlpha"`
+	PriorBeta      float64 `json:"priorBeta"`
+	PriorStrength  float64 `json:"priorStrength"`
+	MinImpressions int64   `json:"minImpressions"`
+}
+
 type RMQ struct {
 	RabbitmqProtocol string `json:"rabbitmqProtocol"`
 	RabbitmqUsername string `json:"rabbitmqUsername"`
 	RabbitmqPassword string `json:"rabbitmqPassword"`
 	RabbitmqHost     string `json:"rabbitmqHost"`
 	RabbitmqPort     int    `json:"rabbitmqPort"`
+	ConfirmTimeout   string `json:"confirmTimeout"`
 	ReConnect        struct {
 		MaxElapsedTime  string  `json:"maxElapsedTime"`
 		InitialInterval string  `json:"initialInterval"`
@@ -47,6 +89,36 @@ type RMQ struct {
 	}
 }
 
+type OutboxConf struct {
+	Interval  string `json:"interval"`
+	Lease     string `json:"lease"`
+	BatchSize int    `json:"batchSize"`
+}
+
+type ImpressionsConf struct {
+	TokenSecret string `json:"tokenSecret"`
+	TokenTTL    string `json:"tokenTTL"`
+}
+
+type ClicksConf struct {
+	ImpressionDedupWindow string   `json:"impressionDedupWindow"`
+	ClientDedupWindow     string   `json:"clientDedupWindow"`
+	TrustedProxies        []string `json:"trustedProxies"`
+	RequireImpression     bool     `json:"requireImpression"`
+	ImpressionMaxAge      string   `json:"impressionMaxAge"`
+}
+
+type FrequencyConf struct {
+	MaxViews      int    `json:"maxViews"`
+	Period        string `json:"period"`
+	History       string `json:"history"` // memory
+	EvictInterval string `json:"evictInterval"`
+}
+
+type EventFeedConf struct {
+	BufferSize int `json:"bufferSize"`
+}
+
 type Queue struct {
 	ExchangeName string `json:"exchangeName"`
 	ExchangeType string `json:"exchangeType"`
@@ -58,6 +130,9 @@ type Consumer struct {
 	ConsumerTag      string  `json:"consumerTag"`
 	QosPrefetchCount float64 `json:"qosPrefetchCount"`
 	Threads          float64 `json:"threads"`
+	// Сколько хранить отметки учтенных сообщений; больше окна повторной доставки брокера.
+	DedupTTL           string `json:"dedupTTL"`
+	DedupPurgeInterval string `json:"dedupPurgeInterval"`
 }
 
 func Init(file string, c Configure) (Configure, error) {
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+)
+
+var _ Configure = (*StatsConsumerConfig)(nil)
+
+type StatsConsumerConfig struct {
+	Logger   LoggerConf   `json:"logger"`
+	Database DataBaseConf `json:"database"`
+	RMQ      RMQ          `json:"rmq"`
+	Queues   struct {
+		Events Queue
+	}
+	Consumer Consumer
+}
+
+func (s *StatsConsumerConfig) Init(file string) error {
+	cfg, err := Init(file, s)
+
+	_, ok := cfg.(*StatsConsumerConfig)
+	if !ok {
+		return errors.Wrap(err, "init config failed")
+	}
+
+	return nil
+}
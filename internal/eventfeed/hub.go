@@ -0,0 +1,138 @@
+package eventfeed
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+// DefaultBufferSize - размер очереди подписчика, если в конфигурации он не задан.
+const DefaultBufferSize = 256
+
+// Filter отбирает события для подписчика. Нулевые поля означают "любое значение".
+type Filter struct {
+	SlotID      int
+	BannerID    int
+	UserGroupID int
+	TypeEvent   string
+}
+
+func (f Filter) Match(n storage.Notification) bool {
+	switch {
+	case f.SlotID != 0 && n.SlotID != f.SlotID:
+		return false
+	case f.BannerID != 0 && n.BannerID != f.BannerID:
+		return false
+	case f.UserGroupID != 0 && n.UsergroupID != f.UserGroupID:
+		return false
+	case f.TypeEvent != "" && n.TypeEvent != f.TypeEvent:
+		return false
+	}
+	return true
+}
+
+// Event - уведомление для подписчика. Dropped - сколько событий подписчик пропустил перед этим,
+// потому что не успевал их читать.
+type Event struct {
+	storage.Notification
+	Dropped uint64
+}
+
+// Hub рассылает уведомления подписчикам. Publish никогда не блокируется:
+// если очередь подписчика заполнена, событие для него отбрасывается и учитывается в Dropped.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	bufferSize  int
+	closed      bool
+}
+
+func NewHub(bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Hub{
+		subscribers: make(map[*Subscription]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Publish отправляет уведомление подходящим подписчикам. Для nil-хаба ничего не делает.
+func (h *Hub) Publish(n storage.Notification) {
+	if h == nil {
+		return
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers {
+		if sub.filter.Match(n) {
+			sub.offer(n)
+		}
+	}
+}
+
+// Subscribe регистрирует подписчика. После Close хаба возвращает уже закрытую подписку.
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	sub := &Subscription{
+		hub:    h,
+		filter: filter,
+		events: make(chan Event, h.bufferSize),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(sub.events)
+		return sub
+	}
+	h.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Close закрывает все подписки, чтобы потоковые RPC завершились перед остановкой сервера.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+type Subscription struct {
+	hub     *Hub
+	filter  Filter
+	events  chan Event
+	dropped atomic.Uint64
+}
+
+// Events возвращает канал событий. Канал закрывается при Close подписки или хаба.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if _, ok := s.hub.subscribers[s]; !ok {
+		return
+	}
+	delete(s.hub.subscribers, s)
+	close(s.events)
+}
+
+// offer кладет событие в очередь без ожидания. Вызывается под блокировкой хаба на чтение.
+func (s *Subscription) offer(n storage.Notification) {
+	dropped := s.dropped.Swap(0)
+	select {
+	case s.events <- Event{Notification: n, Dropped: dropped}:
+	default:
+		s.dropped.Add(dropped + 1)
+	}
+}
@@ -0,0 +1,72 @@
+package eventfeed
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHubFilters(t *testing.T) {
+	hub := NewHub(10)
+	clicks := hub.Subscribe(Filter{TypeEvent: storage.EventClick})
+	slot2 := hub.Subscribe(Filter{SlotID: 2, UserGroupID: 1})
+	defer clicks.Close()
+	defer slot2.Close()
+
+	hub.Publish(storage.Notification{TypeEvent: storage.EventImpress, SlotID: 1, BannerID: 1, UsergroupID: 1})
+	hub.Publish(storage.Notification{TypeEvent: storage.EventClick, SlotID: 1, BannerID: 1, UsergroupID: 1})
+	hub.Publish(storage.Notification{TypeEvent: storage.EventImpress, SlotID: 2, BannerID: 3, UsergroupID: 1})
+	hub.Publish(storage.Notification{TypeEvent: storage.EventImpress, SlotID: 2, BannerID: 3, UsergroupID: 2})
+
+	require.Len(t, clicks.Events(), 1)
+	require.Equal(t, 1, (<-clicks.Events()).SlotID)
+
+	require.Len(t, slot2.Events(), 1)
+	require.Equal(t, 3, (<-slot2.Events()).BannerID)
+}
+
+func TestHubDropsForSlowSubscriber(t *testing.T) {
+	hub := NewHub(2)
+	slow := hub.Subscribe(Filter{})
+	defer slow.Close()
+
+	for i := 1; i <= 5; i++ {
+		hub.Publish(storage.Notification{TypeEvent: storage.EventImpress, BannerID: i})
+	}
+
+	// Первые два события в очереди, остальные три отброшены
+	require.Equal(t, 1, (<-slow.Events()).BannerID)
+	require.Equal(t, 2, (<-slow.Events()).BannerID)
+
+	hub.Publish(storage.Notification{TypeEvent: storage.EventImpress, BannerID: 6})
+	event := <-slow.Events()
+	require.Equal(t, 6, event.BannerID)
+	require.Equal(t, uint64(3), event.Dropped)
+}
+
+func TestHubClose(t *testing.T) {
+	hub := NewHub(1)
+	sub := hub.Subscribe(Filter{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			hub.Publish(storage.Notification{TypeEvent: storage.EventClick})
+		}
+	}()
+	hub.Close()
+	wg.Wait()
+
+	// Канал закрыт, повторные Close безопасны
+	for range sub.Events() {
+	}
+	sub.Close()
+	hub.Close()
+
+	_, ok := <-hub.Subscribe(Filter{}).Events()
+	require.False(t, ok)
+}
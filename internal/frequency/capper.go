@@ -0,0 +1,85 @@
+package frequency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// History - история просмотров баннеров пользователями. Реализация сама удаляет
+// просмотры старше срока хранения, поэтому может быть внешней (например, Redis).
+type History interface {
+	AddView(ctx context.Context, userKey string, bannerID int, at time.Time) error
+	// Views возвращает число просмотров каждого баннера пользователем начиная с since.
+	Views(ctx context.Context, userKey string, since time.Time) (map[int]int, error)
+}
+
+// Cap - не больше MaxViews показов баннера одному пользователю за Period.
+type Cap struct {
+	MaxViews int
+	Period   time.Duration
+}
+
+// NewCap разбирает ограничение из конфигурации. Нулевое maxViews отключает ограничение.
+func NewCap(maxViews int, period string) (Cap, error) {
+	if maxViews < 0 {
+		return Cap{}, fmt.Errorf("max views must not be negative, got %d", maxViews)
+	}
+	if maxViews == 0 {
+		return Cap{}, nil
+	}
+
+	periodDur, err := time.ParseDuration(period)
+	if err != nil {
+		return Cap{}, fmt.Errorf("frequency period parsing fail (%s): %w", period, err)
+	}
+	if periodDur <= 0 {
+		return Cap{}, errors.New("frequency period must be positive")
+	}
+	return Cap{MaxViews: maxViews, Period: periodDur}, nil
+}
+
+// Capper отбирает баннеры, которые пользователь уже видел слишком часто.
+// Для nil-значения ограничение не действует.
+type Capper struct {
+	limit   Cap
+	history History
+}
+
+// NewCapper возвращает nil, если ограничение не задано.
+func NewCapper(limit Cap, history History) *Capper {
+	if limit.MaxViews == 0 {
+		return nil
+	}
+	return &Capper{limit: limit, history: history}
+}
+
+// Excluded возвращает баннеры, исчерпавшие ограничение для пользователя к моменту now.
+// Пустой userKey означает анонимного пользователя без ограничения.
+func (c *Capper) Excluded(ctx context.Context, userKey string, now time.Time) (map[int]struct{}, error) {
+	if c == nil || userKey == "" {
+		return nil, nil
+	}
+
+	views, err := c.history.Views(ctx, userKey, now.Add(-c.limit.Period))
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[int]struct{})
+	for bannerID, count := range views {
+		if count >= c.limit.MaxViews {
+			excluded[bannerID] = struct{}{}
+		}
+	}
+	return excluded, nil
+}
+
+// AddView учитывает подтвержденный показ баннера пользователю.
+func (c *Capper) AddView(ctx context.Context, userKey string, bannerID int, at time.Time) error {
+	if c == nil || userKey == "" {
+		return nil
+	}
+	return c.history.AddView(ctx, userKey, bannerID, at)
+}
@@ -0,0 +1,73 @@
+package frequency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCap(t *testing.T) {
+	limit, err := NewCap(3, "24h")
+	require.NoError(t, err)
+	require.Equal(t, Cap{MaxViews: 3, Period: 24 * time.Hour}, limit)
+
+	limit, err = NewCap(0, "")
+	require.NoError(t, err)
+	require.Nil(t, NewCapper(limit, nil))
+
+	_, err = NewCap(-1, "24h")
+	require.Error(t, err)
+	_, err = NewCap(3, "0s")
+	require.Error(t, err)
+	_, err = NewCap(3, "day")
+	require.Error(t, err)
+}
+
+func TestCapperExcluded(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	capper := NewCapper(Cap{MaxViews: 2, Period: time.Hour}, NewMemoryHistory(time.Hour))
+
+	require.NoError(t, capper.AddView(ctx, "user-1", 1, now.Add(-2*time.Hour)))
+	require.NoError(t, capper.AddView(ctx, "user-1", 1, now.Add(-time.Minute)))
+	require.NoError(t, capper.AddView(ctx, "user-1", 2, now.Add(-time.Minute)))
+	require.NoError(t, capper.AddView(ctx, "user-1", 2, now))
+	require.NoError(t, capper.AddView(ctx, "user-2", 1, now))
+	require.NoError(t, capper.AddView(ctx, "user-2", 1, now))
+
+	// Просмотр двухчасовой давности не учитывается
+	excluded, err := capper.Excluded(ctx, "user-1", now)
+	require.NoError(t, err)
+	require.Equal(t, map[int]struct{}{2: {}}, excluded)
+
+	excluded, err = capper.Excluded(ctx, "", now)
+	require.NoError(t, err)
+	require.Empty(t, excluded)
+
+	var disabled *Capper
+	require.NoError(t, disabled.AddView(ctx, "user-1", 1, now))
+	excluded, err = disabled.Excluded(ctx, "user-1", now)
+	require.NoError(t, err)
+	require.Empty(t, excluded)
+}
+
+func TestMemoryHistoryEvict(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	history := NewMemoryHistory(time.Hour)
+
+	require.NoError(t, history.AddView(ctx, "user-1", 1, now))
+	require.NoError(t, history.AddView(ctx, "user-2", 1, now.Add(-30*time.Minute)))
+	require.NoError(t, history.AddView(ctx, "user-2", 2, now))
+
+	history.Evict(now.Add(45 * time.Minute))
+	require.Len(t, history.views, 2)
+	views, err := history.Views(ctx, "user-2", time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, map[int]int{2: 1}, views)
+
+	history.Evict(now.Add(2 * time.Hour))
+	require.Empty(t, history.views)
+}
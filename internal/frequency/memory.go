@@ -0,0 +1,101 @@
+package frequency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ History = (*MemoryHistory)(nil)
+
+// MemoryHistory хранит просмотры в памяти процесса. Просмотры старше ttl удаляются
+// при обращении к пользователю и периодически в Run.
+type MemoryHistory struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	views map[string]map[int][]time.Time
+}
+
+func NewMemoryHistory(ttl time.Duration) *MemoryHistory {
+	return &MemoryHistory{
+		ttl:   ttl,
+		views: make(map[string]map[int][]time.Time),
+	}
+}
+
+func (h *MemoryHistory) AddView(ctx context.Context, userKey string, bannerID int, at time.Time) error {
+	_ = ctx
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	banners, ok := h.views[userKey]
+	if !ok {
+		banners = make(map[int][]time.Time)
+		h.views[userKey] = banners
+	}
+	banners[bannerID] = append(banners[bannerID], at)
+	h.evictUser(userKey, at)
+	return nil
+}
+
+func (h *MemoryHistory) Views(ctx context.Context, userKey string, since time.Time) (map[int]int, error) {
+	_ = ctx
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	views := make(map[int]int)
+	for bannerID, times := range h.views[userKey] {
+		for _, at := range times {
+			if !at.Before(since) {
+				views[bannerID]++
+			}
+		}
+	}
+	return views, nil
+}
+
+// Evict удаляет просмотры старше ttl на момент now.
+func (h *MemoryHistory) Evict(now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for userKey := range h.views {
+		h.evictUser(userKey, now)
+	}
+}
+
+// Run периодически удаляет устаревшие просмотры до отмены контекста.
+func (h *MemoryHistory) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			h.Evict(now)
+		}
+	}
+}
+
+// evictUser удаляет устаревшие просмотры пользователя. Вызывается под блокировкой.
+func (h *MemoryHistory) evictUser(userKey string, now time.Time) {
+	from := now.Add(-h.ttl)
+	banners := h.views[userKey]
+	for bannerID, times := range banners {
+		// Просмотры добавляются по времени, поэтому устаревшие находятся в начале
+		i := 0
+		for i < len(times) && times[i].Before(from) {
+			i++
+		}
+		if i == len(times) {
+			delete(banners, bannerID)
+			continue
+		}
+		banners[bannerID] = times[i:]
+	}
+	if len(banners) == 0 {
+		delete(h.views, userKey)
+	}
+}
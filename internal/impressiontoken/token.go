@@ -0,0 +1,131 @@
+package impressiontoken
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformed = errors.New("malformed impression token")
+	ErrSignature = errors.New("invalid impression token signature")
+	ErrExpired   = errors.New("impression token has expired")
+)
+
+// Claims - данные выбора баннера, подписанные в токене показа.
+type Claims struct {
+	ID          string    `json:"id"`
+	SlotID      int       `json:"slot_id"`      //nolint:tagliatelle
+	BannerID    int       `json:"banner_id"`    //nolint:tagliatelle
+	UserGroupID int       `json:"usergroup_id"` //nolint:tagliatelle
+	ExpiresAt   time.Time `json:"expires_at"`   //nolint:tagliatelle
+	// UserKey - пользователь, которому выбран баннер; по нему учитывается частота показов.
+	UserKey string `json:"user_key,omitempty"` //nolint:tagliatelle
+}
+
+// Signer выдает и проверяет токены показа вида base64(claims).base64(hmac-sha256).
+type Signer struct {
+	key []byte
+	ttl time.Duration
+}
+
+func NewSigner(key []byte, ttl string) (*Signer, error) {
+	if len(key) == 0 {
+		return nil, errors.New("impression token key must not be empty")
+	}
+
+	ttlDur, err := time.ParseDuration(ttl)
+	if err != nil {
+		return nil, fmt.Errorf("impression token ttl parsing fail (%s): %w", ttl, err)
+	}
+	if ttlDur <= 0 {
+		return nil, fmt.Errorf("impression token ttl must be positive (%s)", ttl)
+	}
+
+	return &Signer{key: key, ttl: ttlDur}, nil
+}
+
+// RandomKey возвращает случайный ключ. Токены, подписанные им, недействительны
+// после перезапуска и на других экземплярах сервиса.
+func RandomKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Issue выдает токен для выбранного баннера со сроком подтверждения ttl. userKey необязателен.
+func (s *Signer) Issue(slotID, bannerID, userGroupID int, userKey string, now time.Time) (string, Claims, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", Claims{}, err
+	}
+
+	claims := Claims{
+		ID:          hex.EncodeToString(id),
+		SlotID:      slotID,
+		BannerID:    bannerID,
+		UserGroupID: userGroupID,
+		ExpiresAt:   now.Add(s.ttl).UTC(),
+		UserKey:     userKey,
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", Claims{}, err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), claims, nil
+}
+
+// Parse проверяет подпись токена и возвращает его данные без проверки срока.
+func (s *Signer) Parse(token string) (Claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Claims{}, ErrMalformed
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	if !hmac.Equal(sig, s.sign(encoded)) {
+		return Claims{}, ErrSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, ErrMalformed
+	}
+	return claims, nil
+}
+
+// Verify проверяет подпись и срок подтверждения токена.
+func (s *Signer) Verify(token string, now time.Time) (Claims, error) {
+	claims, err := s.Parse(token)
+	if err != nil {
+		return Claims{}, err
+	}
+	if !now.Before(claims.ExpiresAt) {
+		return Claims{}, ErrExpired
+	}
+	return claims, nil
+}
+
+func (s *Signer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,60 @@
+package impressiontoken
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueAndVerify(t *testing.T) {
+	signer, err := NewSigner([]byte("secret"), "15m")
+	require.NoError(t, err)
+
+	now := time.Now()
+	token, claims, err := signer.Issue(1, 2, 3, "user-1", now)
+	require.NoError(t, err)
+
+	got, err := signer.Verify(token, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, claims.ID, got.ID)
+	require.Equal(t, 1, got.SlotID)
+	require.Equal(t, 2, got.BannerID)
+	require.Equal(t, 3, got.UserGroupID)
+	require.Equal(t, "user-1", got.UserKey)
+
+	// Истекший токен не подтверждает показ, но его подпись остается действительной
+	_, err = signer.Verify(token, now.Add(16*time.Minute))
+	require.ErrorIs(t, err, ErrExpired)
+	_, err = signer.Parse(token)
+	require.NoError(t, err)
+
+	other, _, err := signer.Issue(1, 2, 3, "", now)
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+}
+
+func TestVerifyRejectsForgedToken(t *testing.T) {
+	signer, err := NewSigner([]byte("secret"), "15m")
+	require.NoError(t, err)
+	forger, err := NewSigner([]byte("another secret"), "15m")
+	require.NoError(t, err)
+
+	now := time.Now()
+	token, _, err := forger.Issue(1, 2, 3, "", now)
+	require.NoError(t, err)
+
+	_, err = signer.Verify(token, now)
+	require.ErrorIs(t, err, ErrSignature)
+
+	_, err = signer.Verify("garbage", now)
+	require.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestNewSignerValidation(t *testing.T) {
+	_, err := NewSigner(nil, "15m")
+	require.Error(t, err)
+
+	_, err = NewSigner([]byte("secret"), "soon")
+	require.Error(t, err)
+}
@@ -0,0 +1,154 @@
+package multiarmedbandit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Источники априорного распределения CTR.
+const (
+	// PriorNone - без априорного распределения.
+	PriorNone = ""
+	// PriorSlot - среднее CTR кандидатов слота с весом Strength показов.
+	PriorSlot = "slot"
+	// PriorFixed - явно заданное Beta(Alpha, Beta).
+	PriorFixed = "fixed"
+)
+
+// defaultPriorStrength - сколько показов весит среднее слота, если Strength не задан.
+const defaultPriorStrength = 10
+
+// ColdStart задает, как стратегия обращается с новыми баннерами. Нулевое значение - без
+// априорного распределения и обязательного исследования.
+type ColdStart struct {
+	Prior Prior
+	// MinImpressions - сколько подтвержденных показов получает баннер в слоте, прежде чем
+	// конкурировать с остальными. Пока показов меньше, баннер получает долю 1/N выборов слота
+	// (N - число кандидатов) в обход стратегии. Подтверждения приходят с задержкой, поэтому
+	// доля ограничена: иначе до первого подтверждения новый баннер забирал бы весь трафик.
+	MinImpressions int64
+}
+
+// Prior - априорное Beta-распределение CTR. Его параметры добавляются к счетчикам каждого
+// баннера как псевдо-клики (Alpha) и псевдо-показы без клика (Beta), поэтому баннер без показов
+// оценивается средним, а не нулем или единицей.
+type Prior struct {
+	Source   string
+	Alpha    float64
+	Beta     float64
+	Strength float64
+}
+
+// NewColdStart разбирает настройки холодного старта из конфигурации.
+func NewColdStart(prior string, alpha, beta, strength float64, minImpressions int64) (ColdStart, error) {
+	coldStart := ColdStart{
+		Prior:          Prior{Source: prior, Alpha: alpha, Beta: beta, Strength: strength},
+		MinImpressions: minImpressions,
+	}
+	switch prior {
+	case PriorNone:
+	case PriorSlot:
+		if strength < 0 {
+			return ColdStart{}, fmt.Errorf("negative prior strength %v", strength)
+		}
+	case PriorFixed:
+		if alpha < 0 || beta < 0 || alpha+beta == 0 {
+			return ColdStart{}, errors.New("fixed prior requires non-negative alpha and beta with positive sum")
+		}
+	default:
+		return ColdStart{}, fmt.Errorf("unknown prior: %q", prior)
+	}
+	if minImpressions < 0 {
+		return ColdStart{}, fmt.Errorf("negative min impressions %d", minImpressions)
+	}
+	return coldStart, nil
+}
+
+// IsZero сообщает, что холодный старт не настроен.
+func (c ColdStart) IsZero() bool {
+	return c.Prior.Source == PriorNone && c.MinImpressions == 0
+}
+
+// Apply возвращает баннеры со счетчиками, к которым добавлено априорное распределение.
+// Исходные баннеры не меняются.
+func (p Prior) Apply(banners []Banner) []Banner {
+	alpha, beta := p.Alpha, p.Beta
+	switch p.Source {
+	case PriorFixed:
+	case PriorSlot:
+		var impressions, clicks float64
+		for _, b := range banners {
+			impressions += b.GetImpressions()
+			clicks += b.GetClicks()
+		}
+		// У слота еще нет показов - среднего нет
+		if impressions == 0 {
+			return banners
+		}
+		strength := p.Strength
+		if strength == 0 {
+			strength = defaultPriorStrength
+		}
+		mean := clicks / impressions
+		alpha, beta = mean*strength, (1-mean)*strength
+	default:
+		return banners
+	}
+
+	withPrior := make([]Banner, 0, len(banners))
+	for _, b := range banners {
+		if c, ok := b.(*Counts); ok {
+			counts := *c
+			counts.Impressions += alpha + beta
+			counts.Clicks += alpha
+			withPrior = append(withPrior, &counts)
+			continue
+		}
+		withPrior = append(withPrior, priorBanner{Banner: b, alpha: alpha, beta: beta})
+	}
+	return withPrior
+}
+
+// priorBanner добавляет априорное распределение к счетчикам произвольного баннера.
+type priorBanner struct {
+	Banner
+	alpha float64
+	beta  float64
+}
+
+func (p priorBanner) GetImpressions() float64 {
+	return p.Banner.GetImpressions() + p.alpha + p.beta
+}
+
+func (p priorBanner) GetClicks() float64 {
+	return p.Banner.GetClicks() + p.alpha
+}
+
+// ServedBanner - баннер, для которого известно число подтвержденных показов в слоте по всем группам.
+type ServedBanner interface {
+	GetServed() int64
+}
+
+// pickExploring с вероятностью 1/len(banners) для каждого баннера, еще не получившего
+// minImpressions показов, выбирает этот баннер; иначе выбор остается за стратегией.
+func pickExploring(banners []Banner, minImpressions int64, rnd *lockedRand) (int, bool) {
+	if minImpressions <= 0 || len(banners) == 0 {
+		return 0, false
+	}
+
+	exploring := make([]int, 0)
+	for _, b := range banners {
+		sb, ok := b.(ServedBanner)
+		if ok && sb.GetServed() < minImpressions {
+			exploring = append(exploring, b.GetID())
+		}
+	}
+	if len(exploring) == 0 {
+		return 0, false
+	}
+
+	if i := rnd.Intn(len(banners)); i < len(exploring) {
+		return exploring[i], true
+	}
+	return 0, false
+}
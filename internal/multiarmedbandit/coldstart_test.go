@@ -0,0 +1,98 @@
+package multiarmedbandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewColdStart(t *testing.T) {
+	coldStart, err := NewColdStart(PriorNone, 0, 0, 0, 0)
+	require.NoError(t, err)
+	require.True(t, coldStart.IsZero())
+
+	coldStart, err = NewColdStart(PriorFixed, 1, 99, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, ColdStart{Prior: Prior{Source: PriorFixed, Alpha: 1, Beta: 99}, MinImpressions: 100}, coldStart)
+
+	_, err = NewColdStart(PriorSlot, 0, 0, 20, 0)
+	require.NoError(t, err)
+
+	for _, invalid := range []struct {
+		prior                 string
+		alpha, beta, strength float64
+		minImpressions        int64
+	}{
+		{prior: "median"},
+		{prior: PriorFixed},
+		{prior: PriorFixed, alpha: -1, beta: 2},
+		{prior: PriorSlot, strength: -1},
+		{minImpressions: -1},
+	} {
+		_, err := NewColdStart(invalid.prior, invalid.alpha, invalid.beta, invalid.strength, invalid.minImpressions)
+		require.Error(t, err, "%+v", invalid)
+	}
+}
+
+func TestPriorApply(t *testing.T) {
+	banners := []Banner{
+		&Counts{BannerID: 1, Impressions: 90, Clicks: 9},
+		&bnr{ID: 2, impressions: 10, clicks: 1},
+	}
+
+	// Среднее слота 10% с весом 20 показов
+	withPrior := Prior{Source: PriorSlot, Strength: 20}.Apply(banners)
+	require.InDelta(t, 110, withPrior[0].GetImpressions(), 1e-9)
+	require.InDelta(t, 11, withPrior[0].GetClicks(), 1e-9)
+	require.InDelta(t, 30, withPrior[1].GetImpressions(), 1e-9)
+	require.InDelta(t, 3, withPrior[1].GetClicks(), 1e-9)
+	require.Equal(t, 2, withPrior[1].GetID())
+	// Исходные счетчики не меняются
+	require.Equal(t, float64(90), banners[0].GetImpressions())
+
+	withPrior = Prior{Source: PriorFixed, Alpha: 1, Beta: 99}.Apply(banners)
+	require.InDelta(t, 190, withPrior[0].GetImpressions(), 1e-9)
+	require.InDelta(t, 10, withPrior[0].GetClicks(), 1e-9)
+
+	// Без показов в слоте среднего нет
+	empty := []Banner{&Counts{BannerID: 1}}
+	require.Equal(t, empty, Prior{Source: PriorSlot}.Apply(empty))
+	require.Equal(t, banners, Prior{}.Apply(banners))
+}
+
+func TestSelectorColdStart(t *testing.T) {
+	selector := NewSelector(NewEpsilonGreedy(0, rand.NewSource(1)))
+	banners := []Banner{
+		&Counts{BannerID: 1, Impressions: 1000, Clicks: 50, Served: 1000},
+		&Counts{BannerID: 2},
+	}
+
+	// Жадная стратегия отдает весь трафик баннеру без показов
+	require.Equal(t, 2, selector.Pick(1, banners))
+
+	// С априорным CTR 1% новый баннер проигрывает баннеру с CTR 5%
+	selector.SetDefaultColdStart(ColdStart{Prior: Prior{Source: PriorFixed, Alpha: 1, Beta: 99}})
+	require.Equal(t, 1, selector.Pick(1, banners))
+
+	// Пока новый баннер не набрал обязательные показы, он получает долю 1/N трафика слота,
+	// даже если стратегия его не выбирает
+	selector.SetSlotColdStart(2, ColdStart{Prior: Prior{Source: PriorFixed, Alpha: 1, Beta: 99}, MinImpressions: 10})
+	banners = append(banners,
+		&Counts{BannerID: 3, Impressions: 1000, Clicks: 10, Served: 1000},
+		&Counts{BannerID: 4, Impressions: 1000, Clicks: 10, Served: 1000})
+	const picks = 4000
+	explored := 0
+	for i := 0; i < picks; i++ {
+		if selector.Pick(2, banners) == 2 {
+			explored++
+		}
+	}
+	require.InDelta(t, 0.25, float64(explored)/picks, 0.05)
+
+	banners[1] = &Counts{BannerID: 2, Impressions: 10, Served: 10}
+	for i := 0; i < 100; i++ {
+		require.Equal(t, 1, selector.Pick(2, banners))
+	}
+	require.Equal(t, ColdStart{}, (*Selector)(nil).ColdStartForSlot(2))
+}
@@ -0,0 +1,104 @@
+package multiarmedbandit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Decay задает, как стратегия забывает старую статистику, чтобы ротация успевала
+// за меняющимися предпочтениями. Нулевое значение - учитывается вся история.
+type Decay struct {
+	// HalfLife - период, за который вес показа или перехода уменьшается вдвое.
+	HalfLife time.Duration
+	// Window - учитываются только события за этот период.
+	Window time.Duration
+}
+
+// NewDecay разбирает настройки забывания из конфигурации. Пустая строка не задает параметр;
+// период полураспада и окно взаимоисключающие.
+func NewDecay(halfLife, window string) (Decay, error) {
+	var (
+		decay Decay
+		err   error
+	)
+	if decay.HalfLife, err = parsePeriod(halfLife); err != nil {
+		return Decay{}, fmt.Errorf("invalid half-life: %w", err)
+	}
+	if decay.Window, err = parsePeriod(window); err != nil {
+		return Decay{}, fmt.Errorf("invalid window: %w", err)
+	}
+	if decay.HalfLife > 0 && decay.Window > 0 {
+		return Decay{}, errors.New("half-life and window are mutually exclusive")
+	}
+	return decay, nil
+}
+
+func parsePeriod(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	period, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if period < 0 {
+		return 0, fmt.Errorf("negative period %s", value)
+	}
+	return period, nil
+}
+
+// IsZero сообщает, что учитывается вся история.
+func (d Decay) IsZero() bool {
+	return d.HalfLife == 0 && d.Window == 0
+}
+
+// Factor возвращает вес события, случившегося elapsed назад. Без периода полураспада вес равен 1.
+func (d Decay) Factor(elapsed time.Duration) float64 {
+	if d.HalfLife <= 0 || elapsed <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(elapsed) / float64(d.HalfLife))
+}
+
+// Counts - счетчики баннера, которые могут быть дробными после затухания, и условия его показа.
+type Counts struct {
+	BannerID    int
+	Impressions float64
+	Clicks      float64
+	Priority    Priority
+	// Served - подтвержденные показы баннера в слоте по всем группам за все время (см. ColdStart).
+	Served int64
+	// Context и Observations заполняются для контекстной стратегии (см. AttachContext).
+	Context      []float64
+	Observations []Observation
+}
+
+func (c *Counts) GetID() int {
+	return c.BannerID
+}
+
+func (c *Counts) GetImpressions() float64 {
+	return c.Impressions
+}
+
+func (c *Counts) GetClicks() float64 {
+	return c.Clicks
+}
+
+func (c *Counts) GetPriority() Priority {
+	return c.Priority
+}
+
+func (c *Counts) GetServed() int64 {
+	return c.Served
+}
+
+func (c *Counts) GetContext() []float64 {
+	return c.Context
+}
+
+func (c *Counts) GetObservations() []Observation {
+	return c.Observations
+}
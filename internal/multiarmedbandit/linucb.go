@@ -0,0 +1,244 @@
+package multiarmedbandit
+
+import (
+	"math"
+	"sort"
+)
+
+const StrategyLinUCB = "linucb"
+
+// defaultAlpha - ширина доверительной границы LinUCB, если в конфигурации она не задана.
+const defaultAlpha = 1.0
+
+// Observation - показы и переходы баннера в группе пользователей с признаками Features.
+type Observation struct {
+	Features    []float64
+	Impressions float64
+	Clicks      float64
+}
+
+// ContextBanner - баннер с признаками группы, для которой выбирается баннер, и статистикой
+// этого баннера во всех группах слота.
+type ContextBanner interface {
+	Banner
+	GetContext() []float64
+	GetObservations() []Observation
+}
+
+// LinUCB - контекстная стратегия: CTR баннера моделируется линейной функцией признаков группы,
+// поэтому статистика похожих групп помогает новой или малой группе. Модель баннера
+// (A = I + sum(n*x*x^T), b = sum(c*x)) строится по накопленным счетчикам всех групп при каждом выборе.
+// Без признаков группы выбор делает UCB1.
+type LinUCB struct {
+	alpha float64
+}
+
+// NewLinUCB создает стратегию с шириной доверительной границы alpha; 0 - значение по умолчанию.
+func NewLinUCB(alpha float64) *LinUCB {
+	if alpha <= 0 {
+		alpha = defaultAlpha
+	}
+	return &LinUCB{alpha: alpha}
+}
+
+func (l *LinUCB) Name() string {
+	return StrategyLinUCB
+}
+
+func (l *LinUCB) Pick(banners []Banner) int {
+	var (
+		bestScore        = math.Inf(-1)
+		selectedBannerID = 0
+	)
+	for _, b := range banners {
+		cb, ok := b.(ContextBanner)
+		if !ok || len(cb.GetContext()) == 0 {
+			return UCB1{}.Pick(banners)
+		}
+		if score := l.score(cb); score > bestScore {
+			bestScore = score
+			selectedBannerID = b.GetID()
+		}
+	}
+	return selectedBannerID
+}
+
+// score возвращает верхнюю границу ожидаемого CTR баннера для признаков x: theta*x + alpha*sqrt(x*A^-1*x).
+func (l *LinUCB) score(b ContextBanner) float64 {
+	x := b.GetContext()
+	d := len(x)
+
+	a := identity(d)
+	r := make([]float64, d)
+	for _, obs := range b.GetObservations() {
+		if len(obs.Features) != d {
+			continue
+		}
+		for i := 0; i < d; i++ {
+			r[i] += obs.Clicks * obs.Features[i]
+			for j := 0; j < d; j++ {
+				a[i][j] += obs.Impressions * obs.Features[i] * obs.Features[j]
+			}
+		}
+	}
+
+	chol, ok := cholesky(a)
+	if !ok {
+		return math.Inf(-1)
+	}
+	theta := chol.solve(r)
+	z := chol.solve(x)
+	return dot(theta, x) + l.alpha*math.Sqrt(math.Max(dot(x, z), 0))
+}
+
+// FeatureSpace упорядочивает имена признаков групп, чтобы превращать их в векторы одной длины.
+type FeatureSpace struct {
+	names []string
+}
+
+// NewFeatureSpace собирает имена признаков всех групп.
+func NewFeatureSpace(features map[int]map[string]float64) FeatureSpace {
+	seen := make(map[string]struct{})
+	for _, groupFeatures := range features {
+		for name := range groupFeatures {
+			seen[name] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return FeatureSpace{names: names}
+}
+
+// Vector возвращает вектор признаков группы. Первая координата - свободный член, поэтому
+// группы без признаков разделяют общую для слота модель. Незаданные признаки равны 0.
+func (fs FeatureSpace) Vector(features map[string]float64) []float64 {
+	vector := make([]float64, len(fs.names)+1)
+	vector[0] = 1
+	for i, name := range fs.names {
+		vector[i+1] = features[name]
+	}
+	return vector
+}
+
+// GroupStats - счетчики баннера в одной группе пользователей.
+type GroupStats struct {
+	UserGroupID int
+	Impressions float64
+	Clicks      float64
+}
+
+// AttachContext добавляет баннерам признаки группы userGroupID и статистику всех групп слота
+// stats[bannerID] для контекстной стратегии.
+func AttachContext(
+	banners []Banner,
+	userGroupID int,
+	features map[int]map[string]float64,
+	stats map[int][]GroupStats,
+) {
+	space := NewFeatureSpace(features)
+	vectors := make(map[int][]float64, len(features))
+	vector := func(groupID int) []float64 {
+		v, ok := vectors[groupID]
+		if !ok {
+			v = space.Vector(features[groupID])
+			vectors[groupID] = v
+		}
+		return v
+	}
+
+	context := vector(userGroupID)
+	for _, b := range banners {
+		c, ok := b.(*Counts)
+		if !ok {
+			continue
+		}
+		c.Context = context
+		c.Observations = make([]Observation, 0, len(stats[c.BannerID]))
+		for _, st := range stats[c.BannerID] {
+			c.Observations = append(c.Observations, Observation{
+				Features:    vector(st.UserGroupID),
+				Impressions: st.Impressions,
+				Clicks:      st.Clicks,
+			})
+		}
+	}
+}
+
+// NeedsContext сообщает, нужны ли стратегии слота признаки групп и статистика всех групп.
+func (s *Selector) NeedsContext(slotID int) bool {
+	_, ok := s.ForSlot(slotID).(*LinUCB)
+	return ok
+}
+
+func identity(d int) [][]float64 {
+	m := make([][]float64, d)
+	for i := range m {
+		m[i] = make([]float64, d)
+		m[i][i] = 1
+	}
+	return m
+}
+
+func dot(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// choleskyFactor - нижнетреугольная L, для которой A = L*L^T.
+type choleskyFactor [][]float64
+
+// cholesky раскладывает симметричную положительно определенную матрицу.
+func cholesky(a [][]float64) (choleskyFactor, bool) {
+	d := len(a)
+	l := make(choleskyFactor, d)
+	for i := range l {
+		l[i] = make([]float64, d)
+	}
+	for i := 0; i < d; i++ {
+		for j := 0; j <= i; j++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum <= 0 {
+					return nil, false
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, true
+}
+
+// solve решает A*x = v прямой и обратной подстановкой.
+func (l choleskyFactor) solve(v []float64) []float64 {
+	d := len(l)
+	y := make([]float64, d)
+	for i := 0; i < d; i++ {
+		sum := v[i]
+		for k := 0; k < i; k++ {
+			sum -= l[i][k] * y[k]
+		}
+		y[i] = sum / l[i][i]
+	}
+
+	x := make([]float64, d)
+	for i := d - 1; i >= 0; i-- {
+		sum := y[i]
+		for k := i + 1; k < d; k++ {
+			sum -= l[k][i] * x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+	return x
+}
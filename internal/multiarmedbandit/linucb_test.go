@@ -0,0 +1,76 @@
+package multiarmedbandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinUCBSharesAcrossGroups(t *testing.T) {
+	features := map[int]map[string]float64{
+		1: {"age_18_24": 1, "interest_sport": 1},
+		2: {"age_18_24": 1},
+		3: {"age_55_plus": 1},
+		// Новые группы без собственной статистики
+		4: {"age_18_24": 1, "interest_sport": 0.5},
+		5: {"age_55_plus": 1},
+	}
+	// Баннер 1 нравится молодым, баннер 2 - старшим
+	stats := map[int][]GroupStats{
+		1: {
+			{UserGroupID: 1, Impressions: 1000, Clicks: 300},
+			{UserGroupID: 2, Impressions: 1000, Clicks: 250},
+			{UserGroupID: 3, Impressions: 1000, Clicks: 10},
+		},
+		2: {
+			{UserGroupID: 1, Impressions: 1000, Clicks: 20},
+			{UserGroupID: 2, Impressions: 1000, Clicks: 20},
+			{UserGroupID: 3, Impressions: 1000, Clicks: 280},
+		},
+	}
+
+	linUCB := NewLinUCB(0)
+	pick := func(userGroupID int) int {
+		banners := []Banner{&Counts{BannerID: 1}, &Counts{BannerID: 2}}
+		AttachContext(banners, userGroupID, features, stats)
+		return linUCB.Pick(banners)
+	}
+
+	require.Equal(t, 1, pick(1))
+	require.Equal(t, 2, pick(3))
+	require.Equal(t, 1, pick(4))
+	require.Equal(t, 2, pick(5))
+}
+
+func TestLinUCBExploresUntriedBanner(t *testing.T) {
+	features := map[int]map[string]float64{1: {"gender_female": 1}}
+	stats := map[int][]GroupStats{
+		1: {{UserGroupID: 1, Impressions: 1000, Clicks: 100}},
+	}
+
+	banners := []Banner{&Counts{BannerID: 1}, &Counts{BannerID: 2}}
+	AttachContext(banners, 1, features, stats)
+	require.Equal(t, 2, NewLinUCB(1).Pick(banners))
+}
+
+func TestLinUCBWithoutContext(t *testing.T) {
+	banners := []Banner{
+		&Counts{BannerID: 1, Impressions: 1000, Clicks: 10},
+		&Counts{BannerID: 2, Impressions: 1000, Clicks: 300},
+	}
+	require.Equal(t, 2, NewLinUCB(1).Pick(banners))
+
+	selector := NewSelector(nil)
+	selector.SetSlotStrategy(2, NewLinUCB(1))
+	require.False(t, selector.NeedsContext(1))
+	require.True(t, selector.NeedsContext(2))
+}
+
+func TestFeatureSpace(t *testing.T) {
+	space := NewFeatureSpace(map[int]map[string]float64{
+		1: {"interest_sport": 0.5, "age_18_24": 1},
+		2: {"gender_male": 1},
+	})
+	require.Equal(t, []float64{1, 1, 0, 0.5}, space.Vector(map[string]float64{"age_18_24": 1, "interest_sport": 0.5}))
+	require.Equal(t, []float64{1, 0, 0, 0}, space.Vector(nil))
+}
@@ -10,32 +10,9 @@ type Banner interface {
 	GetClicks() float64
 }
 
+// PickBanner выбирает баннер стратегией UCB1.
 func PickBanner(banners []Banner) int {
-	var (
-		totalImpressions float64
-		maximumRating    float64 = -1
-		selectedBannerID         = 0
-	)
-
-	// Находим сумму всех impressions для последующего расчета
-	for _, b := range banners {
-		imp := b.GetImpressions()
-		if imp == 0 {
-			imp = 1
-		}
-		totalImpressions += imp
-	}
-
-	// Выбираем баннер с максимальным рейтингом
-	for _, b := range banners {
-		rating := calculateRating(b.GetClicks(), b.GetImpressions(), totalImpressions)
-		if rating > maximumRating {
-			maximumRating = rating
-			selectedBannerID = b.GetID()
-		}
-	}
-
-	return selectedBannerID
+	return UCB1{}.Pick(banners)
 }
 
 // calculateRating вычисляет рейтинг баннера.
@@ -45,3 +22,18 @@ func calculateRating(clicks, impressions, totalImpressions float64) float64 {
 	}
 	return clicks/impressions + math.Sqrt(2*math.Log(totalImpressions)/impressions)
 }
+
+// Exclude возвращает баннеры, ID которых нет в excluded.
+func Exclude(banners []Banner, excluded map[int]struct{}) []Banner {
+	if len(excluded) == 0 {
+		return banners
+	}
+
+	filtered := make([]Banner, 0, len(banners))
+	for _, b := range banners {
+		if _, ok := excluded[b.GetID()]; !ok {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered
+}
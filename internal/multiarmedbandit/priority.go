@@ -0,0 +1,102 @@
+package multiarmedbandit
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Priority - бизнес-условия показа баннера, которые выполняются раньше стратегии.
+type Priority struct {
+	// Pinned - закрепленные баннеры получают весь трафик слота, разделяя его по Weight.
+	Pinned bool
+	// Weight - вес среди закрепленных баннеров; 0 означает 1.
+	Weight float64
+	// MinShare - гарантированная доля трафика слота от 0 до 1.
+	MinShare float64
+}
+
+// Prioritized - баннер с бизнес-условиями показа. Для баннеров без них действует нулевой Priority.
+type Prioritized interface {
+	GetPriority() Priority
+}
+
+// defaultRand используется nil-селектором.
+var defaultRand = newLockedRand(rand.NewSource(time.Now().UnixNano()))
+
+// Pick выбирает баннер для слота. Закрепленные баннеры делят весь трафик по весам; иначе
+// гарантированные доли разыгрываются случайно, из оставшегося трафика новые баннеры до MinImpressions
+// показов получают по доле 1/N (см. ColdStart), а остальное распределяет стратегия слота с учетом
+// априорного CTR.
+func (s *Selector) Pick(slotID int, banners []Banner) int {
+	rnd := defaultRand
+	if s != nil {
+		rnd = s.rnd
+	}
+
+	if id, ok := pickPinned(banners, rnd); ok {
+		return id
+	}
+
+	// Сумма долей может превышать 1, если часть долей задана позже: тогда их делят пропорционально
+	var total float64
+	for _, b := range banners {
+		total += priorityOf(b).MinShare
+	}
+	u := rnd.Float64()
+	if total > 1 {
+		u *= total
+	}
+	for _, b := range banners {
+		share := priorityOf(b).MinShare
+		if u < share {
+			return b.GetID()
+		}
+		u -= share
+	}
+
+	coldStart := s.ColdStartForSlot(slotID)
+	if id, ok := pickExploring(banners, coldStart.MinImpressions, rnd); ok {
+		return id
+	}
+	return s.ForSlot(slotID).Pick(coldStart.Prior.Apply(banners))
+}
+
+// pickPinned выбирает закрепленный баннер пропорционально весу.
+func pickPinned(banners []Banner, rnd *lockedRand) (int, bool) {
+	var (
+		pinned []Banner
+		total  float64
+	)
+	for _, b := range banners {
+		if p := priorityOf(b); p.Pinned {
+			pinned = append(pinned, b)
+			total += p.weight()
+		}
+	}
+	if len(pinned) == 0 {
+		return 0, false
+	}
+
+	u := rnd.Float64() * total
+	for _, b := range pinned {
+		if u < priorityOf(b).weight() {
+			return b.GetID(), true
+		}
+		u -= priorityOf(b).weight()
+	}
+	return pinned[len(pinned)-1].GetID(), true
+}
+
+func priorityOf(b Banner) Priority {
+	if p, ok := b.(Prioritized); ok {
+		return p.GetPriority()
+	}
+	return Priority{}
+}
+
+func (p Priority) weight() float64 {
+	if p.Weight == 0 {
+		return 1
+	}
+	return p.Weight
+}
@@ -0,0 +1,52 @@
+package multiarmedbandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorPickPinned(t *testing.T) {
+	selector := NewSelector(nil)
+	selector.rnd = newLockedRand(rand.NewSource(1))
+
+	banners := []Banner{
+		&Counts{BannerID: 1, Impressions: 100, Clicks: 90},
+		&Counts{BannerID: 2, Impressions: 100, Clicks: 1, Priority: Priority{Pinned: true, Weight: 3}},
+		&Counts{BannerID: 3, Impressions: 100, Clicks: 1, Priority: Priority{Pinned: true}},
+	}
+
+	// Закрепленные баннеры делят весь трафик в отношении 3:1
+	picks := make(map[int]int)
+	for i := 0; i < 4000; i++ {
+		picks[selector.Pick(1, banners)]++
+	}
+	require.Zero(t, picks[1])
+	require.InDelta(t, 3000, picks[2], 150)
+	require.InDelta(t, 1000, picks[3], 150)
+}
+
+func TestSelectorPickMinShare(t *testing.T) {
+	selector := NewSelector(nil)
+	selector.rnd = newLockedRand(rand.NewSource(1))
+
+	// Баннер 2 проигрывает UCB1, но получает гарантированные 30% трафика
+	banners := []Banner{
+		&Counts{BannerID: 1, Impressions: 1000, Clicks: 500},
+		&Counts{BannerID: 2, Impressions: 1000, Clicks: 1, Priority: Priority{MinShare: 0.3}},
+	}
+
+	picks := make(map[int]int)
+	for i := 0; i < 4000; i++ {
+		picks[selector.Pick(1, banners)]++
+	}
+	require.InDelta(t, 1200, picks[2], 150)
+	require.Equal(t, 4000, picks[1]+picks[2])
+
+	// Без условий показа выбор делает стратегия, в том числе у nil-селектора
+	var nilSelector *Selector
+	plain := []Banner{&Counts{BannerID: 1, Impressions: 1000, Clicks: 500}, &Counts{BannerID: 2, Impressions: 1000}}
+	require.Equal(t, 1, nilSelector.Pick(1, plain))
+	require.Equal(t, 1, selector.Pick(1, plain))
+}
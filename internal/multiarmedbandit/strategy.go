@@ -0,0 +1,378 @@
+package multiarmedbandit
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	StrategyUCB1          = "ucb1"
+	StrategyEpsilonGreedy = "epsilon-greedy"
+	StrategyThompson      = "thompson"
+	StrategySoftmax       = "softmax"
+)
+
+// Strategy - алгоритм выбора баннера среди кандидатов.
+type Strategy interface {
+	Name() string
+	Pick(banners []Banner) int
+}
+
+// Params - настройки, используемые при создании стратегии по имени.
+type Params struct {
+	Epsilon     float64
+	Temperature float64
+	Alpha       float64
+	Seed        int64
+}
+
+// NewStrategy создает стратегию по ее имени.
+func NewStrategy(name string, params Params) (Strategy, error) {
+	seed := params.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	switch name {
+	case "", StrategyUCB1:
+		return UCB1{}, nil
+	case StrategyEpsilonGreedy:
+		return NewEpsilonGreedy(params.Epsilon, rand.NewSource(seed)), nil
+	case StrategyThompson:
+		return NewThompsonSampling(rand.NewSource(seed)), nil
+	case StrategySoftmax:
+		return NewSoftmax(params.Temperature, rand.NewSource(seed)), nil
+	case StrategyLinUCB:
+		return NewLinUCB(params.Alpha), nil
+	default:
+		return nil, fmt.Errorf("unknown bandit strategy: %q", name)
+	}
+}
+
+// Selector хранит стратегию и забывание статистики по умолчанию и переопределения для отдельных слотов.
+// Бизнес-условия показа баннеров Selector выполняет до стратегии (см. Pick).
+type Selector struct {
+	mu               sync.RWMutex
+	defaultStrategy  Strategy
+	slots            map[int]Strategy
+	defaultDecay     Decay
+	decays           map[int]Decay
+	defaultColdStart ColdStart
+	coldStarts       map[int]ColdStart
+	rnd              *lockedRand
+}
+
+func NewSelector(defaultStrategy Strategy) *Selector {
+	return NewSelectorWithSource(defaultStrategy, rand.NewSource(time.Now().UnixNano()))
+}
+
+// NewSelectorWithSource создает селектор, случайные розыгрыши которого (закрепленные баннеры,
+// гарантированные доли, обязательные показы) берутся из src: с одинаковым src выбор повторяется.
+func NewSelectorWithSource(defaultStrategy Strategy, src rand.Source) *Selector {
+	if defaultStrategy == nil {
+		defaultStrategy = UCB1{}
+	}
+	return &Selector{
+		defaultStrategy: defaultStrategy,
+		slots:           make(map[int]Strategy),
+		decays:          make(map[int]Decay),
+		coldStarts:      make(map[int]ColdStart),
+		rnd:             newLockedRand(src),
+	}
+}
+
+func (s *Selector) SetSlotStrategy(slotID int, strategy Strategy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.slots[slotID] = strategy
+}
+
+func (s *Selector) SetDefaultDecay(decay Decay) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultDecay = decay
+}
+
+func (s *Selector) SetSlotDecay(slotID int, decay Decay) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.decays[slotID] = decay
+}
+
+// DecayForSlot возвращает забывание статистики для слота. Для nil-селектора учитывается вся история.
+func (s *Selector) DecayForSlot(slotID int) Decay {
+	if s == nil {
+		return Decay{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if decay, ok := s.decays[slotID]; ok {
+		return decay
+	}
+	return s.defaultDecay
+}
+
+func (s *Selector) SetDefaultColdStart(coldStart ColdStart) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultColdStart = coldStart
+}
+
+func (s *Selector) SetSlotColdStart(slotID int, coldStart ColdStart) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.coldStarts[slotID] = coldStart
+}
+
+// ColdStartForSlot возвращает настройки холодного старта для слота. Для nil-селектора - без них.
+func (s *Selector) ColdStartForSlot(slotID int) ColdStart {
+	if s == nil {
+		return ColdStart{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if coldStart, ok := s.coldStarts[slotID]; ok {
+		return coldStart
+	}
+	return s.defaultColdStart
+}
+
+// ForSlot возвращает стратегию, настроенную для слота. Для nil-селектора - UCB1.
+func (s *Selector) ForSlot(slotID int) Strategy {
+	if s == nil {
+		return UCB1{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if strategy, ok := s.slots[slotID]; ok {
+		return strategy
+	}
+	return s.defaultStrategy
+}
+
+// UCB1 - стратегия "верхней доверительной границы".
+type UCB1 struct{}
+
+func (UCB1) Name() string {
+	return StrategyUCB1
+}
+
+func (UCB1) Pick(banners []Banner) int {
+	var (
+		totalImpressions         = sumImpressions(banners)
+		maximumRating    float64 = -1
+		selectedBannerID         = 0
+	)
+
+	// Выбираем баннер с максимальным рейтингом
+	for _, b := range banners {
+		rating := calculateRating(b.GetClicks(), b.GetImpressions(), totalImpressions)
+		if rating > maximumRating {
+			maximumRating = rating
+			selectedBannerID = b.GetID()
+		}
+	}
+
+	return selectedBannerID
+}
+
+// Scores возвращает рейтинги UCB1, которые Pick присвоил бы баннерам.
+func (UCB1) Scores(banners []Banner) map[int]float64 {
+	totalImpressions := sumImpressions(banners)
+
+	scores := make(map[int]float64, len(banners))
+	for _, b := range banners {
+		scores[b.GetID()] = calculateRating(b.GetClicks(), b.GetImpressions(), totalImpressions)
+	}
+	return scores
+}
+
+// sumImpressions находит сумму всех impressions для расчета рейтинга.
+func sumImpressions(banners []Banner) float64 {
+	var total float64
+	for _, b := range banners {
+		imp := b.GetImpressions()
+		if imp == 0 {
+			imp = 1
+		}
+		total += imp
+	}
+	return total
+}
+
+// EpsilonGreedy с вероятностью epsilon показывает случайный баннер,
+// в остальных случаях - баннер с лучшим CTR.
+type EpsilonGreedy struct {
+	epsilon float64
+	rnd     *lockedRand
+}
+
+func NewEpsilonGreedy(epsilon float64, src rand.Source) *EpsilonGreedy {
+	return &EpsilonGreedy{epsilon: epsilon, rnd: newLockedRand(src)}
+}
+
+func (e *EpsilonGreedy) Name() string {
+	return StrategyEpsilonGreedy
+}
+
+func (e *EpsilonGreedy) Pick(banners []Banner) int {
+	if len(banners) == 0 {
+		return 0
+	}
+	if e.rnd.Float64() < e.epsilon {
+		return banners[e.rnd.Intn(len(banners))].GetID()
+	}
+
+	var (
+		bestCTR          float64 = -1
+		selectedBannerID         = 0
+	)
+	for _, b := range banners {
+		// Баннеры без показов пробуем в первую очередь
+		if b.GetImpressions() == 0 {
+			return b.GetID()
+		}
+		if ctr := b.GetClicks() / b.GetImpressions(); ctr > bestCTR {
+			bestCTR = ctr
+			selectedBannerID = b.GetID()
+		}
+	}
+	return selectedBannerID
+}
+
+// ThompsonSampling выбирает баннер по сэмплу из апостериорного Beta-распределения CTR.
+type ThompsonSampling struct {
+	rnd *lockedRand
+}
+
+func NewThompsonSampling(src rand.Source) *ThompsonSampling {
+	return &ThompsonSampling{rnd: newLockedRand(src)}
+}
+
+func (t *ThompsonSampling) Name() string {
+	return StrategyThompson
+}
+
+func (t *ThompsonSampling) Pick(banners []Banner) int {
+	var (
+		maximumSample    float64 = -1
+		selectedBannerID         = 0
+	)
+	for _, b := range banners {
+		failures := math.Max(b.GetImpressions()-b.GetClicks(), 0)
+		sample := t.rnd.Beta(b.GetClicks()+1, failures+1)
+		if sample > maximumSample {
+			maximumSample = sample
+			selectedBannerID = b.GetID()
+		}
+	}
+	return selectedBannerID
+}
+
+// Softmax выбирает баннер с вероятностью, пропорциональной exp(CTR / temperature).
+type Softmax struct {
+	temperature float64
+	rnd         *lockedRand
+}
+
+func NewSoftmax(temperature float64, src rand.Source) *Softmax {
+	return &Softmax{temperature: temperature, rnd: newLockedRand(src)}
+}
+
+func (s *Softmax) Name() string {
+	return StrategySoftmax
+}
+
+func (s *Softmax) Pick(banners []Banner) int {
+	if len(banners) == 0 {
+		return 0
+	}
+	temperature := s.temperature
+	if temperature <= 0 {
+		temperature = 0.1
+	}
+
+	ctrs := make([]float64, len(banners))
+	maxCTR := math.Inf(-1)
+	for i, b := range banners {
+		if b.GetImpressions() > 0 {
+			ctrs[i] = b.GetClicks() / b.GetImpressions()
+		}
+		maxCTR = math.Max(maxCTR, ctrs[i])
+	}
+
+	// Вычитаем максимум, чтобы экспонента не переполнялась
+	var sum float64
+	weights := make([]float64, len(banners))
+	for i, ctr := range ctrs {
+		weights[i] = math.Exp((ctr - maxCTR) / temperature)
+		sum += weights[i]
+	}
+
+	point := s.rnd.Float64() * sum
+	for i, w := range weights {
+		point -= w
+		if point <= 0 {
+			return banners[i].GetID()
+		}
+	}
+	return banners[len(banners)-1].GetID()
+}
+
+// lockedRand - потокобезопасная обертка над rand.Rand.
+type lockedRand struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func newLockedRand(src rand.Source) *lockedRand {
+	return &lockedRand{rnd: rand.New(src)} //nolint:gosec
+}
+
+func (l *lockedRand) Float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rnd.Float64()
+}
+
+func (l *lockedRand) Intn(n int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rnd.Intn(n)
+}
+
+// Beta возвращает сэмпл из Beta(alpha, beta) через два Gamma-распределения.
+func (l *lockedRand) Beta(alpha, beta float64) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	x := gamma(l.rnd, alpha)
+	y := gamma(l.rnd, beta)
+	if x+y == 0 {
+		return 0
+	}
+	return x / (x + y)
+}
+
+// gamma - генератор Gamma(shape, 1) по методу Марсальи-Цанга.
+func gamma(rnd *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		return gamma(rnd, shape+1) * math.Pow(rnd.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := rnd.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := rnd.Float64()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
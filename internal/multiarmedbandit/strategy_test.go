@@ -0,0 +1,144 @@
+package multiarmedbandit
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "", want: StrategyUCB1},
+		{name: StrategyUCB1, want: StrategyUCB1},
+		{name: StrategyEpsilonGreedy, want: StrategyEpsilonGreedy},
+		{name: StrategyThompson, want: StrategyThompson},
+		{name: StrategySoftmax, want: StrategySoftmax},
+		{name: StrategyLinUCB, want: StrategyLinUCB},
+		{name: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := NewStrategy(tt.name, Params{Epsilon: 0.1, Temperature: 0.1, Seed: 1})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, strategy.Name())
+		})
+	}
+}
+
+func TestSelector(t *testing.T) {
+	var nilSelector *Selector
+	require.Equal(t, StrategyUCB1, nilSelector.ForSlot(1).Name())
+
+	selector := NewSelector(nil)
+	selector.SetSlotStrategy(2, NewThompsonSampling(rand.NewSource(1)))
+
+	require.Equal(t, StrategyUCB1, selector.ForSlot(1).Name())
+	require.Equal(t, StrategyThompson, selector.ForSlot(2).Name())
+
+	require.True(t, nilSelector.DecayForSlot(1).IsZero())
+	selector.SetDefaultDecay(Decay{HalfLife: time.Hour})
+	selector.SetSlotDecay(2, Decay{Window: time.Minute})
+	require.Equal(t, Decay{HalfLife: time.Hour}, selector.DecayForSlot(1))
+	require.Equal(t, Decay{Window: time.Minute}, selector.DecayForSlot(2))
+}
+
+func TestStrategiesPreferBestBanner(t *testing.T) {
+	banners := []Banner{
+		&bnr{ID: 1, impressions: 1000, clicks: 10},
+		&bnr{ID: 2, impressions: 1000, clicks: 300},
+		&bnr{ID: 3, impressions: 1000, clicks: 20},
+	}
+
+	strategies := []Strategy{
+		UCB1{},
+		NewEpsilonGreedy(0.1, rand.NewSource(1)),
+		NewThompsonSampling(rand.NewSource(1)),
+		NewSoftmax(0.05, rand.NewSource(1)),
+	}
+	for _, strategy := range strategies {
+		t.Run(strategy.Name(), func(t *testing.T) {
+			picks := make(map[int]int)
+			for i := 0; i < 1000; i++ {
+				picks[strategy.Pick(banners)]++
+			}
+			require.Greater(t, picks[2], 800)
+		})
+	}
+}
+
+func TestEpsilonGreedyExploresUntriedBanner(t *testing.T) {
+	banners := []Banner{
+		&bnr{ID: 1, impressions: 10, clicks: 5},
+		&bnr{ID: 2, impressions: 0, clicks: 0},
+	}
+	strategy := NewEpsilonGreedy(0, rand.NewSource(1))
+	require.Equal(t, 2, strategy.Pick(banners))
+}
+
+func TestStrategiesAreReproducibleWithSeed(t *testing.T) {
+	banners := []Banner{
+		&bnr{ID: 1, impressions: 10, clicks: 1},
+		&bnr{ID: 2, impressions: 10, clicks: 2},
+		&bnr{ID: 3, impressions: 10, clicks: 1},
+	}
+	first := NewThompsonSampling(rand.NewSource(42))
+	second := NewThompsonSampling(rand.NewSource(42))
+	for i := 0; i < 100; i++ {
+		require.Equal(t, first.Pick(banners), second.Pick(banners))
+	}
+}
+
+func TestUCB1Scores(t *testing.T) {
+	banners := []Banner{
+		&bnr{ID: 1, impressions: 7, clicks: 2},
+		&bnr{ID: 2, impressions: 4, clicks: 1},
+		&bnr{ID: 3, impressions: 4, clicks: 0},
+	}
+	scores := UCB1{}.Scores(banners)
+
+	require.Len(t, scores, 3)
+	require.InDelta(t, calculateRating(1, 4, 15), scores[2], 1e-9)
+	require.Greater(t, scores[2], scores[1])
+	require.Greater(t, scores[2], scores[3])
+}
+
+func TestNewDecay(t *testing.T) {
+	decay, err := NewDecay("", "")
+	require.NoError(t, err)
+	require.True(t, decay.IsZero())
+
+	decay, err = NewDecay("1h", "")
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, decay.HalfLife)
+
+	decay, err = NewDecay("", "24h")
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour, decay.Window)
+
+	_, err = NewDecay("1h", "24h")
+	require.Error(t, err)
+	_, err = NewDecay("-1h", "")
+	require.Error(t, err)
+	_, err = NewDecay("", "week")
+	require.Error(t, err)
+}
+
+func TestDecayFactor(t *testing.T) {
+	require.Equal(t, 1.0, Decay{}.Factor(time.Hour))
+	require.Equal(t, 1.0, Decay{Window: time.Hour}.Factor(time.Hour))
+
+	decay := Decay{HalfLife: time.Hour}
+	require.Equal(t, 1.0, decay.Factor(0))
+	require.InDelta(t, 0.5, decay.Factor(time.Hour), 1e-9)
+	require.InDelta(t, 0.25, decay.Factor(2*time.Hour), 1e-9)
+}
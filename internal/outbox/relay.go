@@ -0,0 +1,119 @@
+package outbox
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/interfaces"
+	"github.com/pkg/errors"
+	"github.com/streadway/amqp"
+)
+
+type Publisher interface {
+	Publish(msg amqp.Publishing) error
+}
+
+// Relay периодически публикует уведомления из outbox и помечает их отправленными.
+// Запись помечается только после успешной публикации, поэтому доставка - at-least-once.
+type Relay struct {
+	storage   interfaces.Outbox
+	publisher Publisher
+	logger    interfaces.Logger
+
+	interval  time.Duration
+	lease     time.Duration
+	batchSize int
+}
+
+func NewRelay(
+	storage interfaces.Outbox,
+	publisher Publisher,
+	logger interfaces.Logger,
+	interval, lease string,
+	batchSize int,
+) (*Relay, error) {
+	intervalDur, err := time.ParseDuration(interval)
+	if err != nil {
+		return nil, errors.Wrapf(err, "outbox interval parsing fail (%s)", interval)
+	}
+
+	leaseDur, err := time.ParseDuration(lease)
+	if err != nil {
+		return nil, errors.Wrapf(err, "outbox lease parsing fail (%s)", lease)
+	}
+
+	if batchSize <= 0 {
+		return nil, errors.Errorf("outbox batch size must be positive, got %d", batchSize)
+	}
+
+	return &Relay{
+		storage:   storage,
+		publisher: publisher,
+		logger:    logger,
+		interval:  intervalDur,
+		lease:     leaseDur,
+		batchSize: batchSize,
+	}, nil
+}
+
+// Run публикует уведомления до отмены контекста.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Пока пачки полные, забираем следующие без ожидания
+			for {
+				sent, err := r.RelayOnce(ctx)
+				if err != nil {
+					r.logger.Error("Failed to relay outbox: %v", err)
+					break
+				}
+				if sent < r.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// RelayOnce публикует одну пачку уведомлений и возвращает число отправленных.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	messages, err := r.storage.ClaimOutbox(ctx, r.batchSize, r.lease)
+	if err != nil {
+		return 0, errors.Wrap(err, "claim outbox fail")
+	}
+
+	sent := make([]int64, 0, len(messages))
+	var publishErr error
+	for _, msg := range messages {
+		if publishErr = r.publisher.Publish(amqp.Publishing{
+			ContentType: "application/json",
+			MessageId:   strconv.FormatInt(msg.ID, 10),
+			Body:        msg.Payload,
+		}); publishErr != nil {
+			// Остальные записи будут отправлены после истечения аренды, с сохранением порядка
+			break
+		}
+		sent = append(sent, msg.ID)
+		r.logger.Info(
+			"Sent a notification to queue RabbitMQ: %s %s",
+			string(msg.Payload),
+			time.Now().Format("2006-01-02 15:04"),
+		)
+	}
+
+	if err := r.storage.MarkOutboxSent(ctx, sent); err != nil {
+		return 0, errors.Wrap(err, "mark outbox sent fail")
+	}
+
+	if publishErr != nil {
+		return len(sent), errors.Wrap(publishErr, "publish fail")
+	}
+	return len(sent), nil
+}
@@ -0,0 +1,68 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/logger"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage/memory"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePublisher struct {
+	published [][]byte
+	failAfter int
+}
+
+func (p *fakePublisher) Publish(msg amqp.Publishing) error {
+	if p.failAfter >= 0 && len(p.published) >= p.failAfter {
+		return errors.New("rabbitmq is down")
+	}
+	p.published = append(p.published, msg.Body)
+	return nil
+}
+
+func TestRelayOnce(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.New(nil)
+	require.NoError(t, storage.Migrate(ctx, ""))
+
+	for i := 0; i < 3; i++ {
+		_, err := storage.ImpressBanner(ctx, 1, 1, 1)
+		require.NoError(t, err)
+	}
+
+	publisher := &fakePublisher{failAfter: 2}
+	relay, err := NewRelay(storage, publisher, logger.New("error", io.Discard), "1s", "1ms", 10)
+	require.NoError(t, err)
+
+	// RabbitMQ недоступен после двух сообщений: третье остается в outbox
+	sent, err := relay.RelayOnce(ctx)
+	require.Error(t, err)
+	require.Equal(t, 2, sent)
+
+	publisher.failAfter = -1
+	require.Eventually(t, func() bool {
+		sent, err = relay.RelayOnce(ctx)
+		return err == nil && sent == 1
+	}, time.Second, 5*time.Millisecond)
+	require.Len(t, publisher.published, 3)
+
+	sent, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	require.Zero(t, sent)
+}
+
+func TestNewRelayValidation(t *testing.T) {
+	logg := logger.New("error", io.Discard)
+
+	_, err := NewRelay(memory.New(nil), &fakePublisher{}, logg, "1x", "30s", 10)
+	require.Error(t, err)
+
+	_, err = NewRelay(memory.New(nil), &fakePublisher{}, logg, "1s", "30s", 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package rmq
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/streadway/amqp"
+)
+
+// confirmer читает подтверждения публикации канала в отдельной горутине и передает их
+// ожидающим Publish. Подтверждения, которые уже никто не ждет (истек таймаут), отбрасываются,
+// поэтому читатель amqp не блокируется на переполненном канале уведомлений.
+type confirmer struct {
+	mu      sync.Mutex
+	waiters map[uint64]chan error
+	closed  bool
+}
+
+// newConfirmer подписывается на подтверждения и возвраты канала и запускает их чтение.
+func newConfirmer(channel *amqp.Channel) *confirmer {
+	// Возвраты без буфера: amqp присылает basic.return раньше ack того же сообщения, и пока
+	// возврат не прочитан, ack не отправляется в confirms, так что порядок сохраняется.
+	confirms := channel.NotifyPublish(make(chan amqp.Confirmation, 1))
+	returns := channel.NotifyReturn(make(chan amqp.Return))
+
+	c := &confirmer{waiters: make(map[uint64]chan error)}
+	go c.run(confirms, returns)
+	return c
+}
+
+// expect регистрирует ожидание подтверждения сообщения с номером tag. Вызывается до публикации,
+// чтобы не пропустить быстрый ack.
+func (c *confirmer) expect(tag uint64) <-chan error {
+	result := make(chan error, 1)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		result <- ErrNotReady
+		return result
+	}
+	c.waiters[tag] = result
+	return result
+}
+
+// forget снимает ожидание сообщения, которое не отправлено или не дождалось подтверждения.
+func (c *confirmer) forget(tag uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.waiters, tag)
+}
+
+func (c *confirmer) run(confirms <-chan amqp.Confirmation, returns <-chan amqp.Return) {
+	// Возврат относится к сообщению, ack которого придет следующим
+	var returned *amqp.Return
+	for {
+		select {
+		case ret, ok := <-returns:
+			if !ok {
+				returns = nil
+				continue
+			}
+			returned = &ret
+		case confirm, ok := <-confirms:
+			if !ok {
+				c.close()
+				return
+			}
+			c.resolve(confirm, returned)
+			returned = nil
+		}
+	}
+}
+
+func (c *confirmer) resolve(confirm amqp.Confirmation, returned *amqp.Return) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result, ok := c.waiters[confirm.DeliveryTag]
+	if !ok {
+		return
+	}
+	delete(c.waiters, confirm.DeliveryTag)
+
+	switch {
+	case !confirm.Ack:
+		result <- ErrNacked
+	case returned != nil:
+		result <- errors.Wrapf(ErrUnroutable, "%d %s", returned.ReplyCode, returned.ReplyText)
+	default:
+		result <- nil
+	}
+}
+
+// close завершает ожидания при закрытии канала.
+func (c *confirmer) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	for tag, result := range c.waiters {
+		result <- ErrNotReady
+		delete(c.waiters, tag)
+	}
+}
@@ -0,0 +1,55 @@
+package rmq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmer(t *testing.T) {
+	confirms := make(chan amqp.Confirmation)
+	returns := make(chan amqp.Return)
+	c := &confirmer{waiters: make(map[uint64]chan error)}
+	go c.run(confirms, returns)
+
+	first := c.expect(1)
+	confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+	require.NoError(t, receive(t, first))
+
+	// Подтверждения, которые никто не ждет, не блокируют чтение
+	c.expect(2)
+	c.forget(2)
+	for tag := uint64(2); tag < 10; tag++ {
+		confirms <- amqp.Confirmation{DeliveryTag: tag, Ack: true}
+	}
+
+	returned := c.expect(10)
+	returns <- amqp.Return{ReplyCode: 312, ReplyText: "NO_ROUTE"}
+	confirms <- amqp.Confirmation{DeliveryTag: 10, Ack: true}
+	require.ErrorIs(t, receive(t, returned), ErrUnroutable)
+
+	nacked := c.expect(11)
+	confirms <- amqp.Confirmation{DeliveryTag: 11}
+	require.ErrorIs(t, receive(t, nacked), ErrNacked)
+
+	// Закрытие канала завершает ожидания
+	pending := c.expect(12)
+	close(returns)
+	close(confirms)
+	require.ErrorIs(t, receive(t, pending), ErrNotReady)
+	require.ErrorIs(t, receive(t, c.expect(13)), ErrNotReady)
+}
+
+func receive(t *testing.T, result <-chan error) error {
+	t.Helper()
+
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("confirmation was not delivered")
+		return nil
+	}
+}
@@ -2,6 +2,7 @@ package rmq
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -10,18 +11,32 @@ import (
 	"github.com/streadway/amqp"
 )
 
-var ErrStopReconn = errors.New("stop reconnecting")
+var (
+	ErrStopReconn     = errors.New("stop reconnecting")
+	ErrNotReady       = errors.New("rmq channel is not ready")
+	ErrUnroutable     = errors.New("rmq message is unroutable")
+	ErrNacked         = errors.New("rmq message is not acknowledged by broker")
+	ErrConfirmTimeout = errors.New("rmq publish confirmation timeout")
+)
 
 type Rmq struct {
+	mu         sync.Mutex
 	conn       *amqp.Connection
 	channel    *amqp.Channel
 	connClosed chan struct{}
 
+	// Подтверждения публикации (publisher confirms).
+	confirmTimeout time.Duration
+	confirms       *confirmer
+	deliveryTag    uint64
+
 	uri          string
 	exchangeName string
 	exchangeType string
 	queueName    string
 	bindingKey   string
+	// exclusive - очередь только этого подключения с именем, выданным брокером.
+	exclusive bool
 
 	reConnMaxElapsedTime  time.Duration
 	reConnInitialInterval time.Duration
@@ -63,6 +78,29 @@ func New(
 	}, nil
 }
 
+// EnableConfirms включает режим подтверждений публикации: Publish ждет ack от брокера
+// не дольше timeout. Вызывается до Init.
+func (r *Rmq) EnableConfirms(timeout string) error {
+	timeoutDur, err := time.ParseDuration(timeout)
+	if err != nil {
+		return errors.Wrapf(err, "confirm timeout parsing fail (%s)", timeout)
+	}
+	if timeoutDur <= 0 {
+		return errors.Errorf("confirm timeout must be positive (%s)", timeout)
+	}
+
+	r.confirmTimeout = timeoutDur
+	return nil
+}
+
+// UseExclusiveQueue вместо общей очереди queueName объявляет очередь, принадлежащую только
+// этому подключению: брокер выдает ей имя и удаляет ее при разрыве соединения. С fanout-обменником
+// так каждый экземпляр получает все сообщения. Вызывается до Init.
+func (r *Rmq) UseExclusiveQueue() {
+	r.exclusive = true
+	r.queueName = ""
+}
+
 func (r *Rmq) Init(ctx context.Context) error {
 	var err error
 	startTime := time.Now()
@@ -107,18 +145,66 @@ func (r *Rmq) Close() error {
 	return r.conn.Close()
 }
 
+// Publish отправляет сообщение с флагом mandatory. Если включены подтверждения,
+// дожидается ack брокера; сообщение, которое некуда доставить, возвращается как ErrUnroutable.
+// Блокировка держится только на время отправки, ack ждется без нее: иначе медленный брокер
+// останавливал бы остальные публикации и переподключение.
 func (r *Rmq) Publish(msg amqp.Publishing) error {
-	if r.channel == nil {
-		return nil
+	confirms, tag, result, err := r.publish(msg)
+	if err != nil || confirms == nil {
+		return err
 	}
-	if err := r.channel.Publish(r.exchangeName, r.queueName, false, false, msg); err != nil {
-		return errors.Wrap(err, "rmq publish fail")
+
+	timer := time.NewTimer(r.confirmTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-result:
+		return err
+	case <-timer.C:
+		// Запоздавшее подтверждение отбросит confirmer
+		confirms.forget(tag)
+		return ErrConfirmTimeout
 	}
+}
 
-	return nil
+// publish отправляет сообщение под блокировкой. Если включены подтверждения, возвращает
+// confirmer канала, номер сообщения и ожидание его подтверждения: канал может смениться
+// при переподключении, пока Publish ждет ack.
+func (r *Rmq) publish(msg amqp.Publishing) (*confirmer, uint64, <-chan error, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.channel == nil || r.conn == nil || r.conn.IsClosed() {
+		return nil, 0, nil, ErrNotReady
+	}
+	if r.confirms == nil {
+		if err := r.channel.Publish(r.exchangeName, r.queueName, true, false, msg); err != nil {
+			return nil, 0, nil, errors.Wrap(err, "rmq publish fail")
+		}
+		return nil, 0, nil, nil
+	}
+
+	tag := r.deliveryTag + 1
+	result := r.confirms.expect(tag)
+	if err := r.channel.Publish(r.exchangeName, r.queueName, true, false, msg); err != nil {
+		r.confirms.forget(tag)
+		return nil, 0, nil, errors.Wrap(err, "rmq publish fail")
+	}
+	r.deliveryTag = tag
+	return r.confirms, tag, result, nil
 }
 
+// Consume подписывается на очередь текущего канала. Имя эксклюзивной очереди меняется
+// при переподключении, поэтому канал и имя читаются под блокировкой; пока очередь нового
+// соединения не объявлена, возвращается ErrNotReady.
 func (r *Rmq) Consume(consumerTag string) (<-chan amqp.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.channel == nil || r.queueName == "" {
+		return nil, ErrNotReady
+	}
 	msgsCh, err := r.channel.Consume(
 		r.queueName,
 		consumerTag,
@@ -136,6 +222,9 @@ func (r *Rmq) Consume(consumerTag string) (<-chan amqp.Delivery, error) {
 }
 
 func (r *Rmq) Qos(prefetchCount int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if err := r.channel.Qos(prefetchCount, 0, false); err != nil {
 		return errors.Wrap(err, "error setting qos")
 	}
@@ -144,7 +233,10 @@ func (r *Rmq) Qos(prefetchCount int) error {
 }
 
 func (r *Rmq) IsClosed() bool {
-	return r.conn.IsClosed()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.conn == nil || r.conn.IsClosed()
 }
 
 // Reconnecting algo.
@@ -184,7 +276,14 @@ func (r *Rmq) reConnect(ctx context.Context) error {
 
 // Connect to RabbitMQ.
 func (r *Rmq) connect(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	var err error
+	if r.exclusive {
+		// Очередь прежнего соединения удаляется брокером, новую объявит prepareQueue
+		r.queueName = ""
+	}
 
 	r.conn, err = amqp.Dial(r.uri)
 	if err != nil {
@@ -196,6 +295,15 @@ func (r *Rmq) connect(ctx context.Context) error {
 		return errors.Wrap(err, "channel fail")
 	}
 
+	if r.confirmTimeout > 0 {
+		if err := r.channel.Confirm(false); err != nil {
+			return errors.Wrap(err, "confirm mode fail")
+		}
+		// Номера подтверждений начинаются заново для каждого канала
+		r.deliveryTag = 0
+		r.confirms = newConfirmer(r.channel)
+	}
+
 	r.connClosed = make(chan struct{})
 
 	// Event for closing channel
@@ -224,17 +332,22 @@ func (r *Rmq) connect(ctx context.Context) error {
 
 // Declare queue.
 func (r *Rmq) prepareQueue() error {
-	_, err := r.channel.QueueDeclare(
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Для эксклюзивной очереди имя пустое (см. connect): брокер выдаст новое
+	queue, err := r.channel.QueueDeclare(
 		r.queueName,
-		true,
-		false,
-		false,
+		!r.exclusive,
+		r.exclusive,
+		r.exclusive,
 		false,
 		nil,
 	)
 	if err != nil {
 		return errors.Wrap(err, "queue declare fail")
 	}
+	r.queueName = queue.Name
 
 	// Создаём биндинг (правило маршрутизации).
 	if err = r.channel.QueueBind(
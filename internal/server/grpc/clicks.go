@@ -0,0 +1,119 @@
+package internalgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// forwardedForKey - заголовок со цепочкой адресов, через которые прошел запрос.
+const forwardedForKey = "x-forwarded-for"
+
+// SetTrustedProxies задает адреса прокси и балансировщиков, которым доверяется заголовок
+// X-Forwarded-For. Без них адресом клиента считается адрес соединения.
+func (s *ServiceServer) SetTrustedProxies(proxies []netip.Prefix) {
+	s.trustedProxies = proxies
+}
+
+// ParseTrustedProxies разбирает список доверенных прокси: подсети CIDR или отдельные адреса.
+func ParseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	proxies := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+		}
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
+// clientIP возвращает IP-адрес клиента для отсечения повторных переходов. Если запрос пришел
+// от доверенного прокси, адрес берется из X-Forwarded-For: первый справа, не принадлежащий
+// доверенным прокси. Левые адреса цепочки задает сам клиент, поэтому они не учитываются.
+// client_key и user_key из запроса не учитываются: меняя их на каждом переходе, бот обходил бы окно.
+func (s *ServiceServer) clientIP(ctx context.Context) string {
+	client, ok := peerAddr(ctx)
+	if !ok || !s.trusted(client) {
+		return peerHost(ctx)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var hops []string
+	for _, value := range md.Get(forwardedForKey) {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = hop.Unmap()
+		if !s.trusted(client) {
+			break
+		}
+	}
+	return client.String()
+}
+
+func (s *ServiceServer) trusted(addr netip.Addr) bool {
+	for _, proxy := range s.trustedProxies {
+		if proxy.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// peerAddr возвращает IP-адрес соединения, если он разбирается.
+func peerAddr(ctx context.Context) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(peerHost(ctx))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// peerHost возвращает адрес, с которого пришел запрос.
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// clickError переводит ошибку записи перехода в gRPC-статус.
+func (s *ServiceServer) clickError(attempt storage.ClickAttempt, err error) error {
+	var rejected *storage.RejectedClickError
+	if !errors.As(err, &rejected) {
+		return status.Errorf(codes.Internal, "failed to click banner: %v", err)
+	}
+
+	s.logger.Debug("Click rejected (%s): slot %d, banner %d, usergroup %d, client %q",
+		rejected.Reason, attempt.SlotID, attempt.BannerID, attempt.UserGroupID, attempt.ClientKey)
+
+	switch rejected.Reason {
+	case storage.RejectDuplicateImpression, storage.RejectDuplicateClient:
+		return status.Errorf(codes.AlreadyExists, "%v", rejected)
+	default:
+		return status.Errorf(codes.FailedPrecondition, "%v", rejected)
+	}
+}
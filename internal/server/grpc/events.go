@@ -0,0 +1,69 @@
+package internalgrpc
+
+import (
+	"github.com/dianapovarnitsina/banners-rotation/internal/eventfeed"
+	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// StreamEvents передает показы, клики и исчерпание объема ротаций по мере их появления. Медленный подписчик
+// не задерживает PickBanner: события, которые он не успел прочитать, отбрасываются,
+// а их число приходит в поле dropped следующего события.
+func (s *ServiceServer) StreamEvents(req *pb.StreamEventsRequest, stream pb.BannerService_StreamEventsServer) error {
+	switch req.GetTypeEvent() {
+	case "", storage.EventClick, storage.EventImpress, storage.EventCapReached:
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown type_event: %q", req.GetTypeEvent())
+	}
+
+	sub := s.events.Subscribe(eventfeed.Filter{
+		SlotID:      int(req.GetSlotId()),
+		BannerID:    int(req.GetBannerId()),
+		UserGroupID: int(req.GetUsergroupId()),
+		TypeEvent:   req.GetTypeEvent(),
+	})
+	defer sub.Close()
+
+	// Заголовки отправляются сразу: клиент узнает, что подписка уже действует
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				return status.Errorf(codes.Unavailable, "event feed is closed")
+			}
+			if err := stream.Send(eventToPb(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func eventToPb(event eventfeed.Event) *pb.Event {
+	return &pb.Event{
+		TypeEvent:   event.TypeEvent,
+		SlotId:      int32(event.SlotID),
+		BannerId:    int32(event.BannerID),
+		UsergroupId: int32(event.UsergroupID),
+		DateTime:    timestamppb.New(event.DateTime),
+		Dropped:     event.Dropped,
+		Cap:         event.Cap,
+	}
+}
+
+// publish рассылает подписчикам событие и уведомления об исчерпанных им ограничениях.
+func (s *ServiceServer) publish(event storage.Notification, caps []string) {
+	s.events.Publish(event)
+	for _, n := range storage.NewCapNotifications(event, caps) {
+		s.events.Publish(n)
+	}
+}
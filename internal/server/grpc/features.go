@@ -0,0 +1,45 @@
+package internalgrpc
+
+import (
+	"context"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ServiceServer) SetUserGroupFeatures(
+	ctx context.Context,
+	req *pb.SetUserGroupFeaturesRequest,
+) (*pb.UserGroupFeaturesResponse, error) {
+	userGroupID := int(req.GetUsergroupId())
+	features := storage.Features(req.GetFeatures())
+	if err := features.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	if err := s.storage.SetUserGroupFeatures(ctx, userGroupID, features); err != nil {
+		return nil, storageError(err, "failed to set userGroup features")
+	}
+	return featuresToPb(userGroupID, features), nil
+}
+
+func (s *ServiceServer) GetUserGroupFeatures(
+	ctx context.Context,
+	req *pb.GetUserGroupFeaturesRequest,
+) (*pb.UserGroupFeaturesResponse, error) {
+	userGroupID := int(req.GetUsergroupId())
+	features, err := s.storage.UserGroupFeatures(ctx, userGroupID)
+	if err != nil {
+		return nil, storageError(err, "failed to get userGroup features")
+	}
+	return featuresToPb(userGroupID, features), nil
+}
+
+func featuresToPb(userGroupID int, features storage.Features) *pb.UserGroupFeaturesResponse {
+	return &pb.UserGroupFeaturesResponse{
+		UsergroupId: int32(userGroupID),
+		Features:    features,
+	}
+}
@@ -0,0 +1,81 @@
+package internalgrpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/impressiontoken"
+	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RecordImpression подтверждает, что выбранный баннер действительно показан.
+// Только подтвержденные показы учитываются в статистике и попадают в очередь.
+func (s *ServiceServer) RecordImpression(
+	ctx context.Context,
+	req *pb.RecordImpressionRequest,
+) (*pb.RecordImpressionResponse, error) {
+	claims, err := s.tokens.Verify(req.GetImpressionToken(), time.Now())
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	impress, err := s.storage.RecordImpression(ctx, claims.ID, claims.BannerID, claims.SlotID, claims.UserGroupID)
+	if errors.Is(err, storage.ErrAlreadyRecorded) {
+		return nil, status.Errorf(codes.AlreadyExists, "impression is already recorded")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to record impression: %v", err)
+	}
+	s.publish(storage.NewImpressNotification(impress), impress.CapsReached)
+
+	// Показ уже записан: сбой истории просмотров не отменяет подтверждение
+	if err := s.frequency.AddView(ctx, claims.UserKey, claims.BannerID, impress.CreatedAt); err != nil {
+		s.logger.Error("Failed to add view of banner %d to history: %v", claims.BannerID, err)
+	}
+
+	return &pb.RecordImpressionResponse{Message: "Impression recorded successfully"}, nil
+}
+
+// issueToken выдает токен показа для выбранного баннера пользователю userKey.
+func (s *ServiceServer) issueToken(
+	slotID, bannerID, userGroupID int,
+	userKey string,
+) (string, *timestamppb.Timestamp, error) {
+	token, claims, err := s.tokens.Issue(slotID, bannerID, userGroupID, userKey, time.Now())
+	if err != nil {
+		return "", nil, status.Errorf(codes.Internal, "failed to issue impression token: %v", err)
+	}
+	return token, timestamppb.New(claims.ExpiresAt), nil
+}
+
+// clickToken проверяет токен показа из запроса перехода и возвращает его данные; без токена -
+// пустые. Срок токена не проверяется: переход может прийти позже, чем истекло время на подтверждение показа.
+func (s *ServiceServer) clickToken(req *pb.ClickBannerRequest) (impressiontoken.Claims, error) {
+	if req.GetImpressionToken() == "" {
+		return impressiontoken.Claims{}, nil
+	}
+
+	claims, err := s.tokens.Parse(req.GetImpressionToken())
+	if err != nil {
+		return impressiontoken.Claims{}, tokenError(err)
+	}
+	if claims.SlotID != int(req.GetSlotId()) ||
+		claims.BannerID != int(req.GetBannerId()) ||
+		claims.UserGroupID != int(req.GetUsergroupId()) {
+		return impressiontoken.Claims{}, status.Errorf(codes.InvalidArgument, "impression token does not match the click")
+	}
+	return claims, nil
+}
+
+// tokenError переводит ошибку проверки токена в gRPC-статус.
+func tokenError(err error) error {
+	if errors.Is(err, impressiontoken.ErrExpired) {
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return status.Errorf(codes.InvalidArgument, "%v", err)
+}
@@ -61,6 +61,36 @@ func (l *LoggingInterceptor) UnaryServerInterceptor(
 	return resp, err
 }
 
+// StreamServerInterceptor логирует потоковые вызовы после их завершения.
+func (l *LoggingInterceptor) StreamServerInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx := ss.Context()
+	l.logger.Info("Opened stream: method=%s", info.FullMethod)
+	startTime := time.Now()
+	err := handler(srv, ss)
+	latency := time.Since(startTime)
+
+	statusCode := 0
+	if err != nil {
+		statusCode = int(status.Code(err))
+		l.logger.Error("Error: %v", err)
+	}
+
+	l.logger.Info("INFO [%s] { ClientIPAddress:%s StartAt:%s HTTPMethod:%s StatusCode:%d Latency:%s}",
+		time.Now().Format("2006-01-02 15:04:05"),
+		getIP(ctx),
+		startTime.Format("2006-01-02 15:04:05.999999 -0700 MST"),
+		methodFromFullMethod(info.FullMethod),
+		statusCode,
+		latency,
+	)
+	return err
+}
+
 func getIP(ctx context.Context) string {
 	var clientIP string
 	peer, ok := peer.FromContext(ctx)
@@ -0,0 +1,230 @@
+package internalgrpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *ServiceServer) CreateSlot(ctx context.Context, req *pb.CreateSlotRequest) (*pb.SlotResponse, error) {
+	name, err := validateName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := s.storage.CreateSlot(ctx, name)
+	if err != nil {
+		return nil, storageError(err, "failed to create slot")
+	}
+	return &pb.SlotResponse{Slot: slotToPb(slot)}, nil
+}
+
+func (s *ServiceServer) GetSlot(ctx context.Context, req *pb.GetSlotRequest) (*pb.SlotResponse, error) {
+	slot, err := s.storage.GetSlot(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, storageError(err, "failed to get slot")
+	}
+	return &pb.SlotResponse{Slot: slotToPb(slot)}, nil
+}
+
+func (s *ServiceServer) ListSlots(ctx context.Context, _ *pb.ListSlotsRequest) (*pb.ListSlotsResponse, error) {
+	slots, err := s.storage.ListSlots(ctx)
+	if err != nil {
+		return nil, storageError(err, "failed to list slots")
+	}
+
+	resp := &pb.ListSlotsResponse{Slots: make([]*pb.Slot, 0, len(slots))}
+	for i := range slots {
+		resp.Slots = append(resp.Slots, slotToPb(&slots[i]))
+	}
+	return resp, nil
+}
+
+func (s *ServiceServer) UpdateSlot(ctx context.Context, req *pb.UpdateSlotRequest) (*pb.SlotResponse, error) {
+	name, err := validateName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := s.storage.UpdateSlot(ctx, int(req.GetId()), name)
+	if err != nil {
+		return nil, storageError(err, "failed to update slot")
+	}
+	return &pb.SlotResponse{Slot: slotToPb(slot)}, nil
+}
+
+func (s *ServiceServer) DeleteSlot(ctx context.Context, req *pb.DeleteSlotRequest) (*pb.DeleteSlotResponse, error) {
+	if err := s.storage.DeleteSlot(ctx, int(req.GetId())); err != nil {
+		return nil, storageError(err, "failed to delete slot")
+	}
+	return &pb.DeleteSlotResponse{Message: "Slot deleted successfully"}, nil
+}
+
+func (s *ServiceServer) CreateBanner(ctx context.Context, req *pb.CreateBannerRequest) (*pb.BannerResponse, error) {
+	name, err := validateName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	banner, err := s.storage.CreateBanner(ctx, name)
+	if err != nil {
+		return nil, storageError(err, "failed to create banner")
+	}
+	return &pb.BannerResponse{Banner: bannerToPb(banner)}, nil
+}
+
+func (s *ServiceServer) GetBanner(ctx context.Context, req *pb.GetBannerRequest) (*pb.BannerResponse, error) {
+	banner, err := s.storage.GetBanner(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, storageError(err, "failed to get banner")
+	}
+	return &pb.BannerResponse{Banner: bannerToPb(banner)}, nil
+}
+
+func (s *ServiceServer) ListBanners(ctx context.Context, _ *pb.ListBannersRequest) (*pb.ListBannersResponse, error) {
+	banners, err := s.storage.ListBanners(ctx)
+	if err != nil {
+		return nil, storageError(err, "failed to list banners")
+	}
+
+	resp := &pb.ListBannersResponse{Banners: make([]*pb.Banner, 0, len(banners))}
+	for i := range banners {
+		resp.Banners = append(resp.Banners, bannerToPb(&banners[i]))
+	}
+	return resp, nil
+}
+
+func (s *ServiceServer) UpdateBanner(ctx context.Context, req *pb.UpdateBannerRequest) (*pb.BannerResponse, error) {
+	name, err := validateName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	banner, err := s.storage.UpdateBanner(ctx, int(req.GetId()), name)
+	if err != nil {
+		return nil, storageError(err, "failed to update banner")
+	}
+	return &pb.BannerResponse{Banner: bannerToPb(banner)}, nil
+}
+
+func (s *ServiceServer) DeleteBanner(
+	ctx context.Context,
+	req *pb.DeleteBannerRequest,
+) (*pb.DeleteBannerResponse, error) {
+	if err := s.storage.DeleteBanner(ctx, int(req.GetId())); err != nil {
+		return nil, storageError(err, "failed to delete banner")
+	}
+	return &pb.DeleteBannerResponse{Message: "Banner deleted successfully"}, nil
+}
+
+func (s *ServiceServer) CreateUserGroup(
+	ctx context.Context,
+	req *pb.CreateUserGroupRequest,
+) (*pb.UserGroupResponse, error) {
+	name, err := validateName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.storage.CreateUserGroup(ctx, name)
+	if err != nil {
+		return nil, storageError(err, "failed to create userGroup")
+	}
+	return &pb.UserGroupResponse{Usergroup: userGroupToPb(group)}, nil
+}
+
+func (s *ServiceServer) GetUserGroup(ctx context.Context, req *pb.GetUserGroupRequest) (*pb.UserGroupResponse, error) {
+	group, err := s.storage.GetUserGroup(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, storageError(err, "failed to get userGroup")
+	}
+	return &pb.UserGroupResponse{Usergroup: userGroupToPb(group)}, nil
+}
+
+func (s *ServiceServer) ListUserGroups(
+	ctx context.Context,
+	_ *pb.ListUserGroupsRequest,
+) (*pb.ListUserGroupsResponse, error) {
+	groups, err := s.storage.ListUserGroups(ctx)
+	if err != nil {
+		return nil, storageError(err, "failed to list userGroups")
+	}
+
+	resp := &pb.ListUserGroupsResponse{Usergroups: make([]*pb.UserGroup, 0, len(groups))}
+	for i := range groups {
+		resp.Usergroups = append(resp.Usergroups, userGroupToPb(&groups[i]))
+	}
+	return resp, nil
+}
+
+func (s *ServiceServer) UpdateUserGroup(
+	ctx context.Context,
+	req *pb.UpdateUserGroupRequest,
+) (*pb.UserGroupResponse, error) {
+	name, err := validateName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.storage.UpdateUserGroup(ctx, int(req.GetId()), name)
+	if err != nil {
+		return nil, storageError(err, "failed to update userGroup")
+	}
+	return &pb.UserGroupResponse{Usergroup: userGroupToPb(group)}, nil
+}
+
+func (s *ServiceServer) DeleteUserGroup(
+	ctx context.Context,
+	req *pb.DeleteUserGroupRequest,
+) (*pb.DeleteUserGroupResponse, error) {
+	if err := s.storage.DeleteUserGroup(ctx, int(req.GetId())); err != nil {
+		return nil, storageError(err, "failed to delete userGroup")
+	}
+	return &pb.DeleteUserGroupResponse{Message: "UserGroup deleted successfully"}, nil
+}
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", status.Errorf(codes.InvalidArgument, "name must not be empty")
+	}
+	return name, nil
+}
+
+// storageError переводит ошибку хранилища в gRPC-статус.
+func storageError(err error, msg string) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+func slotToPb(slot *storage.Slot) *pb.Slot {
+	return &pb.Slot{
+		Id:        int32(slot.ID),
+		Name:      slot.Name,
+		CreatedAt: timestamppb.New(slot.CreatedAt),
+	}
+}
+
+func bannerToPb(banner *storage.Banner) *pb.Banner {
+	return &pb.Banner{
+		Id:        int32(banner.ID),
+		Name:      banner.Name,
+		CreatedAt: timestamppb.New(banner.CreatedAt),
+	}
+}
+
+func userGroupToPb(group *storage.UserGroup) *pb.UserGroup {
+	return &pb.UserGroup{
+		Id:        int32(group.ID),
+		Name:      group.Name,
+		CreatedAt: timestamppb.New(group.CreatedAt),
+	}
+}
@@ -2,31 +2,53 @@ package internalgrpc
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"net/netip"
 	"time"
 
 	"github.com/dianapovarnitsina/banners-rotation/interfaces"
+	"github.com/dianapovarnitsina/banners-rotation/internal/eventfeed"
+	"github.com/dianapovarnitsina/banners-rotation/internal/frequency"
+	"github.com/dianapovarnitsina/banners-rotation/internal/impressiontoken"
 	"github.com/dianapovarnitsina/banners-rotation/internal/logger"
-	"github.com/dianapovarnitsina/banners-rotation/internal/rmq"
 	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
 	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
-	"github.com/streadway/amqp"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type ServiceServer struct {
-	storage      interfaces.Storage
-	eventsProdMq *rmq.Rmq
-	logger       *logger.Logger
+	storage   interfaces.Storage
+	events    *eventfeed.Hub
+	tokens    *impressiontoken.Signer
+	clicks    storage.ClickGuard
+	frequency *frequency.Capper
+	logger    *logger.Logger
+	// trustedProxies - прокси, от которых принимается X-Forwarded-For (см. clientIP).
+	trustedProxies []netip.Prefix
 	pb.UnimplementedBannerServiceServer
 }
 
-func NewEventServiceServer(storage interfaces.Storage, eventsProdMq *rmq.Rmq, log *logger.Logger) *ServiceServer {
+// NewEventServiceServer создает сервер API. Уведомления о событиях хранилище
+// записывает в outbox, откуда их публикует outbox.Relay; подписчикам StreamEvents
+// они рассылаются через events. Токены показов подписываются tokens, переходы
+// проверяются правилами clicks, частота показов одному пользователю ограничивается frequency.
+func NewEventServiceServer(
+	storage interfaces.Storage,
+	events *eventfeed.Hub,
+	tokens *impressiontoken.Signer,
+	clicks storage.ClickGuard,
+	frequency *frequency.Capper,
+	log *logger.Logger,
+) *ServiceServer {
 	return &ServiceServer{
-		storage:      storage,
-		eventsProdMq: eventsProdMq,
-		logger:       log,
+		storage:   storage,
+		events:    events,
+		tokens:    tokens,
+		clicks:    clicks,
+		frequency: frequency,
+		logger:    log,
 	}
 }
 
@@ -51,14 +73,77 @@ func (s *ServiceServer) AddBanner(ctx context.Context, req *pb.AddBannerRequest)
 		return nil, status.Errorf(codes.AlreadyExists, "banner is already assigned to the slot")
 	}
 
+	settings := rotationSettings(req)
+	if err := settings.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	// Добавление записи
-	if err := s.storage.AddBanner(ctx, bannerID, slotID); err != nil {
+	err := s.storage.AddBanner(ctx, bannerID, slotID, settings)
+	if errors.Is(err, storage.ErrShareExceeded) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to add banner: %v", err)
 	}
 
 	return &pb.AddBannerResponse{Message: "Banner added successfully"}, nil
 }
 
+// UpdateRotation заменяет условия ротации баннера в слоте. Набранные показы и переходы
+// сохраняются, поэтому новые ограничения учитывают уже открученный объем.
+func (s *ServiceServer) UpdateRotation(
+	ctx context.Context,
+	req *pb.UpdateRotationRequest,
+) (*pb.UpdateRotationResponse, error) {
+	settings := rotationSettings(req)
+	if err := settings.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	err := s.storage.UpdateRotation(ctx, int(req.GetBannerId()), int(req.GetSlotId()), settings)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "banner is not assigned to the slot")
+	}
+	if errors.Is(err, storage.ErrShareExceeded) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update banner rotation: %v", err)
+	}
+
+	return &pb.UpdateRotationResponse{Message: "Banner rotation updated successfully"}, nil
+}
+
+// rotationRequest - поля условий ротации, общие для AddBannerRequest и UpdateRotationRequest.
+type rotationRequest interface {
+	GetStartsAt() *timestamppb.Timestamp
+	GetEndsAt() *timestamppb.Timestamp
+	GetMaxImpressions() int64
+	GetMaxClicks() int64
+	GetMaxDailyImpressions() int64
+	GetPinned() bool
+	GetWeight() float64
+	GetMinShare() float64
+}
+
+func rotationSettings(req rotationRequest) storage.RotationSettings {
+	return storage.RotationSettings{
+		Flight: storage.Flight{
+			StartsAt: timeFromPb(req.GetStartsAt()),
+			EndsAt:   timeFromPb(req.GetEndsAt()),
+		},
+		Caps: storage.Caps{
+			MaxImpressions:      req.GetMaxImpressions(),
+			MaxClicks:           req.GetMaxClicks(),
+			MaxDailyImpressions: req.GetMaxDailyImpressions(),
+		},
+		Pinned:   req.GetPinned(),
+		Weight:   req.GetWeight(),
+		MinShare: req.GetMinShare(),
+	}
+}
+
 func (s *ServiceServer) checkDuplicateBannerSlot(ctx context.Context, bannerID, slotID int) (bool, error) {
 	return s.storage.IsBannerAssignedToSlot(ctx, bannerID, slotID)
 }
@@ -85,6 +170,44 @@ func (s *ServiceServer) RemoveBanner(
 	return &pb.RemoveBannerResponse{Message: "Banner removed successfully"}, nil
 }
 
+// PauseBanner приостанавливает показ баннера в слоте. Накопленная статистика сохраняется
+// и продолжает использоваться после возобновления.
+func (s *ServiceServer) PauseBanner(ctx context.Context, req *pb.PauseBannerRequest) (*pb.PauseBannerResponse, error) {
+	if err := s.setBannerPaused(ctx, int(req.GetBannerId()), int(req.GetSlotId()), true); err != nil {
+		return nil, err
+	}
+	return &pb.PauseBannerResponse{Message: "Banner paused successfully"}, nil
+}
+
+func (s *ServiceServer) ResumeBanner(
+	ctx context.Context,
+	req *pb.ResumeBannerRequest,
+) (*pb.ResumeBannerResponse, error) {
+	if err := s.setBannerPaused(ctx, int(req.GetBannerId()), int(req.GetSlotId()), false); err != nil {
+		return nil, err
+	}
+	return &pb.ResumeBannerResponse{Message: "Banner resumed successfully"}, nil
+}
+
+// pickError переводит ошибку выбора баннера в gRPC-статус.
+func pickError(message string, err error) error {
+	if errors.Is(err, storage.ErrNoActiveBanners) {
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", message, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", message, err)
+}
+
+func (s *ServiceServer) setBannerPaused(ctx context.Context, bannerID, slotID int, paused bool) error {
+	err := s.storage.SetBannerPaused(ctx, bannerID, slotID, paused)
+	if errors.Is(err, storage.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "banner is not assigned to the slot")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to update banner rotation: %v", err)
+	}
+	return nil
+}
+
 func (s *ServiceServer) ClickBanner(ctx context.Context, req *pb.ClickBannerRequest) (*pb.ClickBannerResponse, error) {
 	bannerID := int(req.GetBannerId())
 	slotID := int(req.GetSlotId())
@@ -105,97 +228,116 @@ func (s *ServiceServer) ClickBanner(ctx context.Context, req *pb.ClickBannerRequ
 		return nil, status.Errorf(codes.NotFound, "specified userGroup does not exist")
 	}
 
-	click, err := s.storage.ClickBanner(ctx, bannerID, slotID, userGroupID)
+	// Переход с токеном привязывается к подтвержденному показу
+	claims, err := s.clickToken(req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to click banner: %v", err)
+		return nil, err
 	}
 
-	// Отправка уведомления в очередь
-	notification := createClickNotification(click)
-	err = s.sendNotification(notification)
-
+	attempt := storage.ClickAttempt{
+		BannerID:          bannerID,
+		SlotID:            slotID,
+		UserGroupID:       userGroupID,
+		ImpressionTokenID: claims.ID,
+		ClientKey:         s.clientIP(ctx),
+	}
+	click, err := s.storage.ClickBanner(ctx, attempt, s.clicks)
 	if err != nil {
-		s.logger.Error("Failed to RMQ: %v", err)
+		return nil, s.clickError(attempt, err)
 	}
+	s.publish(storage.NewClickNotification(click), click.CapsReached)
+
 	return &pb.ClickBannerResponse{Message: "Banner clicked successfully"}, nil
 }
 
+// PickBanner выбирает баннер для показа. Баннеры, которые пользователь user_key видел
+// чаще ограничения частоты показов, исключаются до выбора стратегией.
 func (s *ServiceServer) PickBanner(ctx context.Context, req *pb.PickBannerRequest) (*pb.PickBannerResponse, error) {
 	slotID := int(req.GetSlotId())
 	userGroupID := int(req.GetUsergroupId())
 
-	impress, bannerID, err := s.storage.PickBanner(ctx, slotID, userGroupID)
+	excluded, err := s.frequency.Excluded(ctx, req.GetUserKey(), time.Now())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to pick banner: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to load view history: %v", err)
 	}
 
-	// Отправка уведомления в очередь
-	notification := createImpressNotification(impress)
-	err = s.sendNotification(notification)
+	bannerID, err := s.storage.PickBanner(ctx, slotID, userGroupID, excluded)
+	if err != nil {
+		return nil, pickError("failed to pick banner", err)
+	}
 
+	token, expiresAt, err := s.issueToken(slotID, bannerID, userGroupID, req.GetUserKey())
 	if err != nil {
-		s.logger.Error("Failed to RMQ: %v", err)
+		return nil, err
 	}
 
-	return &pb.PickBannerResponse{BannerId: int32(bannerID), Message: "Banner picked successfully"}, nil
+	return &pb.PickBannerResponse{
+		BannerId:        int32(bannerID),
+		Message:         "Banner picked successfully",
+		ImpressionToken: token,
+		ExpiresAt:       expiresAt,
+	}, nil
 }
 
-func (s *ServiceServer) sendNotification(notification storage.Notification) error {
-	notificationJSON, err := serializeNotification(notification)
-	if err != nil {
-		s.logger.Error("Failed to serialize notification: %v", err)
-		return err
-	}
+// maxPickBannersSlots ограничивает число слотов в одном запросе PickBanners.
+const maxPickBannersSlots = 50
 
-	err = publishNotificationToRMQ(notificationJSON, s.eventsProdMq)
-	if err != nil {
-		s.logger.Error("Failed to publish notification to RMQ: %v", err)
-		return err
+// PickBanners выбирает баннеры для слотов страницы. Ограничение частоты показов пользователю
+// user_key применяется так же, как в PickBanner.
+func (s *ServiceServer) PickBanners(ctx context.Context, req *pb.PickBannersRequest) (*pb.PickBannersResponse, error) {
+	if len(req.GetSlotIds()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "slot_ids must not be empty")
+	}
+	if len(req.GetSlotIds()) > maxPickBannersSlots {
+		return nil, status.Errorf(codes.InvalidArgument, "too many slot_ids: at most %d allowed", maxPickBannersSlots)
 	}
 
-	s.logger.Info(
-		"Sent a notification to queue RabbitMQ: %s %s",
-		string(notificationJSON),
-		time.Now().Format("2006-01-02 15:04"),
-	)
-	return nil
-}
-
-func createClickNotification(click *storage.Click) storage.Notification {
-	notification := storage.Notification{
-		TypeEvent:   "click",
-		SlotID:      click.SlotID,
-		BannerID:    click.BannerID,
-		UsergroupID: click.UserGroupID,
-		DateTime:    click.CreatedAt,
+	slotIDs := make([]int, 0, len(req.GetSlotIds()))
+	seen := make(map[int]struct{}, len(req.GetSlotIds()))
+	for _, id := range req.GetSlotIds() {
+		slotID := int(id)
+		if _, ok := seen[slotID]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "duplicate slot_id: %d", slotID)
+		}
+		if !s.slotExists(ctx, slotID) {
+			return nil, status.Errorf(codes.NotFound, "slot %d does not exist", slotID)
+		}
+		seen[slotID] = struct{}{}
+		slotIDs = append(slotIDs, slotID)
 	}
-	return notification
-}
 
-func createImpressNotification(impress *storage.Impress) storage.Notification {
-	notification := storage.Notification{
-		TypeEvent:   "impress",
-		SlotID:      impress.SlotID,
-		BannerID:    impress.BannerID,
-		UsergroupID: impress.UserGroupID,
-		DateTime:    impress.CreatedAt,
+	userGroupID := int(req.GetUsergroupId())
+	if !s.userGroupExists(ctx, userGroupID) {
+		return nil, status.Errorf(codes.NotFound, "specified userGroup does not exist")
 	}
-	return notification
-}
 
-func serializeNotification(notification storage.Notification) ([]byte, error) {
-	notificationJSON, err := json.Marshal(notification)
+	excluded, err := s.frequency.Excluded(ctx, req.GetUserKey(), time.Now())
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.Internal, "failed to load view history: %v", err)
 	}
-	return notificationJSON, nil
-}
 
-func publishNotificationToRMQ(notificationJSON []byte, rmq *rmq.Rmq) error {
-	msg := amqp.Publishing{
-		ContentType: "application/json",
-		Body:        notificationJSON,
+	// Слот без доступных баннеров остается пустым и не мешает заполнить остальные
+	bannerIDs, err := s.storage.PickBanners(ctx, slotIDs, userGroupID, excluded, req.GetUniqueBanners())
+	if err != nil {
+		return nil, pickError("failed to pick banners", err)
 	}
 
-	return rmq.Publish(msg)
+	resp := &pb.PickBannersResponse{Banners: make([]*pb.SlotBanner, 0, len(bannerIDs))}
+	for i, bannerID := range bannerIDs {
+		if bannerID == 0 {
+			resp.Banners = append(resp.Banners, &pb.SlotBanner{SlotId: int32(slotIDs[i])})
+			continue
+		}
+		token, expiresAt, err := s.issueToken(slotIDs[i], bannerID, userGroupID, req.GetUserKey())
+		if err != nil {
+			return nil, err
+		}
+		resp.Banners = append(resp.Banners, &pb.SlotBanner{
+			SlotId:          int32(slotIDs[i]),
+			BannerId:        int32(bannerID),
+			ImpressionToken: token,
+			ExpiresAt:       expiresAt,
+		})
+	}
+	return resp, nil
 }
@@ -0,0 +1,132 @@
+package internalgrpc
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
+	"github.com/dianapovarnitsina/banners-rotation/internal/statefile"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stateChunkSize - размер части снимка в потоке ExportState.
+const stateChunkSize = 64 << 10
+
+// ExportState передает снимок состояния частями по stateChunkSize байт.
+func (s *ServiceServer) ExportState(req *pb.ExportStateRequest, stream pb.BannerService_ExportStateServer) error {
+	if err := statefile.CheckFormat(req.GetFormat()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	state, err := s.storage.ExportState(stream.Context())
+	if err != nil {
+		return storageError(err, "failed to export state")
+	}
+
+	w := bufio.NewWriterSize(chunkWriter{stream: stream}, stateChunkSize)
+	if err := statefile.Write(w, req.GetFormat(), state); err != nil {
+		return status.Errorf(codes.Internal, "failed to write state: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		return status.Errorf(codes.Internal, "failed to write state: %v", err)
+	}
+	return nil
+}
+
+// chunkWriter отправляет каждую запись в поток отдельным сообщением.
+type chunkWriter struct {
+	stream pb.BannerService_ExportStateServer
+}
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	// Буфер переиспользуется bufio.Writer, а сообщение может отправляться асинхронно
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := w.stream.Send(&pb.StateChunk{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ImportState загружает снимок, переданный частями. Режим импорта задает первое сообщение.
+func (s *ServiceServer) ImportState(stream pb.BannerService_ImportStateServer) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Errorf(codes.InvalidArgument, "import options are required")
+	}
+	if err != nil {
+		return err
+	}
+
+	opts := storage.ImportOptions{OnConflict: first.GetOnConflict(), DryRun: first.GetDryRun()}
+	if err := opts.Validate(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := statefile.CheckFormat(first.GetFormat()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	state, err := statefile.Read(&chunkReader{stream: stream, data: first.GetData()}, first.GetFormat())
+	if err != nil {
+		return importError(err)
+	}
+	result, err := s.storage.ImportState(stream.Context(), state, opts)
+	if err != nil {
+		return importError(err)
+	}
+
+	return stream.SendAndClose(&pb.ImportStateResponse{
+		DryRun:     opts.DryRun,
+		Slots:      importCountsToPb(result.Slots),
+		Banners:    importCountsToPb(result.Banners),
+		Usergroups: importCountsToPb(result.UserGroups),
+		Rotations:  importCountsToPb(result.Rotations),
+		Stats:      importCountsToPb(result.Stats),
+	})
+}
+
+// chunkReader читает data сообщений потока ImportState по порядку.
+type chunkReader struct {
+	stream pb.BannerService_ImportStateServer
+	data   []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.data = req.GetData()
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func importError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrInvalidState):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, storage.ErrImportConflict):
+		return status.Errorf(codes.AlreadyExists, "%v", err)
+	case errors.Is(err, storage.ErrShareExceeded):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case status.Code(err) != codes.Unknown:
+		// Ошибка чтения потока уже содержит статус
+		return err
+	default:
+		return status.Errorf(codes.Internal, "failed to import state: %v", err)
+	}
+}
+
+func importCountsToPb(counts storage.ImportCounts) *pb.ImportCounts {
+	return &pb.ImportCounts{
+		Created: int32(counts.Created),
+		Updated: int32(counts.Updated),
+		Skipped: int32(counts.Skipped),
+	}
+}
@@ -0,0 +1,77 @@
+package internalgrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *ServiceServer) GetBannerStats(
+	ctx context.Context,
+	req *pb.GetBannerStatsRequest,
+) (*pb.GetBannerStatsResponse, error) {
+	filter := storage.StatsFilter{
+		SlotID:      int(req.GetSlotId()),
+		UserGroupID: int(req.GetUsergroupId()),
+		From:        timeFromPb(req.GetFrom()),
+		To:          timeFromPb(req.GetTo()),
+	}
+
+	// Проверка на несуществующий слот
+	if !s.slotExists(ctx, filter.SlotID) {
+		return nil, status.Errorf(codes.NotFound, "specified slot does not exist")
+	}
+
+	// Проверка на несуществующую группу, если фильтр по группе задан
+	if filter.UserGroupID != 0 && !s.userGroupExists(ctx, filter.UserGroupID) {
+		return nil, status.Errorf(codes.NotFound, "specified userGroup does not exist")
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, status.Errorf(codes.InvalidArgument, "'from' must be before 'to'")
+	}
+
+	stats, err := s.storage.BannerStats(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get banner stats: %v", err)
+	}
+
+	banners := make([]multiarmedbandit.Banner, 0, len(stats))
+	for i := range stats {
+		banners = append(banners, &stats[i])
+	}
+	// Оценка только для сравнения баннеров в отчете: выбор учитывает активность ротаций,
+	// затухание, априорные оценки и стратегию слота, здесь - только счетчики выборки
+	estimates := multiarmedbandit.UCB1{}.Scores(banners)
+
+	resp := &pb.GetBannerStatsResponse{Banners: make([]*pb.BannerStats, 0, len(stats))}
+	for _, bnr := range stats {
+		var ctr float64
+		if bnr.Impressions > 0 {
+			ctr = float64(bnr.Clicks) / float64(bnr.Impressions)
+		}
+		resp.Banners = append(resp.Banners, &pb.BannerStats{
+			BannerId:     int32(bnr.BannerID),
+			Impressions:  int64(bnr.Impressions),
+			Clicks:       int64(bnr.Clicks),
+			Ctr:          ctr,
+			Ucb1Estimate: estimates[bnr.BannerID],
+		})
+	}
+
+	return resp, nil
+}
+
+// timeFromPb возвращает нулевое время для незаданной метки.
+func timeFromPb(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
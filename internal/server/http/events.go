@@ -0,0 +1,54 @@
+package internalhttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
+	"google.golang.org/grpc/metadata"
+)
+
+// streamEvents передает события StreamEvents как Server-Sent Events: каждое событие - строка
+// data с JSON-сообщением Event. Фильтры задаются параметрами slot_id, banner_id, usergroup_id
+// и type_event; поток идет до отключения клиента.
+func (s *Server) streamEvents(ctx context.Context, w http.ResponseWriter, r *http.Request, _ pathParams) error {
+	req := &pb.StreamEventsRequest{TypeEvent: r.URL.Query().Get("type_event")}
+	var err error
+	if req.SlotId, err = queryInt32(r, "slot_id"); err != nil {
+		return err
+	}
+	if req.BannerId, err = queryInt32(r, "banner_id"); err != nil {
+		return err
+	}
+	if req.UsergroupId, err = queryInt32(r, "usergroup_id"); err != nil {
+		return err
+	}
+	return s.api.StreamEvents(req, &eventsStream{serverStream: serverStream{ctx: ctx}, w: w})
+}
+
+// eventsStream пишет события в тело ответа в формате text/event-stream.
+type eventsStream struct {
+	serverStream
+	w http.ResponseWriter
+}
+
+// SendHeader начинает ответ: клиент узнает, что подписка уже действует.
+func (s *eventsStream) SendHeader(metadata.MD) error {
+	s.w.Header().Set("Content-Type", "text/event-stream")
+	s.w.Header().Set("Cache-Control", "no-cache")
+	s.w.WriteHeader(http.StatusOK)
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (s *eventsStream) Send(event *pb.Event) error {
+	data, err := marshalOptions.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.w, "data: %s\n\n", data)
+	return err
+}
@@ -0,0 +1,274 @@
+package internalhttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *Server) addBanner(ctx context.Context, r *http.Request, p pathParams) (proto.Message, error) {
+	req := &pb.AddBannerRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	slotID, err := p.int32("slot_id")
+	if err != nil {
+		return nil, err
+	}
+	req.SlotId = slotID
+	return s.api.AddBanner(ctx, req)
+}
+
+func (s *Server) updateRotation(ctx context.Context, r *http.Request, p pathParams) (proto.Message, error) {
+	req := &pb.UpdateRotationRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	slotID, err := p.int32("slot_id")
+	if err != nil {
+		return nil, err
+	}
+	bannerID, err := p.int32("banner_id")
+	if err != nil {
+		return nil, err
+	}
+	req.SlotId, req.BannerId = slotID, bannerID
+	return s.api.UpdateRotation(ctx, req)
+}
+
+func (s *Server) removeBanner(ctx context.Context, _ *http.Request, p pathParams) (proto.Message, error) {
+	slotID, err := p.int32("slot_id")
+	if err != nil {
+		return nil, err
+	}
+	bannerID, err := p.int32("banner_id")
+	if err != nil {
+		return nil, err
+	}
+	return s.api.RemoveBanner(ctx, &pb.RemoveBannerRequest{SlotId: slotID, BannerId: bannerID})
+}
+
+func (s *Server) pauseBanner(ctx context.Context, _ *http.Request, p pathParams) (proto.Message, error) {
+	slotID, err := p.int32("slot_id")
+	if err != nil {
+		return nil, err
+	}
+	bannerID, err := p.int32("banner_id")
+	if err != nil {
+		return nil, err
+	}
+	return s.api.PauseBanner(ctx, &pb.PauseBannerRequest{SlotId: slotID, BannerId: bannerID})
+}
+
+func (s *Server) resumeBanner(ctx context.Context, _ *http.Request, p pathParams) (proto.Message, error) {
+	slotID, err := p.int32("slot_id")
+	if err != nil {
+		return nil, err
+	}
+	bannerID, err := p.int32("banner_id")
+	if err != nil {
+		return nil, err
+	}
+	return s.api.ResumeBanner(ctx, &pb.ResumeBannerRequest{SlotId: slotID, BannerId: bannerID})
+}
+
+func (s *Server) pickBanner(ctx context.Context, r *http.Request, p pathParams) (proto.Message, error) {
+	req := &pb.PickBannerRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	slotID, err := p.int32("slot_id")
+	if err != nil {
+		return nil, err
+	}
+	req.SlotId = slotID
+	return s.api.PickBanner(ctx, req)
+}
+
+func (s *Server) pickBanners(ctx context.Context, r *http.Request, _ pathParams) (proto.Message, error) {
+	req := &pb.PickBannersRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	return s.api.PickBanners(ctx, req)
+}
+
+func (s *Server) recordImpression(ctx context.Context, r *http.Request, _ pathParams) (proto.Message, error) {
+	req := &pb.RecordImpressionRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	return s.api.RecordImpression(ctx, req)
+}
+
+func (s *Server) clickBanner(ctx context.Context, r *http.Request, _ pathParams) (proto.Message, error) {
+	req := &pb.ClickBannerRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	return s.api.ClickBanner(ctx, req)
+}
+
+func (s *Server) getBannerStats(ctx context.Context, r *http.Request, p pathParams) (proto.Message, error) {
+	slotID, err := p.int32("slot_id")
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.GetBannerStatsRequest{SlotId: slotID}
+	if req.UsergroupId, err = queryInt32(r, "usergroup_id"); err != nil {
+		return nil, err
+	}
+	if req.From, err = queryTime(r, "from"); err != nil {
+		return nil, err
+	}
+	if req.To, err = queryTime(r, "to"); err != nil {
+		return nil, err
+	}
+	return s.api.GetBannerStats(ctx, req)
+}
+
+func (s *Server) createSlot(ctx context.Context, r *http.Request, _ pathParams) (proto.Message, error) {
+	req := &pb.CreateSlotRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	return s.api.CreateSlot(ctx, req)
+}
+
+func (s *Server) listSlots(ctx context.Context, _ *http.Request, _ pathParams) (proto.Message, error) {
+	return s.api.ListSlots(ctx, &pb.ListSlotsRequest{})
+}
+
+func (s *Server) getSlot(ctx context.Context, _ *http.Request, p pathParams) (proto.Message, error) {
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	return s.api.GetSlot(ctx, &pb.GetSlotRequest{Id: id})
+}
+
+func (s *Server) updateSlot(ctx context.Context, r *http.Request, p pathParams) (proto.Message, error) {
+	req := &pb.UpdateSlotRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	req.Id = id
+	return s.api.UpdateSlot(ctx, req)
+}
+
+func (s *Server) deleteSlot(ctx context.Context, _ *http.Request, p pathParams) (proto.Message, error) {
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	return s.api.DeleteSlot(ctx, &pb.DeleteSlotRequest{Id: id})
+}
+
+func (s *Server) createBanner(ctx context.Context, r *http.Request, _ pathParams) (proto.Message, error) {
+	req := &pb.CreateBannerRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	return s.api.CreateBanner(ctx, req)
+}
+
+func (s *Server) listBanners(ctx context.Context, _ *http.Request, _ pathParams) (proto.Message, error) {
+	return s.api.ListBanners(ctx, &pb.ListBannersRequest{})
+}
+
+func (s *Server) getBanner(ctx context.Context, _ *http.Request, p pathParams) (proto.Message, error) {
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	return s.api.GetBanner(ctx, &pb.GetBannerRequest{Id: id})
+}
+
+func (s *Server) updateBanner(ctx context.Context, r *http.Request, p pathParams) (proto.Message, error) {
+	req := &pb.UpdateBannerRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	req.Id = id
+	return s.api.UpdateBanner(ctx, req)
+}
+
+func (s *Server) deleteBanner(ctx context.Context, _ *http.Request, p pathParams) (proto.Message, error) {
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	return s.api.DeleteBanner(ctx, &pb.DeleteBannerRequest{Id: id})
+}
+
+func (s *Server) createUserGroup(ctx context.Context, r *http.Request, _ pathParams) (proto.Message, error) {
+	req := &pb.CreateUserGroupRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	return s.api.CreateUserGroup(ctx, req)
+}
+
+func (s *Server) listUserGroups(ctx context.Context, _ *http.Request, _ pathParams) (proto.Message, error) {
+	return s.api.ListUserGroups(ctx, &pb.ListUserGroupsRequest{})
+}
+
+func (s *Server) getUserGroup(ctx context.Context, _ *http.Request, p pathParams) (proto.Message, error) {
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	return s.api.GetUserGroup(ctx, &pb.GetUserGroupRequest{Id: id})
+}
+
+func (s *Server) updateUserGroup(ctx context.Context, r *http.Request, p pathParams) (proto.Message, error) {
+	req := &pb.UpdateUserGroupRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	req.Id = id
+	return s.api.UpdateUserGroup(ctx, req)
+}
+
+func (s *Server) deleteUserGroup(ctx context.Context, _ *http.Request, p pathParams) (proto.Message, error) {
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	return s.api.DeleteUserGroup(ctx, &pb.DeleteUserGroupRequest{Id: id})
+}
+
+func (s *Server) getUserGroupFeatures(ctx context.Context, _ *http.Request, p pathParams) (proto.Message, error) {
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	return s.api.GetUserGroupFeatures(ctx, &pb.GetUserGroupFeaturesRequest{UsergroupId: id})
+}
+
+func (s *Server) setUserGroupFeatures(ctx context.Context, r *http.Request, p pathParams) (proto.Message, error) {
+	req := &pb.SetUserGroupFeaturesRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	id, err := p.int32("id")
+	if err != nil {
+		return nil, err
+	}
+	req.UsergroupId = id
+	return s.api.SetUserGroupFeatures(ctx, req)
+}
@@ -0,0 +1,261 @@
+package internalhttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// handlerFunc выполняет запрос и возвращает ответ в виде proto-сообщения.
+type handlerFunc func(ctx context.Context, r *http.Request, params pathParams) (proto.Message, error)
+
+// streamHandlerFunc сам пишет ответ по мере получения данных от потокового метода API.
+// Ошибка до начала ответа передается клиенту как обычно, после начала - обрывает соединение.
+type streamHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, params pathParams) error
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+	stream   streamHandlerFunc
+}
+
+// router сопоставляет запрос с шаблонами вида /slots/{slot_id}/pick.
+type router struct {
+	routes []route
+}
+
+func (rt *router) handle(method, pattern string, handler handlerFunc) {
+	rt.routes = append(rt.routes, route{
+		method:   method,
+		segments: splitPath(pattern),
+		handler:  handler,
+	})
+}
+
+func (rt *router) handleStream(method, pattern string, handler streamHandlerFunc) {
+	rt.routes = append(rt.routes, route{
+		method:   method,
+		segments: splitPath(pattern),
+		stream:   handler,
+	})
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := splitPath(r.URL.Path)
+
+	pathMatched := false
+	for _, route := range rt.routes {
+		params, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if route.method != r.Method {
+			continue
+		}
+
+		// Шлюз вызывает API напрямую, поэтому адрес клиента и цепочка прокси передаются
+		// так же, как от gRPC-транспорта
+		ctx := peer.NewContext(r.Context(), &peer.Peer{Addr: clientAddr(r.RemoteAddr)})
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", strings.Join(forwarded, ",")))
+		}
+		if route.stream != nil {
+			serveStream(ctx, w, r, params, route.stream)
+			return
+		}
+		resp, err := route.handler(ctx, r, params)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeMessage(w, http.StatusOK, resp)
+		return
+	}
+
+	if pathMatched {
+		writeStatus(w, http.StatusMethodNotAllowed, status.New(codes.Unimplemented, "method not allowed"))
+		return
+	}
+	writeError(w, status.Error(codes.NotFound, "route not found"))
+}
+
+func (r route) match(segments []string) (pathParams, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+
+	params := make(pathParams)
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[segment[1:len(segment)-1]] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+type pathParams map[string]string
+
+// int32 возвращает числовой параметр пути.
+func (p pathParams) int32(name string) (int32, error) {
+	value, err := strconv.ParseInt(p[name], 10, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s: %q", name, p[name])
+	}
+	return int32(value), nil
+}
+
+// queryInt32 возвращает необязательный числовой параметр запроса.
+func queryInt32(r *http.Request, name string) (int32, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s: %q", name, raw)
+	}
+	return int32(value), nil
+}
+
+// queryTime возвращает необязательный параметр запроса в формате RFC 3339.
+func queryTime(r *http.Request, name string) (*timestamppb.Timestamp, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %q", name, raw)
+	}
+	return timestamppb.New(value), nil
+}
+
+// queryBool возвращает необязательный логический параметр запроса.
+func queryBool(r *http.Request, name string) (bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "invalid %s: %q", name, raw)
+	}
+	return value, nil
+}
+
+// clientAddr - адрес HTTP-клиента (host:port) в виде net.Addr.
+type clientAddr string
+
+func (a clientAddr) Network() string {
+	return "tcp"
+}
+
+func (a clientAddr) String() string {
+	return string(a)
+}
+
+// decodeBody заполняет сообщение из JSON-тела запроса. Пустое тело допустимо.
+func decodeBody(r *http.Request, msg proto.Message) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "cannot read body: %v", err)
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil
+	}
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid JSON body: %v", err)
+	}
+	return nil
+}
+
+var marshalOptions = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+func writeMessage(w http.ResponseWriter, code int, msg proto.Message) {
+	body, err := marshalOptions.Marshal(msg)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "cannot encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.New(codes.Internal, err.Error())
+	}
+	if errors.Is(err, context.Canceled) {
+		st = status.New(codes.Canceled, err.Error())
+	}
+	writeStatus(w, HTTPStatusFromCode(st.Code()), st)
+}
+
+func writeStatus(w http.ResponseWriter, code int, st *status.Status) {
+	body, _ := marshalOptions.Marshal(st.Proto())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// HTTPStatusFromCode переводит код gRPC-статуса в HTTP-код.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unknown, codes.Internal, codes.DataLoss:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
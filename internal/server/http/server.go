@@ -0,0 +1,134 @@
+package internalhttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/logger"
+	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
+)
+
+const maxBodySize = 1 << 20
+
+// Server - REST/JSON-шлюз к методам BannerService.
+// Валидация и бизнес-логика не дублируются: запросы передаются в тот же pb.BannerServiceServer,
+// что обслуживает gRPC.
+type Server struct {
+	api    pb.BannerServiceServer
+	logger *logger.Logger
+	server *http.Server
+}
+
+func NewServer(api pb.BannerServiceServer, logg *logger.Logger, host string, port int) *Server {
+	s := &Server{
+		api:    api,
+		logger: logg,
+	}
+	s.server = &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", host, port),
+		Handler:           s.loggingMiddleware(s.routes()),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+func (s *Server) Start() error {
+	s.logger.Info("Starting HTTP server on %s", s.server.Addr)
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Server) Handler() http.Handler {
+	return s.server.Handler
+}
+
+func (s *Server) routes() *router {
+	rt := &router{}
+
+	// Ротация баннеров.
+	rt.handle(http.MethodPost, "/slots/{slot_id}/banners", s.addBanner)
+	rt.handle(http.MethodPut, "/slots/{slot_id}/banners/{banner_id}", s.updateRotation)
+	rt.handle(http.MethodDelete, "/slots/{slot_id}/banners/{banner_id}", s.removeBanner)
+	rt.handle(http.MethodPost, "/slots/{slot_id}/banners/{banner_id}/pause", s.pauseBanner)
+	rt.handle(http.MethodPost, "/slots/{slot_id}/banners/{banner_id}/resume", s.resumeBanner)
+	rt.handle(http.MethodPost, "/slots/{slot_id}/pick", s.pickBanner)
+	rt.handle(http.MethodPost, "/pick", s.pickBanners)
+	rt.handle(http.MethodPost, "/impressions", s.recordImpression)
+	rt.handle(http.MethodPost, "/clicks", s.clickBanner)
+	rt.handle(http.MethodGet, "/slots/{slot_id}/stats", s.getBannerStats)
+	rt.handleStream(http.MethodGet, "/events", s.streamEvents)
+
+	// Слоты.
+	rt.handle(http.MethodPost, "/slots", s.createSlot)
+	rt.handle(http.MethodGet, "/slots", s.listSlots)
+	rt.handle(http.MethodGet, "/slots/{id}", s.getSlot)
+	rt.handle(http.MethodPut, "/slots/{id}", s.updateSlot)
+	rt.handle(http.MethodDelete, "/slots/{id}", s.deleteSlot)
+
+	// Баннеры.
+	rt.handle(http.MethodPost, "/banners", s.createBanner)
+	rt.handle(http.MethodGet, "/banners", s.listBanners)
+	rt.handle(http.MethodGet, "/banners/{id}", s.getBanner)
+	rt.handle(http.MethodPut, "/banners/{id}", s.updateBanner)
+	rt.handle(http.MethodDelete, "/banners/{id}", s.deleteBanner)
+
+	// Соц-дем. группы.
+	rt.handle(http.MethodPost, "/usergroups", s.createUserGroup)
+	rt.handle(http.MethodGet, "/usergroups", s.listUserGroups)
+	rt.handle(http.MethodGet, "/usergroups/{id}", s.getUserGroup)
+	rt.handle(http.MethodPut, "/usergroups/{id}", s.updateUserGroup)
+	rt.handle(http.MethodDelete, "/usergroups/{id}", s.deleteUserGroup)
+	rt.handle(http.MethodGet, "/usergroups/{id}/features", s.getUserGroupFeatures)
+	rt.handle(http.MethodPut, "/usergroups/{id}/features", s.setUserGroupFeatures)
+
+	// Снимок состояния.
+	rt.handleStream(http.MethodGet, "/state", s.exportState)
+	rt.handle(http.MethodPost, "/state", s.importState)
+
+	return rt
+}
+
+// statusRecorder запоминает HTTP-код ответа для логирования.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Flush нужен потоковым ответам, которые отправляют данные частями.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		s.logger.Info("INFO [%s] { ClientIPAddress:%s StartAt:%s HTTPMethod:%s Path:%s StatusCode:%d Latency:%s}",
+			time.Now().Format("2006-01-02 15:04:05"),
+			r.RemoteAddr,
+			startTime.Format("2006-01-02 15:04:05.999999 -0700 MST"),
+			r.Method,
+			r.URL.Path,
+			rec.status,
+			time.Since(startTime),
+		)
+	})
+}
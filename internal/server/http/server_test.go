@@ -0,0 +1,469 @@
+package internalhttp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/eventfeed"
+	"github.com/dianapovarnitsina/banners-rotation/internal/frequency"
+	"github.com/dianapovarnitsina/banners-rotation/internal/impressiontoken"
+	"github.com/dianapovarnitsina/banners-rotation/internal/logger"
+	internalgrpc "github.com/dianapovarnitsina/banners-rotation/internal/server/grpc"
+	stor "github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage/memory"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return serveTestAPI(t, newTestAPI(t))
+}
+
+func newTestAPI(t *testing.T) *internalgrpc.ServiceServer {
+	t.Helper()
+
+	storage := memory.New(nil)
+	require.NoError(t, storage.Migrate(context.Background(), ""))
+
+	logg := logger.New("error", io.Discard)
+	tokens, err := impressiontoken.NewSigner([]byte("test"), "15m")
+	require.NoError(t, err)
+	clicks := stor.ClickGuard{ImpressionWindow: time.Hour, ClientWindow: time.Minute}
+	capper := frequency.NewCapper(frequency.Cap{MaxViews: 2, Period: time.Hour}, frequency.NewMemoryHistory(time.Hour))
+	return internalgrpc.NewEventServiceServer(storage, eventfeed.NewHub(0), tokens, clicks, capper, logg)
+}
+
+func serveTestAPI(t *testing.T, api *internalgrpc.ServiceServer) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(NewServer(api, logger.New("error", io.Discard), "localhost", 0).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func doRequest(t *testing.T, method, url, body string) (int, map[string]any) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var decoded map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	return resp.StatusCode, decoded
+}
+
+func TestPickAndClick(t *testing.T) {
+	server := newTestServer(t)
+
+	code, body := doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "Banner picked successfully", body["message"])
+	bannerID := body["banner_id"].(float64)
+	require.Contains(t, []float64{1, 4}, bannerID)
+	token := body["impression_token"].(string)
+	require.NotEmpty(t, token)
+
+	code, body = doRequest(t, http.MethodPost, server.URL+"/impressions",
+		fmt.Sprintf(`{"impression_token": %q}`, token))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "Impression recorded successfully", body["message"])
+
+	// Повторное подтверждение того же показа отклоняется
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/impressions",
+		fmt.Sprintf(`{"impression_token": %q}`, token))
+	require.Equal(t, http.StatusConflict, code)
+
+	code, body = doRequest(t, http.MethodPost, server.URL+"/clicks",
+		fmt.Sprintf(`{"slot_id": 1, "banner_id": %d, "usergroup_id": 1, "impression_token": %q}`, int(bannerID), token))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "Banner clicked successfully", body["message"])
+
+	// Повторный переход по тому же показу отклоняется
+	code, body = doRequest(t, http.MethodPost, server.URL+"/clicks",
+		fmt.Sprintf(`{"slot_id": 1, "banner_id": %d, "usergroup_id": 1, "impression_token": %q, "client_key": "user-2"}`,
+			int(bannerID), token))
+	require.Equal(t, http.StatusConflict, code)
+	require.Contains(t, body["message"], stor.RejectDuplicateImpression)
+
+	// Токен выдан для другого баннера
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/clicks",
+		fmt.Sprintf(`{"slot_id": 2, "banner_id": %d, "usergroup_id": 1, "impression_token": %q}`, int(bannerID), token))
+	require.Equal(t, http.StatusBadRequest, code)
+
+	// Повторный переход того же клиента по баннеру в слоте отклоняется. Клиент - адрес запроса,
+	// поэтому берется второй баннер слота, по первому он уже перешел
+	click := fmt.Sprintf(`{"slot_id": 1, "banner_id": %d, "usergroup_id": 1, "client_key": "user-3"}`, 5-int(bannerID))
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/clicks", click)
+	require.Equal(t, http.StatusOK, code)
+	code, body = doRequest(t, http.MethodPost, server.URL+"/clicks", click)
+	require.Equal(t, http.StatusConflict, code)
+	require.Contains(t, body["message"], stor.RejectDuplicateClient)
+
+	// Новый client_key с того же адреса не обходит окно отсечения
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/clicks", strings.Replace(click, "user-3", "user-4", 1))
+	require.Equal(t, http.StatusConflict, code)
+
+	code, body = doRequest(t, http.MethodGet, server.URL+"/slots/1/stats?usergroup_id=1", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body["banners"], 2)
+}
+
+func TestClickForwardedFor(t *testing.T) {
+	api := newTestAPI(t)
+	proxies, err := internalgrpc.ParseTrustedProxies([]string{"127.0.0.0/8", "::1"})
+	require.NoError(t, err)
+	api.SetTrustedProxies(proxies)
+	server := serveTestAPI(t, api)
+
+	click := func(forwardedFor string) int {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/clicks",
+			strings.NewReader(`{"slot_id": 1, "banner_id": 1, "usergroup_id": 1}`))
+		require.NoError(t, err)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// За доверенным прокси клиенты различаются по X-Forwarded-For
+	require.Equal(t, http.StatusOK, click("10.0.0.1"))
+	require.Equal(t, http.StatusConflict, click("10.0.0.1"))
+	require.Equal(t, http.StatusOK, click("10.0.0.2"))
+
+	// Адрес, дописанный клиентом слева, не меняет ключ
+	require.Equal(t, http.StatusConflict, click("10.0.0.9, 10.0.0.1"))
+	// Адреса доверенных прокси справа пропускаются
+	require.Equal(t, http.StatusConflict, click("10.0.0.2, 127.0.0.2"))
+}
+
+func TestPickBanners(t *testing.T) {
+	server := newTestServer(t)
+
+	code, body := doRequest(t, http.MethodPost, server.URL+"/pick",
+		`{"slot_ids": [1, 2, 3], "usergroup_id": 1, "unique_banners": true}`)
+	require.Equal(t, http.StatusOK, code)
+	banners := body["banners"].([]any)
+	require.Len(t, banners, 3)
+	require.Equal(t, float64(1), banners[0].(map[string]any)["slot_id"])
+
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/pick", `{"slot_ids": [1, 1], "usergroup_id": 1}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/pick", `{"usergroup_id": 1}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/pick", `{"slot_ids": [1, 99], "usergroup_id": 1}`)
+	require.Equal(t, http.StatusNotFound, code)
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/pick", `{"slot_ids": [1], "usergroup_id": 99}`)
+	require.Equal(t, http.StatusNotFound, code)
+
+	// Слот без активных баннеров остается пустым, остальные заполняются
+	for _, bannerID := range []string{"2", "5"} {
+		code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/2/banners/"+bannerID+"/pause", "")
+		require.Equal(t, http.StatusOK, code)
+	}
+	code, body = doRequest(t, http.MethodPost, server.URL+"/pick", `{"slot_ids": [1, 2], "usergroup_id": 1}`)
+	require.Equal(t, http.StatusOK, code)
+	banners = body["banners"].([]any)
+	require.Len(t, banners, 2)
+	require.NotEmpty(t, banners[0].(map[string]any)["impression_token"])
+	require.Equal(t, float64(0), banners[1].(map[string]any)["banner_id"])
+	require.Empty(t, banners[1].(map[string]any)["impression_token"])
+}
+
+func TestPauseBanner(t *testing.T) {
+	server := newTestServer(t)
+
+	code, body := doRequest(t, http.MethodPost, server.URL+"/slots/1/banners/1/pause", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "Banner paused successfully", body["message"])
+
+	code, body = doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(4), body["banner_id"])
+
+	// В слоте не осталось активных баннеров
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/banners/4/pause", "")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1}`)
+	require.Equal(t, http.StatusPreconditionFailed, code)
+
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/banners/1/resume", "")
+	require.Equal(t, http.StatusOK, code)
+	code, body = doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(1), body["banner_id"])
+
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/banners/2/pause", "")
+	require.Equal(t, http.StatusNotFound, code)
+
+	// Баннер с периодом показа, который уже закончился
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/3/banners",
+		`{"banner_id": 7, "starts_at": "2023-01-01T00:00:00Z", "ends_at": "2023-02-01T00:00:00Z"}`)
+	require.Equal(t, http.StatusOK, code)
+	for i := 0; i < 5; i++ {
+		code, body = doRequest(t, http.MethodPost, server.URL+"/slots/3/pick", `{"usergroup_id": 1}`)
+		require.Equal(t, http.StatusOK, code)
+		require.NotEqual(t, float64(7), body["banner_id"])
+	}
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/3/banners",
+		`{"banner_id": 8, "starts_at": "2023-02-01T00:00:00Z", "ends_at": "2023-01-01T00:00:00Z"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestBannerCaps(t *testing.T) {
+	server := newTestServer(t)
+
+	code, _ := doRequest(t, http.MethodPost, server.URL+"/slots/1/banners", `{"banner_id": 7, "max_impressions": 1}`)
+	require.Equal(t, http.StatusOK, code)
+	for _, bannerID := range []string{"1", "4"} {
+		code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/banners/"+bannerID+"/pause", "")
+		require.Equal(t, http.StatusOK, code)
+	}
+
+	code, body := doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(7), body["banner_id"])
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/impressions",
+		fmt.Sprintf(`{"impression_token": %q}`, body["impression_token"]))
+	require.Equal(t, http.StatusOK, code)
+
+	// Купленный объем исчерпан
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1}`)
+	require.Equal(t, http.StatusPreconditionFailed, code)
+
+	// Докупленный объем добавляется к уже открученному
+	code, body = doRequest(t, http.MethodPut, server.URL+"/slots/1/banners/7", `{"max_impressions": 2}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "Banner rotation updated successfully", body["message"])
+	code, body = doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(7), body["banner_id"])
+
+	code, _ = doRequest(t, http.MethodPut, server.URL+"/slots/1/banners/2", `{"max_impressions": 2}`)
+	require.Equal(t, http.StatusNotFound, code)
+	code, _ = doRequest(t, http.MethodPut, server.URL+"/slots/1/banners/7", `{"min_share": 2}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/banners", `{"banner_id": 8, "max_clicks": -1}`)
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestBannerPriority(t *testing.T) {
+	server := newTestServer(t)
+
+	code, _ := doRequest(t, http.MethodPost, server.URL+"/slots/1/banners", `{"banner_id": 7, "pinned": true}`)
+	require.Equal(t, http.StatusOK, code)
+	for i := 0; i < 5; i++ {
+		code, body := doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1}`)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, float64(7), body["banner_id"])
+	}
+
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/2/banners", `{"banner_id": 8, "min_share": 0.7}`)
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/2/banners", `{"banner_id": 9, "min_share": 0.5}`)
+	require.Equal(t, http.StatusPreconditionFailed, code)
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/2/banners", `{"banner_id": 9, "min_share": 1.5}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/2/banners", `{"banner_id": 9, "weight": 2}`)
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestFrequencyCap(t *testing.T) {
+	server := newTestServer(t)
+
+	code, _ := doRequest(t, http.MethodPost, server.URL+"/slots/1/banners/4/pause", "")
+	require.Equal(t, http.StatusOK, code)
+
+	// Первый выбор не подтвержден и не учитывается
+	for i := 0; i < 3; i++ {
+		code, body := doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1, "user_key": "u1"}`)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, float64(1), body["banner_id"])
+		if i > 0 {
+			code, _ = doRequest(t, http.MethodPost, server.URL+"/impressions",
+				fmt.Sprintf(`{"impression_token": %q}`, body["impression_token"]))
+			require.Equal(t, http.StatusOK, code)
+		}
+	}
+
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1, "user_key": "u1"}`)
+	require.Equal(t, http.StatusPreconditionFailed, code)
+
+	// Ограничение действует для конкретного пользователя
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1, "user_key": "u2"}`)
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1}`)
+	require.Equal(t, http.StatusOK, code)
+
+	// Выбор для страницы учитывает то же ограничение и выдает токены показа пользователю
+	code, body := doRequest(t, http.MethodPost, server.URL+"/pick",
+		`{"slot_ids": [1], "usergroup_id": 1, "user_key": "u1"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(0), body["banners"].([]any)[0].(map[string]any)["banner_id"])
+
+	for i := 0; i < 2; i++ {
+		code, body = doRequest(t, http.MethodPost, server.URL+"/pick",
+			`{"slot_ids": [1], "usergroup_id": 1, "user_key": "u3"}`)
+		require.Equal(t, http.StatusOK, code)
+		code, _ = doRequest(t, http.MethodPost, server.URL+"/impressions",
+			fmt.Sprintf(`{"impression_token": %q}`, body["banners"].([]any)[0].(map[string]any)["impression_token"]))
+		require.Equal(t, http.StatusOK, code)
+	}
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/1/pick", `{"usergroup_id": 1, "user_key": "u3"}`)
+	require.Equal(t, http.StatusPreconditionFailed, code)
+}
+
+func TestInventory(t *testing.T) {
+	server := newTestServer(t)
+
+	code, body := doRequest(t, http.MethodPost, server.URL+"/banners", `{"name": "Spring sale"}`)
+	require.Equal(t, http.StatusOK, code)
+	banner := body["banner"].(map[string]any)
+	require.Equal(t, "Spring sale", banner["name"])
+
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/slots/2/banners", `{"banner_id": 11}`)
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = doRequest(t, http.MethodPost, server.URL+"/slots/2/banners", `{"banner_id": 11}`)
+	require.Equal(t, http.StatusConflict, code)
+	require.Equal(t, float64(codes.AlreadyExists), body["code"])
+
+	code, _ = doRequest(t, http.MethodDelete, server.URL+"/slots/2/banners/11", "")
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = doRequest(t, http.MethodGet, server.URL+"/usergroups", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body["usergroups"], 5)
+}
+
+func TestUserGroupFeatures(t *testing.T) {
+	server := newTestServer(t)
+	url := server.URL + "/usergroups/2/features"
+
+	code, body := doRequest(t, http.MethodPut, url, `{"features": {"age_18_24": 1, "interest_sport": 0.5}}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(2), body["usergroup_id"])
+
+	code, body = doRequest(t, http.MethodGet, url, "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, map[string]any{"age_18_24": float64(1), "interest_sport": 0.5}, body["features"])
+
+	code, _ = doRequest(t, http.MethodPut, url, `{"features": {" ": 1}}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doRequest(t, http.MethodGet, server.URL+"/usergroups/100/features", "")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestState(t *testing.T) {
+	server := newTestServer(t)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/state?format=jsonl", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	snapshot, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	require.Contains(t, string(snapshot), `"slot"`)
+
+	code, _ := doRequest(t, http.MethodGet, server.URL+"/state?format=xml", "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	// Снимок того же сервиса целиком состоит из уже существующих записей
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/state?format=jsonl", string(snapshot))
+	require.Equal(t, http.StatusConflict, code)
+
+	code, body := doRequest(t, http.MethodPost, server.URL+"/state?format=jsonl&on_conflict=skip&dry_run=true",
+		string(snapshot))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, true, body["dry_run"])
+	require.Equal(t, float64(0), body["slots"].(map[string]any)["created"])
+	require.NotZero(t, body["slots"].(map[string]any)["skipped"])
+
+	code, _ = doRequest(t, http.MethodPost, server.URL+"/state?format=jsonl&dry_run=maybe", string(snapshot))
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestStreamEvents(t *testing.T) {
+	server := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events?slot_id=1&type_event=impress", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// Показ в другом слоте не проходит фильтр
+	for _, slotID := range []string{"2", "1"} {
+		code, body := doRequest(t, http.MethodPost, server.URL+"/slots/"+slotID+"/pick", `{"usergroup_id": 1}`)
+		require.Equal(t, http.StatusOK, code)
+		code, _ = doRequest(t, http.MethodPost, server.URL+"/impressions",
+			fmt.Sprintf(`{"impression_token": %q}`, body["impression_token"]))
+		require.Equal(t, http.StatusOK, code)
+	}
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "data: "))
+	var event map[string]any
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event))
+	require.Equal(t, "impress", event["type_event"])
+	require.Equal(t, float64(1), event["slot_id"])
+
+	code, _ := doRequest(t, http.MethodGet, server.URL+"/events?type_event=view", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = doRequest(t, http.MethodGet, server.URL+"/events?slot_id=abc", "")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestErrors(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"Unknown route", http.MethodGet, "/unknown", "", http.StatusNotFound},
+		{"Method not allowed", http.MethodPatch, "/slots", "", http.StatusMethodNotAllowed},
+		{"Invalid path parameter", http.MethodGet, "/slots/abc", "", http.StatusBadRequest},
+		{"Invalid JSON", http.MethodPost, "/clicks", "{", http.StatusBadRequest},
+		{"Forged impression token", http.MethodPost, "/impressions", `{"impression_token": "a.b"}`, http.StatusBadRequest},
+		{"Slot does not exist", http.MethodGet, "/slots/1000", "", http.StatusNotFound},
+		{"Empty name", http.MethodPost, "/slots", `{"name": ""}`, http.StatusBadRequest},
+		{"Invalid time", http.MethodGet, "/slots/1/stats?from=yesterday", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := doRequest(t, tt.method, server.URL+tt.path, tt.body)
+			require.Equal(t, tt.want, code)
+			require.NotEmpty(t, body["message"])
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package internalhttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
+	"github.com/dianapovarnitsina/banners-rotation/internal/statefile"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// importChunkSize - размер части тела запроса, передаваемой в ImportState одним сообщением.
+const importChunkSize = 64 << 10
+
+// exportState отдает снимок состояния в формате format телом ответа по мере его записи.
+func (s *Server) exportState(ctx context.Context, w http.ResponseWriter, r *http.Request, _ pathParams) error {
+	format := r.URL.Query().Get("format")
+	switch format {
+	case statefile.FormatJSONL:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	case statefile.FormatCSV:
+		w.Header().Set("Content-Type", "text/csv")
+	}
+	return s.api.ExportState(&pb.ExportStateRequest{Format: format}, &exportStream{
+		serverStream: serverStream{ctx: ctx},
+		w:            w,
+	})
+}
+
+// exportStream пишет части снимка в тело ответа.
+type exportStream struct {
+	serverStream
+	w io.Writer
+}
+
+func (s *exportStream) Send(chunk *pb.StateChunk) error {
+	_, err := s.w.Write(chunk.GetData())
+	return err
+}
+
+// importState загружает снимок из тела запроса. Режим импорта задается параметрами
+// format, on_conflict и dry_run, как в первом сообщении потока ImportState.
+func (s *Server) importState(ctx context.Context, r *http.Request, _ pathParams) (proto.Message, error) {
+	dryRun, err := queryBool(r, "dry_run")
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &importStream{
+		serverStream: serverStream{ctx: ctx},
+		body:         r.Body,
+		first: &pb.ImportStateRequest{
+			Format:     r.URL.Query().Get("format"),
+			OnConflict: r.URL.Query().Get("on_conflict"),
+			DryRun:     dryRun,
+		},
+	}
+	if err := s.api.ImportState(stream); err != nil {
+		return nil, err
+	}
+	return stream.resp, nil
+}
+
+// importStream передает тело запроса частями по importChunkSize байт; первая часть идет
+// вместе с режимом импорта.
+type importStream struct {
+	serverStream
+	body  io.Reader
+	first *pb.ImportStateRequest
+	resp  *pb.ImportStateResponse
+}
+
+func (s *importStream) Recv() (*pb.ImportStateRequest, error) {
+	data := make([]byte, importChunkSize)
+	n, err := io.ReadFull(s.body, data)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = nil
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot read body: %v", err)
+	}
+
+	req := &pb.ImportStateRequest{Data: data[:n]}
+	if s.first != nil {
+		req, s.first = s.first, nil
+		req.Data = data[:n]
+		return req, nil
+	}
+	if n == 0 {
+		return nil, io.EOF
+	}
+	return req, nil
+}
+
+func (s *importStream) SendAndClose(resp *pb.ImportStateResponse) error {
+	s.resp = resp
+	return nil
+}
@@ -0,0 +1,80 @@
+package internalhttp
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serverStream - общая часть grpc.ServerStream для потоковых методов, которые шлюз вызывает
+// напрямую. Метаданные по HTTP не передаются, а сообщения отправляются и принимаются
+// типизированными методами конкретного потока.
+type serverStream struct {
+	ctx context.Context
+}
+
+func (s serverStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s serverStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s serverStream) SetTrailer(metadata.MD) {}
+
+func (s serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s serverStream) SendMsg(any) error {
+	return status.Error(codes.Unimplemented, "untyped stream messages are not supported")
+}
+
+func (s serverStream) RecvMsg(any) error {
+	return status.Error(codes.Unimplemented, "untyped stream messages are not supported")
+}
+
+// streamWriter запоминает, начат ли ответ, и отправляет клиенту каждую запись сразу.
+type streamWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (w *streamWriter) WriteHeader(code int) {
+	w.started = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.started = true
+	n, err := w.ResponseWriter.Write(p)
+	if err == nil {
+		w.Flush()
+	}
+	return n, err
+}
+
+func (w *streamWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func serveStream(ctx context.Context, w http.ResponseWriter, r *http.Request, params pathParams, h streamHandlerFunc) {
+	sw := &streamWriter{ResponseWriter: w}
+	if err := h(ctx, sw, r, params); err != nil {
+		if !sw.started {
+			writeError(w, err)
+			return
+		}
+		// Код ответа уже отправлен: клиент должен увидеть обрыв, а не принять неполные данные
+		panic(http.ErrAbortHandler)
+	}
+	if !sw.started {
+		w.WriteHeader(http.StatusOK)
+	}
+}
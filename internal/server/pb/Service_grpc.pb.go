@@ -19,10 +19,36 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	BannerService_AddBanner_FullMethodName    = "/banner.BannerService/AddBanner"
-	BannerService_RemoveBanner_FullMethodName = "/banner.BannerService/RemoveBanner"
-	BannerService_ClickBanner_FullMethodName  = "/banner.BannerService/ClickBanner"
-	BannerService_PickBanner_FullMethodName   = "/banner.BannerService/PickBanner"
+	BannerService_AddBanner_FullMethodName            = "/banner.BannerService/AddBanner"
+	BannerService_RemoveBanner_FullMethodName         = "/banner.BannerService/RemoveBanner"
+	BannerService_PauseBanner_FullMethodName          = "/banner.BannerService/PauseBanner"
+	BannerService_ResumeBanner_FullMethodName         = "/banner.BannerService/ResumeBanner"
+	BannerService_UpdateRotation_FullMethodName       = "/banner.BannerService/UpdateRotation"
+	BannerService_ClickBanner_FullMethodName          = "/banner.BannerService/ClickBanner"
+	BannerService_PickBanner_FullMethodName           = "/banner.BannerService/PickBanner"
+	BannerService_PickBanners_FullMethodName          = "/banner.BannerService/PickBanners"
+	BannerService_RecordImpression_FullMethodName     = "/banner.BannerService/RecordImpression"
+	BannerService_GetBannerStats_FullMethodName       = "/banner.BannerService/GetBannerStats"
+	BannerService_StreamEvents_FullMethodName         = "/banner.BannerService/StreamEvents"
+	BannerService_CreateSlot_FullMethodName           = "/banner.BannerService/CreateSlot"
+	BannerService_GetSlot_FullMethodName              = "/banner.BannerService/GetSlot"
+	BannerService_ListSlots_FullMethodName            = "/banner.BannerService/ListSlots"
+	BannerService_UpdateSlot_FullMethodName           = "/banner.BannerService/UpdateSlot"
+	BannerService_DeleteSlot_FullMethodName           = "/banner.BannerService/DeleteSlot"
+	BannerService_CreateBanner_FullMethodName         = "/banner.BannerService/CreateBanner"
+	BannerService_GetBanner_FullMethodName            = "/banner.BannerService/GetBanner"
+	BannerService_ListBanners_FullMethodName          = "/banner.BannerService/ListBanners"
+	BannerService_UpdateBanner_FullMethodName         = "/banner.BannerService/UpdateBanner"
+	BannerService_DeleteBanner_FullMethodName         = "/banner.BannerService/DeleteBanner"
+	BannerService_CreateUserGroup_FullMethodName      = "/banner.BannerService/CreateUserGroup"
+	BannerService_GetUserGroup_FullMethodName         = "/banner.BannerService/GetUserGroup"
+	BannerService_ListUserGroups_FullMethodName       = "/banner.BannerService/ListUserGroups"
+	BannerService_UpdateUserGroup_FullMethodName      = "/banner.BannerService/UpdateUserGroup"
+	BannerService_DeleteUserGroup_FullMethodName      = "/banner.BannerService/DeleteUserGroup"
+	BannerService_SetUserGroupFeatures_FullMethodName = "/banner.BannerService/SetUserGroupFeatures"
+	BannerService_GetUserGroupFeatures_FullMethodName = "/banner.BannerService/GetUserGroupFeatures"
+	BannerService_ExportState_FullMethodName          = "/banner.BannerService/ExportState"
+	BannerService_ImportState_FullMethodName          = "/banner.BannerService/ImportState"
 )
 
 // BannerServiceClient is the client API for BannerService service.
@@ -31,8 +57,34 @@ const (
 type BannerServiceClient interface {
 	AddBanner(ctx context.Context, in *AddBannerRequest, opts ...grpc.CallOption) (*AddBannerResponse, error)
 	RemoveBanner(ctx context.Context, in *RemoveBannerRequest, opts ...grpc.CallOption) (*RemoveBannerResponse, error)
+	PauseBanner(ctx context.Context, in *PauseBannerRequest, opts ...grpc.CallOption) (*PauseBannerResponse, error)
+	ResumeBanner(ctx context.Context, in *ResumeBannerRequest, opts ...grpc.CallOption) (*ResumeBannerResponse, error)
+	UpdateRotation(ctx context.Context, in *UpdateRotationRequest, opts ...grpc.CallOption) (*UpdateRotationResponse, error)
 	ClickBanner(ctx context.Context, in *ClickBannerRequest, opts ...grpc.CallOption) (*ClickBannerResponse, error)
 	PickBanner(ctx context.Context, in *PickBannerRequest, opts ...grpc.CallOption) (*PickBannerResponse, error)
+	PickBanners(ctx context.Context, in *PickBannersRequest, opts ...grpc.CallOption) (*PickBannersResponse, error)
+	RecordImpression(ctx context.Context, in *RecordImpressionRequest, opts ...grpc.CallOption) (*RecordImpressionResponse, error)
+	GetBannerStats(ctx context.Context, in *GetBannerStatsRequest, opts ...grpc.CallOption) (*GetBannerStatsResponse, error)
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (BannerService_StreamEventsClient, error)
+	CreateSlot(ctx context.Context, in *CreateSlotRequest, opts ...grpc.CallOption) (*SlotResponse, error)
+	GetSlot(ctx context.Context, in *GetSlotRequest, opts ...grpc.CallOption) (*SlotResponse, error)
+	ListSlots(ctx context.Context, in *ListSlotsRequest, opts ...grpc.CallOption) (*ListSlotsResponse, error)
+	UpdateSlot(ctx context.Context, in *UpdateSlotRequest, opts ...grpc.CallOption) (*SlotResponse, error)
+	DeleteSlot(ctx context.Context, in *DeleteSlotRequest, opts ...grpc.CallOption) (*DeleteSlotResponse, error)
+	CreateBanner(ctx context.Context, in *CreateBannerRequest, opts ...grpc.CallOption) (*BannerResponse, error)
+	GetBanner(ctx context.Context, in *GetBannerRequest, opts ...grpc.CallOption) (*BannerResponse, error)
+	ListBanners(ctx context.Context, in *ListBannersRequest, opts ...grpc.CallOption) (*ListBannersResponse, error)
+	UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*BannerResponse, error)
+	DeleteBanner(ctx context.Context, in *DeleteBannerRequest, opts ...grpc.CallOption) (*DeleteBannerResponse, error)
+	CreateUserGroup(ctx context.Context, in *CreateUserGroupRequest, opts ...grpc.CallOption) (*UserGroupResponse, error)
+	GetUserGroup(ctx context.Context, in *GetUserGroupRequest, opts ...grpc.CallOption) (*UserGroupResponse, error)
+	ListUserGroups(ctx context.Context, in *ListUserGroupsRequest, opts ...grpc.CallOption) (*ListUserGroupsResponse, error)
+	UpdateUserGroup(ctx context.Context, in *UpdateUserGroupRequest, opts ...grpc.CallOption) (*UserGroupResponse, error)
+	DeleteUserGroup(ctx context.Context, in *DeleteUserGroupRequest, opts ...grpc.CallOption) (*DeleteUserGroupResponse, error)
+	SetUserGroupFeatures(ctx context.Context, in *SetUserGroupFeaturesRequest, opts ...grpc.CallOption) (*UserGroupFeaturesResponse, error)
+	GetUserGroupFeatures(ctx context.Context, in *GetUserGroupFeaturesRequest, opts ...grpc.CallOption) (*UserGroupFeaturesResponse, error)
+	ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (BannerService_ExportStateClient, error)
+	ImportState(ctx context.Context, opts ...grpc.CallOption) (BannerService_ImportStateClient, error)
 }
 
 type bannerServiceClient struct {
@@ -61,6 +113,33 @@ func (c *bannerServiceClient) RemoveBanner(ctx context.Context, in *RemoveBanner
 	return out, nil
 }
 
+func (c *bannerServiceClient) PauseBanner(ctx context.Context, in *PauseBannerRequest, opts ...grpc.CallOption) (*PauseBannerResponse, error) {
+	out := new(PauseBannerResponse)
+	err := c.cc.Invoke(ctx, BannerService_PauseBanner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) ResumeBanner(ctx context.Context, in *ResumeBannerRequest, opts ...grpc.CallOption) (*ResumeBannerResponse, error) {
+	out := new(ResumeBannerResponse)
+	err := c.cc.Invoke(ctx, BannerService_ResumeBanner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) UpdateRotation(ctx context.Context, in *UpdateRotationRequest, opts ...grpc.CallOption) (*UpdateRotationResponse, error) {
+	out := new(UpdateRotationResponse)
+	err := c.cc.Invoke(ctx, BannerService_UpdateRotation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bannerServiceClient) ClickBanner(ctx context.Context, in *ClickBannerRequest, opts ...grpc.CallOption) (*ClickBannerResponse, error) {
 	out := new(ClickBannerResponse)
 	err := c.cc.Invoke(ctx, BannerService_ClickBanner_FullMethodName, in, out, opts...)
@@ -79,14 +158,318 @@ func (c *bannerServiceClient) PickBanner(ctx context.Context, in *PickBannerRequ
 	return out, nil
 }
 
+func (c *bannerServiceClient) PickBanners(ctx context.Context, in *PickBannersRequest, opts ...grpc.CallOption) (*PickBannersResponse, error) {
+	out := new(PickBannersResponse)
+	err := c.cc.Invoke(ctx, BannerService_PickBanners_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) RecordImpression(ctx context.Context, in *RecordImpressionRequest, opts ...grpc.CallOption) (*RecordImpressionResponse, error) {
+	out := new(RecordImpressionResponse)
+	err := c.cc.Invoke(ctx, BannerService_RecordImpression_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) GetBannerStats(ctx context.Context, in *GetBannerStatsRequest, opts ...grpc.CallOption) (*GetBannerStatsResponse, error) {
+	out := new(GetBannerStatsResponse)
+	err := c.cc.Invoke(ctx, BannerService_GetBannerStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (BannerService_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BannerService_ServiceDesc.Streams[0], BannerService_StreamEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bannerServiceStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BannerService_StreamEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type bannerServiceStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *bannerServiceStreamEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bannerServiceClient) CreateSlot(ctx context.Context, in *CreateSlotRequest, opts ...grpc.CallOption) (*SlotResponse, error) {
+	out := new(SlotResponse)
+	err := c.cc.Invoke(ctx, BannerService_CreateSlot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) GetSlot(ctx context.Context, in *GetSlotRequest, opts ...grpc.CallOption) (*SlotResponse, error) {
+	out := new(SlotResponse)
+	err := c.cc.Invoke(ctx, BannerService_GetSlot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) ListSlots(ctx context.Context, in *ListSlotsRequest, opts ...grpc.CallOption) (*ListSlotsResponse, error) {
+	out := new(ListSlotsResponse)
+	err := c.cc.Invoke(ctx, BannerService_ListSlots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) UpdateSlot(ctx context.Context, in *UpdateSlotRequest, opts ...grpc.CallOption) (*SlotResponse, error) {
+	out := new(SlotResponse)
+	err := c.cc.Invoke(ctx, BannerService_UpdateSlot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) DeleteSlot(ctx context.Context, in *DeleteSlotRequest, opts ...grpc.CallOption) (*DeleteSlotResponse, error) {
+	out := new(DeleteSlotResponse)
+	err := c.cc.Invoke(ctx, BannerService_DeleteSlot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) CreateBanner(ctx context.Context, in *CreateBannerRequest, opts ...grpc.CallOption) (*BannerResponse, error) {
+	out := new(BannerResponse)
+	err := c.cc.Invoke(ctx, BannerService_CreateBanner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) GetBanner(ctx context.Context, in *GetBannerRequest, opts ...grpc.CallOption) (*BannerResponse, error) {
+	out := new(BannerResponse)
+	err := c.cc.Invoke(ctx, BannerService_GetBanner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) ListBanners(ctx context.Context, in *ListBannersRequest, opts ...grpc.CallOption) (*ListBannersResponse, error) {
+	out := new(ListBannersResponse)
+	err := c.cc.Invoke(ctx, BannerService_ListBanners_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*BannerResponse, error) {
+	out := new(BannerResponse)
+	err := c.cc.Invoke(ctx, BannerService_UpdateBanner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) DeleteBanner(ctx context.Context, in *DeleteBannerRequest, opts ...grpc.CallOption) (*DeleteBannerResponse, error) {
+	out := new(DeleteBannerResponse)
+	err := c.cc.Invoke(ctx, BannerService_DeleteBanner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) CreateUserGroup(ctx context.Context, in *CreateUserGroupRequest, opts ...grpc.CallOption) (*UserGroupResponse, error) {
+	out := new(UserGroupResponse)
+	err := c.cc.Invoke(ctx, BannerService_CreateUserGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) GetUserGroup(ctx context.Context, in *GetUserGroupRequest, opts ...grpc.CallOption) (*UserGroupResponse, error) {
+	out := new(UserGroupResponse)
+	err := c.cc.Invoke(ctx, BannerService_GetUserGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) ListUserGroups(ctx context.Context, in *ListUserGroupsRequest, opts ...grpc.CallOption) (*ListUserGroupsResponse, error) {
+	out := new(ListUserGroupsResponse)
+	err := c.cc.Invoke(ctx, BannerService_ListUserGroups_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) UpdateUserGroup(ctx context.Context, in *UpdateUserGroupRequest, opts ...grpc.CallOption) (*UserGroupResponse, error) {
+	out := new(UserGroupResponse)
+	err := c.cc.Invoke(ctx, BannerService_UpdateUserGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) DeleteUserGroup(ctx context.Context, in *DeleteUserGroupRequest, opts ...grpc.CallOption) (*DeleteUserGroupResponse, error) {
+	out := new(DeleteUserGroupResponse)
+	err := c.cc.Invoke(ctx, BannerService_DeleteUserGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) SetUserGroupFeatures(ctx context.Context, in *SetUserGroupFeaturesRequest, opts ...grpc.CallOption) (*UserGroupFeaturesResponse, error) {
+	out := new(UserGroupFeaturesResponse)
+	err := c.cc.Invoke(ctx, BannerService_SetUserGroupFeatures_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) GetUserGroupFeatures(ctx context.Context, in *GetUserGroupFeaturesRequest, opts ...grpc.CallOption) (*UserGroupFeaturesResponse, error) {
+	out := new(UserGroupFeaturesResponse)
+	err := c.cc.Invoke(ctx, BannerService_GetUserGroupFeatures_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (BannerService_ExportStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BannerService_ServiceDesc.Streams[1], BannerService_ExportState_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bannerServiceExportStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BannerService_ExportStateClient interface {
+	Recv() (*StateChunk, error)
+	grpc.ClientStream
+}
+
+type bannerServiceExportStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *bannerServiceExportStateClient) Recv() (*StateChunk, error) {
+	m := new(StateChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bannerServiceClient) ImportState(ctx context.Context, opts ...grpc.CallOption) (BannerService_ImportStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BannerService_ServiceDesc.Streams[2], BannerService_ImportState_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bannerServiceImportStateClient{stream}
+	return x, nil
+}
+
+type BannerService_ImportStateClient interface {
+	Send(*ImportStateRequest) error
+	CloseAndRecv() (*ImportStateResponse, error)
+	grpc.ClientStream
+}
+
+type bannerServiceImportStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *bannerServiceImportStateClient) Send(m *ImportStateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *bannerServiceImportStateClient) CloseAndRecv() (*ImportStateResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BannerServiceServer is the server API for BannerService service.
 // All implementations must embed UnimplementedBannerServiceServer
 // for forward compatibility
 type BannerServiceServer interface {
 	AddBanner(context.Context, *AddBannerRequest) (*AddBannerResponse, error)
 	RemoveBanner(context.Context, *RemoveBannerRequest) (*RemoveBannerResponse, error)
+	PauseBanner(context.Context, *PauseBannerRequest) (*PauseBannerResponse, error)
+	ResumeBanner(context.Context, *ResumeBannerRequest) (*ResumeBannerResponse, error)
+	UpdateRotation(context.Context, *UpdateRotationRequest) (*UpdateRotationResponse, error)
 	ClickBanner(context.Context, *ClickBannerRequest) (*ClickBannerResponse, error)
 	PickBanner(context.Context, *PickBannerRequest) (*PickBannerResponse, error)
+	PickBanners(context.Context, *PickBannersRequest) (*PickBannersResponse, error)
+	RecordImpression(context.Context, *RecordImpressionRequest) (*RecordImpressionResponse, error)
+	GetBannerStats(context.Context, *GetBannerStatsRequest) (*GetBannerStatsResponse, error)
+	StreamEvents(*StreamEventsRequest, BannerService_StreamEventsServer) error
+	CreateSlot(context.Context, *CreateSlotRequest) (*SlotResponse, error)
+	GetSlot(context.Context, *GetSlotRequest) (*SlotResponse, error)
+	ListSlots(context.Context, *ListSlotsRequest) (*ListSlotsResponse, error)
+	UpdateSlot(context.Context, *UpdateSlotRequest) (*SlotResponse, error)
+	DeleteSlot(context.Context, *DeleteSlotRequest) (*DeleteSlotResponse, error)
+	CreateBanner(context.Context, *CreateBannerRequest) (*BannerResponse, error)
+	GetBanner(context.Context, *GetBannerRequest) (*BannerResponse, error)
+	ListBanners(context.Context, *ListBannersRequest) (*ListBannersResponse, error)
+	UpdateBanner(context.Context, *UpdateBannerRequest) (*BannerResponse, error)
+	DeleteBanner(context.Context, *DeleteBannerRequest) (*DeleteBannerResponse, error)
+	CreateUserGroup(context.Context, *CreateUserGroupRequest) (*UserGroupResponse, error)
+	GetUserGroup(context.Context, *GetUserGroupRequest) (*UserGroupResponse, error)
+	ListUserGroups(context.Context, *ListUserGroupsRequest) (*ListUserGroupsResponse, error)
+	UpdateUserGroup(context.Context, *UpdateUserGroupRequest) (*UserGroupResponse, error)
+	DeleteUserGroup(context.Context, *DeleteUserGroupRequest) (*DeleteUserGroupResponse, error)
+	SetUserGroupFeatures(context.Context, *SetUserGroupFeaturesRequest) (*UserGroupFeaturesResponse, error)
+	GetUserGroupFeatures(context.Context, *GetUserGroupFeaturesRequest) (*UserGroupFeaturesResponse, error)
+	ExportState(*ExportStateRequest, BannerService_ExportStateServer) error
+	ImportState(BannerService_ImportStateServer) error
 	mustEmbedUnimplementedBannerServiceServer()
 }
 
@@ -100,12 +483,90 @@ func (UnimplementedBannerServiceServer) AddBanner(context.Context, *AddBannerReq
 func (UnimplementedBannerServiceServer) RemoveBanner(context.Context, *RemoveBannerRequest) (*RemoveBannerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveBanner not implemented")
 }
+func (UnimplementedBannerServiceServer) PauseBanner(context.Context, *PauseBannerRequest) (*PauseBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) ResumeBanner(context.Context, *ResumeBannerRequest) (*ResumeBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) UpdateRotation(context.Context, *UpdateRotationRequest) (*UpdateRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRotation not implemented")
+}
 func (UnimplementedBannerServiceServer) ClickBanner(context.Context, *ClickBannerRequest) (*ClickBannerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClickBanner not implemented")
 }
 func (UnimplementedBannerServiceServer) PickBanner(context.Context, *PickBannerRequest) (*PickBannerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PickBanner not implemented")
 }
+func (UnimplementedBannerServiceServer) PickBanners(context.Context, *PickBannersRequest) (*PickBannersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PickBanners not implemented")
+}
+func (UnimplementedBannerServiceServer) RecordImpression(context.Context, *RecordImpressionRequest) (*RecordImpressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordImpression not implemented")
+}
+func (UnimplementedBannerServiceServer) GetBannerStats(context.Context, *GetBannerStatsRequest) (*GetBannerStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBannerStats not implemented")
+}
+func (UnimplementedBannerServiceServer) StreamEvents(*StreamEventsRequest, BannerService_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedBannerServiceServer) CreateSlot(context.Context, *CreateSlotRequest) (*SlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSlot not implemented")
+}
+func (UnimplementedBannerServiceServer) GetSlot(context.Context, *GetSlotRequest) (*SlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlot not implemented")
+}
+func (UnimplementedBannerServiceServer) ListSlots(context.Context, *ListSlotsRequest) (*ListSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSlots not implemented")
+}
+func (UnimplementedBannerServiceServer) UpdateSlot(context.Context, *UpdateSlotRequest) (*SlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSlot not implemented")
+}
+func (UnimplementedBannerServiceServer) DeleteSlot(context.Context, *DeleteSlotRequest) (*DeleteSlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSlot not implemented")
+}
+func (UnimplementedBannerServiceServer) CreateBanner(context.Context, *CreateBannerRequest) (*BannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) GetBanner(context.Context, *GetBannerRequest) (*BannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) ListBanners(context.Context, *ListBannersRequest) (*ListBannersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBanners not implemented")
+}
+func (UnimplementedBannerServiceServer) UpdateBanner(context.Context, *UpdateBannerRequest) (*BannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) DeleteBanner(context.Context, *DeleteBannerRequest) (*DeleteBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) CreateUserGroup(context.Context, *CreateUserGroupRequest) (*UserGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUserGroup not implemented")
+}
+func (UnimplementedBannerServiceServer) GetUserGroup(context.Context, *GetUserGroupRequest) (*UserGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserGroup not implemented")
+}
+func (UnimplementedBannerServiceServer) ListUserGroups(context.Context, *ListUserGroupsRequest) (*ListUserGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserGroups not implemented")
+}
+func (UnimplementedBannerServiceServer) UpdateUserGroup(context.Context, *UpdateUserGroupRequest) (*UserGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserGroup not implemented")
+}
+func (UnimplementedBannerServiceServer) DeleteUserGroup(context.Context, *DeleteUserGroupRequest) (*DeleteUserGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserGroup not implemented")
+}
+func (UnimplementedBannerServiceServer) SetUserGroupFeatures(context.Context, *SetUserGroupFeaturesRequest) (*UserGroupFeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserGroupFeatures not implemented")
+}
+func (UnimplementedBannerServiceServer) GetUserGroupFeatures(context.Context, *GetUserGroupFeaturesRequest) (*UserGroupFeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserGroupFeatures not implemented")
+}
+func (UnimplementedBannerServiceServer) ExportState(*ExportStateRequest, BannerService_ExportStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportState not implemented")
+}
+func (UnimplementedBannerServiceServer) ImportState(BannerService_ImportStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportState not implemented")
+}
 func (UnimplementedBannerServiceServer) mustEmbedUnimplementedBannerServiceServer() {}
 
 // UnsafeBannerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -155,6 +616,60 @@ func _BannerService_RemoveBanner_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BannerService_PauseBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).PauseBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_PauseBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).PauseBanner(ctx, req.(*PauseBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_ResumeBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).ResumeBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_ResumeBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).ResumeBanner(ctx, req.(*ResumeBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_UpdateRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).UpdateRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_UpdateRotation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).UpdateRotation(ctx, req.(*UpdateRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BannerService_ClickBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ClickBannerRequest)
 	if err := dec(in); err != nil {
@@ -191,8 +706,436 @@ func _BannerService_PickBanner_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-// BannerService_ServiceDesc is the grpc.ServiceDesc for BannerService service.
-// It's only intended for direct use with grpc.RegisterService,
+func _BannerService_PickBanners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PickBannersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).PickBanners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_PickBanners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).PickBanners(ctx, req.(*PickBannersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_RecordImpression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordImpressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).RecordImpression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_RecordImpression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).RecordImpression(ctx, req.(*RecordImpressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_GetBannerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBannerStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).GetBannerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_GetBannerStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).GetBannerStats(ctx, req.(*GetBannerStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BannerServiceServer).StreamEvents(m, &bannerServiceStreamEventsServer{stream})
+}
+
+type BannerService_StreamEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type bannerServiceStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *bannerServiceStreamEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BannerService_CreateSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).CreateSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_CreateSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).CreateSlot(ctx, req.(*CreateSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_GetSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).GetSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_GetSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).GetSlot(ctx, req.(*GetSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_ListSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).ListSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_ListSlots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).ListSlots(ctx, req.(*ListSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_UpdateSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).UpdateSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_UpdateSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).UpdateSlot(ctx, req.(*UpdateSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_DeleteSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).DeleteSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_DeleteSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).DeleteSlot(ctx, req.(*DeleteSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_CreateBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).CreateBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_CreateBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).CreateBanner(ctx, req.(*CreateBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_GetBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).GetBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_GetBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).GetBanner(ctx, req.(*GetBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_ListBanners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBannersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).ListBanners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_ListBanners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).ListBanners(ctx, req.(*ListBannersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_UpdateBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).UpdateBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_UpdateBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).UpdateBanner(ctx, req.(*UpdateBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_DeleteBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).DeleteBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_DeleteBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).DeleteBanner(ctx, req.(*DeleteBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_CreateUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).CreateUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_CreateUserGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).CreateUserGroup(ctx, req.(*CreateUserGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_GetUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).GetUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_GetUserGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).GetUserGroup(ctx, req.(*GetUserGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_ListUserGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).ListUserGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_ListUserGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).ListUserGroups(ctx, req.(*ListUserGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_UpdateUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).UpdateUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_UpdateUserGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).UpdateUserGroup(ctx, req.(*UpdateUserGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_DeleteUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).DeleteUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_DeleteUserGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).DeleteUserGroup(ctx, req.(*DeleteUserGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_SetUserGroupFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserGroupFeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).SetUserGroupFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_SetUserGroupFeatures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).SetUserGroupFeatures(ctx, req.(*SetUserGroupFeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_GetUserGroupFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserGroupFeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).GetUserGroupFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_GetUserGroupFeatures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).GetUserGroupFeatures(ctx, req.(*GetUserGroupFeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_ExportState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BannerServiceServer).ExportState(m, &bannerServiceExportStateServer{stream})
+}
+
+type BannerService_ExportStateServer interface {
+	Send(*StateChunk) error
+	grpc.ServerStream
+}
+
+type bannerServiceExportStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *bannerServiceExportStateServer) Send(m *StateChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BannerService_ImportState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BannerServiceServer).ImportState(&bannerServiceImportStateServer{stream})
+}
+
+type BannerService_ImportStateServer interface {
+	SendAndClose(*ImportStateResponse) error
+	Recv() (*ImportStateRequest, error)
+	grpc.ServerStream
+}
+
+type bannerServiceImportStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *bannerServiceImportStateServer) SendAndClose(m *ImportStateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *bannerServiceImportStateServer) Recv() (*ImportStateRequest, error) {
+	m := new(ImportStateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BannerService_ServiceDesc is the grpc.ServiceDesc for BannerService service.
+// It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var BannerService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "banner.BannerService",
@@ -206,6 +1149,18 @@ var BannerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveBanner",
 			Handler:    _BannerService_RemoveBanner_Handler,
 		},
+		{
+			MethodName: "PauseBanner",
+			Handler:    _BannerService_PauseBanner_Handler,
+		},
+		{
+			MethodName: "ResumeBanner",
+			Handler:    _BannerService_ResumeBanner_Handler,
+		},
+		{
+			MethodName: "UpdateRotation",
+			Handler:    _BannerService_UpdateRotation_Handler,
+		},
 		{
 			MethodName: "ClickBanner",
 			Handler:    _BannerService_ClickBanner_Handler,
@@ -214,7 +1169,103 @@ var BannerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PickBanner",
 			Handler:    _BannerService_PickBanner_Handler,
 		},
+		{
+			MethodName: "PickBanners",
+			Handler:    _BannerService_PickBanners_Handler,
+		},
+		{
+			MethodName: "RecordImpression",
+			Handler:    _BannerService_RecordImpression_Handler,
+		},
+		{
+			MethodName: "GetBannerStats",
+			Handler:    _BannerService_GetBannerStats_Handler,
+		},
+		{
+			MethodName: "CreateSlot",
+			Handler:    _BannerService_CreateSlot_Handler,
+		},
+		{
+			MethodName: "GetSlot",
+			Handler:    _BannerService_GetSlot_Handler,
+		},
+		{
+			MethodName: "ListSlots",
+			Handler:    _BannerService_ListSlots_Handler,
+		},
+		{
+			MethodName: "UpdateSlot",
+			Handler:    _BannerService_UpdateSlot_Handler,
+		},
+		{
+			MethodName: "DeleteSlot",
+			Handler:    _BannerService_DeleteSlot_Handler,
+		},
+		{
+			MethodName: "CreateBanner",
+			Handler:    _BannerService_CreateBanner_Handler,
+		},
+		{
+			MethodName: "GetBanner",
+			Handler:    _BannerService_GetBanner_Handler,
+		},
+		{
+			MethodName: "ListBanners",
+			Handler:    _BannerService_ListBanners_Handler,
+		},
+		{
+			MethodName: "UpdateBanner",
+			Handler:    _BannerService_UpdateBanner_Handler,
+		},
+		{
+			MethodName: "DeleteBanner",
+			Handler:    _BannerService_DeleteBanner_Handler,
+		},
+		{
+			MethodName: "CreateUserGroup",
+			Handler:    _BannerService_CreateUserGroup_Handler,
+		},
+		{
+			MethodName: "GetUserGroup",
+			Handler:    _BannerService_GetUserGroup_Handler,
+		},
+		{
+			MethodName: "ListUserGroups",
+			Handler:    _BannerService_ListUserGroups_Handler,
+		},
+		{
+			MethodName: "UpdateUserGroup",
+			Handler:    _BannerService_UpdateUserGroup_Handler,
+		},
+		{
+			MethodName: "DeleteUserGroup",
+			Handler:    _BannerService_DeleteUserGroup_Handler,
+		},
+		{
+			MethodName: "SetUserGroupFeatures",
+			Handler:    _BannerService_SetUserGroupFeatures_Handler,
+		},
+		{
+			MethodName: "GetUserGroupFeatures",
+			Handler:    _BannerService_GetUserGroupFeatures_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _BannerService_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportState",
+			Handler:       _BannerService_ExportState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportState",
+			Handler:       _BannerService_ImportState_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "Service.proto",
 }
@@ -0,0 +1,374 @@
+// Package statefile читает и записывает снимок состояния сервиса (storage.State) в JSON Lines и CSV.
+//
+// Каждая строка - одна запись с полем type: slot, banner, usergroup, rotation или stats.
+// В CSV у всех записей общий заголовок, неприменимые к записи колонки пустые; признаки группы
+// записываются в колонку features объектом JSON.
+package statefile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+var ErrUnknownFormat = errors.New("format must be jsonl or csv")
+
+// Типы записей снимка.
+const (
+	typeSlot      = "slot"
+	typeBanner    = "banner"
+	typeUserGroup = "usergroup"
+	typeRotation  = "rotation"
+	typeStats     = "stats"
+)
+
+// record - строка снимка. Поля, не относящиеся к типу записи, остаются нулевыми и не записываются.
+//
+//nolint:tagliatelle
+type record struct {
+	Type      string           `json:"type"`
+	ID        int              `json:"id,omitempty"`
+	Name      string           `json:"name,omitempty"`
+	CreatedAt *time.Time       `json:"created_at,omitempty"`
+	Features  storage.Features `json:"features,omitempty"`
+
+	SlotID      int `json:"slot_id,omitempty"`
+	BannerID    int `json:"banner_id,omitempty"`
+	UserGroupID int `json:"usergroup_id,omitempty"`
+
+	StartsAt            *time.Time `json:"starts_at,omitempty"`
+	EndsAt              *time.Time `json:"ends_at,omitempty"`
+	Paused              bool       `json:"paused,omitempty"`
+	MaxImpressions      int64      `json:"max_impressions,omitempty"`
+	MaxClicks           int64      `json:"max_clicks,omitempty"`
+	MaxDailyImpressions int64      `json:"max_daily_impressions,omitempty"`
+	Pinned              bool       `json:"pinned,omitempty"`
+	Weight              float64    `json:"weight,omitempty"`
+	MinShare            float64    `json:"min_share,omitempty"`
+	ServedImpressions   int64      `json:"served_impressions,omitempty"`
+	ServedClicks        int64      `json:"served_clicks,omitempty"`
+	DailyImpressions    int64      `json:"daily_impressions,omitempty"`
+	ServedOn            *time.Time `json:"served_on,omitempty"`
+
+	Impressions        int64      `json:"impressions,omitempty"`
+	Clicks             int64      `json:"clicks,omitempty"`
+	DecayedImpressions float64    `json:"decayed_impressions,omitempty"`
+	DecayedClicks      float64    `json:"decayed_clicks,omitempty"`
+	DecayedAt          *time.Time `json:"decayed_at,omitempty"`
+}
+
+// CheckFormat проверяет, что формат поддерживается.
+func CheckFormat(format string) error {
+	switch format {
+	case FormatJSONL, FormatCSV:
+		return nil
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// Write записывает снимок в формате format.
+func Write(w io.Writer, format string, state *storage.State) error {
+	records := fromState(state)
+	switch format {
+	case FormatJSONL:
+		bw := bufio.NewWriter(w)
+		enc := json.NewEncoder(bw)
+		for _, rec := range records {
+			if err := enc.Encode(rec); err != nil {
+				return err
+			}
+		}
+		return bw.Flush()
+	case FormatCSV:
+		return writeCSV(w, records)
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// Read читает снимок в формате format. Некорректные записи возвращаются как storage.ErrInvalidState
+// с номером строки.
+func Read(r io.Reader, format string) (*storage.State, error) {
+	state := &storage.State{}
+	switch format {
+	case FormatJSONL:
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		for line := 1; ; line++ {
+			var rec record
+			err := dec.Decode(&rec)
+			if errors.Is(err, io.EOF) {
+				return state, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", storage.ErrInvalidState, line, err)
+			}
+			if err := rec.addTo(state); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+	case FormatCSV:
+		return readCSV(r, state)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func fromState(state *storage.State) []record {
+	records := make([]record, 0,
+		len(state.Slots)+len(state.Banners)+len(state.UserGroups)+len(state.Rotations)+len(state.Stats))
+	for _, slot := range state.Slots {
+		records = append(records, record{Type: typeSlot, ID: slot.ID, Name: slot.Name, CreatedAt: timePtr(slot.CreatedAt)})
+	}
+	for _, banner := range state.Banners {
+		records = append(records, record{
+			Type:      typeBanner,
+			ID:        banner.ID,
+			Name:      banner.Name,
+			CreatedAt: timePtr(banner.CreatedAt),
+		})
+	}
+	for _, group := range state.UserGroups {
+		records = append(records, record{
+			Type:      typeUserGroup,
+			ID:        group.ID,
+			Name:      group.Name,
+			CreatedAt: timePtr(group.CreatedAt),
+			Features:  group.Features,
+		})
+	}
+	for _, r := range state.Rotations {
+		settings := r.Settings
+		records = append(records, record{
+			Type:                typeRotation,
+			SlotID:              r.SlotID,
+			BannerID:            r.BannerID,
+			CreatedAt:           timePtr(r.CreatedAt),
+			StartsAt:            timePtr(settings.Flight.StartsAt),
+			EndsAt:              timePtr(settings.Flight.EndsAt),
+			Paused:              r.Paused,
+			MaxImpressions:      settings.Caps.MaxImpressions,
+			MaxClicks:           settings.Caps.MaxClicks,
+			MaxDailyImpressions: settings.Caps.MaxDailyImpressions,
+			Pinned:              settings.Pinned,
+			Weight:              settings.Weight,
+			MinShare:            settings.MinShare,
+			ServedImpressions:   r.Served.Impressions,
+			ServedClicks:        r.Served.Clicks,
+			DailyImpressions:    r.Served.DailyImpressions,
+			ServedOn:            timePtr(r.Served.Day),
+		})
+	}
+	for _, st := range state.Stats {
+		records = append(records, record{
+			Type:               typeStats,
+			SlotID:             st.SlotID,
+			BannerID:           st.BannerID,
+			UserGroupID:        st.UserGroupID,
+			Impressions:        st.Impressions,
+			Clicks:             st.Clicks,
+			DecayedImpressions: st.DecayedImpressions,
+			DecayedClicks:      st.DecayedClicks,
+			DecayedAt:          timePtr(st.DecayedAt),
+		})
+	}
+	return records
+}
+
+func (r *record) addTo(state *storage.State) error {
+	switch r.Type {
+	case typeSlot:
+		state.Slots = append(state.Slots, storage.Slot{ID: r.ID, Name: r.Name, CreatedAt: timeVal(r.CreatedAt)})
+	case typeBanner:
+		state.Banners = append(state.Banners, storage.Banner{ID: r.ID, Name: r.Name, CreatedAt: timeVal(r.CreatedAt)})
+	case typeUserGroup:
+		state.UserGroups = append(state.UserGroups, storage.UserGroupState{
+			UserGroup: storage.UserGroup{ID: r.ID, Name: r.Name, CreatedAt: timeVal(r.CreatedAt)},
+			Features:  r.Features,
+		})
+	case typeRotation:
+		state.Rotations = append(state.Rotations, storage.RotationState{
+			SlotID:   r.SlotID,
+			BannerID: r.BannerID,
+			Settings: storage.RotationSettings{
+				Flight: storage.Flight{StartsAt: timeVal(r.StartsAt), EndsAt: timeVal(r.EndsAt)},
+				Caps: storage.Caps{
+					MaxImpressions:      r.MaxImpressions,
+					MaxClicks:           r.MaxClicks,
+					MaxDailyImpressions: r.MaxDailyImpressions,
+				},
+				Pinned:   r.Pinned,
+				Weight:   r.Weight,
+				MinShare: r.MinShare,
+			},
+			Paused: r.Paused,
+			Served: storage.Served{
+				Impressions:      r.ServedImpressions,
+				Clicks:           r.ServedClicks,
+				DailyImpressions: r.DailyImpressions,
+				Day:              timeVal(r.ServedOn),
+			},
+			CreatedAt: timeVal(r.CreatedAt),
+		})
+	case typeStats:
+		state.Stats = append(state.Stats, storage.StatsState{
+			SlotID:             r.SlotID,
+			BannerID:           r.BannerID,
+			UserGroupID:        r.UserGroupID,
+			Impressions:        r.Impressions,
+			Clicks:             r.Clicks,
+			DecayedImpressions: r.DecayedImpressions,
+			DecayedClicks:      r.DecayedClicks,
+			DecayedAt:          timeVal(r.DecayedAt),
+		})
+	default:
+		return fmt.Errorf("%w: unknown record type %q", storage.ErrInvalidState, r.Type)
+	}
+	return nil
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func timeVal(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// csvColumns - колонки CSV в порядке записи; quoted - значение колонки в JSON является строкой.
+var csvColumns = []struct {
+	name   string
+	quoted bool
+}{
+	{"type", true}, {"id", false}, {"name", true}, {"created_at", true}, {"features", false},
+	{"slot_id", false}, {"banner_id", false}, {"usergroup_id", false},
+	{"starts_at", true}, {"ends_at", true}, {"paused", false},
+	{"max_impressions", false}, {"max_clicks", false}, {"max_daily_impressions", false},
+	{"pinned", false}, {"weight", false}, {"min_share", false},
+	{"served_impressions", false}, {"served_clicks", false}, {"daily_impressions", false}, {"served_on", true},
+	{"impressions", false}, {"clicks", false}, {"decayed_impressions", false}, {"decayed_clicks", false},
+	{"decayed_at", true},
+}
+
+// writeCSV записывает записи через их представление в JSON: строки без кавычек, остальное как есть.
+func writeCSV(w io.Writer, records []record) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(csvColumns))
+	for i, col := range csvColumns {
+		header[i] = col.name
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(csvColumns))
+	for _, rec := range records {
+		data, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		for i, col := range csvColumns {
+			row[i] = ""
+			raw, ok := fields[col.name]
+			switch {
+			case !ok:
+			case col.quoted:
+				if err := json.Unmarshal(raw, &row[i]); err != nil {
+					return err
+				}
+			default:
+				row[i] = string(raw)
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// readCSV читает записи с заголовком из любого подмножества колонок в любом порядке.
+func readCSV(r io.Reader, state *storage.State) (*storage.State, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %w", storage.ErrInvalidState, err)
+	}
+
+	quoted := make([]bool, len(header))
+	for i, name := range header {
+		known := false
+		for _, col := range csvColumns {
+			if col.name == name {
+				quoted[i], known = col.quoted, true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: unknown column %q", storage.ErrInvalidState, name)
+		}
+	}
+
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return state, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", storage.ErrInvalidState, err)
+		}
+
+		fields := make(map[string]json.RawMessage, len(row))
+		for i, value := range row {
+			if value == "" {
+				continue
+			}
+			if quoted[i] {
+				fields[header[i]], _ = json.Marshal(value)
+			} else {
+				fields[header[i]] = json.RawMessage(value)
+			}
+		}
+		var rec record
+		if err := decodeFields(fields, &rec); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", storage.ErrInvalidState, line, err)
+		}
+		if err := rec.addTo(state); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+func decodeFields(fields map[string]json.RawMessage, rec *record) error {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, rec)
+}
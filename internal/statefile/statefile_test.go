@@ -0,0 +1,89 @@
+package statefile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func testState() *storage.State {
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	return &storage.State{
+		Slots:   []storage.Slot{{ID: 1, Name: "Main page", CreatedAt: at}},
+		Banners: []storage.Banner{{ID: 2, Name: "Sale, 50%", CreatedAt: at}},
+		UserGroups: []storage.UserGroupState{
+			{UserGroup: storage.UserGroup{ID: 3, Name: "Students", CreatedAt: at}, Features: storage.Features{"age": 0.5}},
+			{UserGroup: storage.UserGroup{ID: 4, Name: "Other", CreatedAt: at}},
+		},
+		Rotations: []storage.RotationState{{
+			SlotID:   1,
+			BannerID: 2,
+			Settings: storage.RotationSettings{
+				Flight:   storage.Flight{StartsAt: at, EndsAt: at.Add(24 * time.Hour)},
+				Caps:     storage.Caps{MaxImpressions: 1000},
+				Pinned:   true,
+				Weight:   1.5,
+				MinShare: 0.2,
+			},
+			Paused:    true,
+			Served:    storage.Served{Impressions: 10, Clicks: 1, DailyImpressions: 4, Day: at.Truncate(24 * time.Hour)},
+			CreatedAt: at,
+		}},
+		Stats: []storage.StatsState{{
+			SlotID:             1,
+			BannerID:           2,
+			UserGroupID:        3,
+			Impressions:        10,
+			Clicks:             1,
+			DecayedImpressions: 7.5,
+			DecayedClicks:      0.75,
+			DecayedAt:          at,
+		}},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSONL, FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, format, testState()))
+
+			state, err := Read(&buf, format)
+			require.NoError(t, err)
+			require.Equal(t, testState(), state)
+		})
+	}
+}
+
+func TestReadCSVColumns(t *testing.T) {
+	// Колонки можно перечислять в любом порядке и опускать
+	state, err := Read(strings.NewReader("name,type,id\nTop,slot,5\n"), FormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, []storage.Slot{{ID: 5, Name: "Top"}}, state.Slots)
+
+	_, err = Read(strings.NewReader("type,color\nslot,red\n"), FormatCSV)
+	require.ErrorIs(t, err, storage.ErrInvalidState)
+
+	_, err = Read(strings.NewReader("type,id\nslot,first\n"), FormatCSV)
+	require.ErrorIs(t, err, storage.ErrInvalidState)
+}
+
+func TestReadInvalid(t *testing.T) {
+	_, err := Read(strings.NewReader(`{"type":"campaign","id":1}`), FormatJSONL)
+	require.ErrorIs(t, err, storage.ErrInvalidState)
+
+	_, err = Read(strings.NewReader(`{"type":"slot","id":1,"color":"red"}`), FormatJSONL)
+	require.ErrorIs(t, err, storage.ErrInvalidState)
+
+	_, err = Read(strings.NewReader(""), "xml")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+	require.ErrorIs(t, Write(&bytes.Buffer{}, "xml", testState()), ErrUnknownFormat)
+
+	state, err := Read(strings.NewReader(""), FormatJSONL)
+	require.NoError(t, err)
+	require.Equal(t, &storage.State{}, state)
+}
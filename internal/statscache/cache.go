@@ -0,0 +1,371 @@
+package statscache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+)
+
+// defaultShards - число шардов, если в конфигурации оно не задано.
+const defaultShards = 16
+
+// Key - тройка слот-баннер-группа, для которой ведутся счетчики.
+type Key struct {
+	SlotID      int
+	BannerID    int
+	UserGroupID int
+}
+
+// Counters - счетчики тройки. Затухающие значения приведены к моменту At.
+type Counters struct {
+	Impressions        int64
+	Clicks             int64
+	DecayedImpressions float64
+	DecayedClicks      float64
+	At                 time.Time
+}
+
+// DecayedAt возвращает затухающие счетчики, приведенные к моменту now.
+func (c Counters) DecayedAt(now time.Time, decay multiarmedbandit.Decay) (impressions, clicks float64) {
+	factor := decay.Factor(now.Sub(c.At))
+	return c.DecayedImpressions * factor, c.DecayedClicks * factor
+}
+
+// merge складывает счетчики, приводя затухающие к более позднему из двух моментов.
+func (c Counters) merge(o Counters, decay multiarmedbandit.Decay) Counters {
+	at := c.At
+	if o.At.After(at) {
+		at = o.At
+	}
+	ci, cc := c.DecayedAt(at, decay)
+	oi, oc := o.DecayedAt(at, decay)
+	return Counters{
+		Impressions:        c.Impressions + o.Impressions,
+		Clicks:             c.Clicks + o.Clicks,
+		DecayedImpressions: ci + oi,
+		DecayedClicks:      cc + oc,
+		At:                 at,
+	}
+}
+
+func (c Counters) isZero() bool {
+	return c.Impressions == 0 && c.Clicks == 0 && c.DecayedImpressions == 0 && c.DecayedClicks == 0
+}
+
+// Delta - прирост счетчиков тройки, еще не записанный в хранилище.
+type Delta struct {
+	Key
+	Counters
+}
+
+// DecayFunc возвращает забывание статистики слота.
+type DecayFunc func(slotID int) multiarmedbandit.Decay
+
+// entry - счетчики тройки: base - сохраненные в хранилище, inflight - записываемые сейчас,
+// pending - накопленные после последней записи, remote - приросты других экземпляров,
+// еще не вошедшие в base (см. ApplyRemote). remote записывают сами эти экземпляры.
+type entry struct {
+	base     Counters
+	inflight Counters
+	pending  Counters
+	remote   Counters
+}
+
+func (e *entry) total(decay multiarmedbandit.Decay) Counters {
+	return e.base.merge(e.inflight, decay).merge(e.pending, decay).merge(e.remote, decay)
+}
+
+// shard хранит тройки нескольких слотов. Все тройки слота лежат в одном шарде и сгруппированы
+// по слоту, поэтому Slot не обходит остальные шарды и тройки.
+type shard struct {
+	mu    sync.RWMutex
+	slots map[int]map[Key]*entry
+}
+
+// entry возвращает счетчики тройки, создавая их при необходимости. Вызывается под mu.
+func (sh *shard) entry(key Key) *entry {
+	entries, ok := sh.slots[key.SlotID]
+	if !ok {
+		entries = make(map[Key]*entry)
+		sh.slots[key.SlotID] = entries
+	}
+	e, ok := entries[key]
+	if !ok {
+		e = &entry{}
+		entries[key] = e
+	}
+	return e
+}
+
+// remove удаляет тройку и пустую группу слота. Вызывается под mu.
+func (sh *shard) remove(key Key) {
+	entries := sh.slots[key.SlotID]
+	delete(entries, key)
+	if len(entries) == 0 {
+		delete(sh.slots, key.SlotID)
+	}
+}
+
+// Snapshot - счетчики хранилища и отметки записи экземпляров, прочитанные одним снимком.
+type Snapshot struct {
+	Counters map[Key]Counters
+	// Watermarks - для каждого экземпляра номер, до которого включительно все его события
+	// вошли в Counters (см. Cache.Reserve).
+	Watermarks map[string]uint64
+}
+
+// remoteEvent - событие другого экземпляра, которого еще нет в сохраненных счетчиках.
+type remoteEvent struct {
+	origin      string
+	seq         uint64
+	key         Key
+	impressions int64
+	clicks      int64
+	at          time.Time
+}
+
+// Cache - шардированный кеш счетчиков показов и кликов. Приросты копятся в памяти и
+// записываются в хранилище пачками (см. Flusher), а чтение не обращается к хранилищу.
+type Cache struct {
+	shards []*shard
+	decay  DecayFunc
+
+	// seqMu защищает нумерацию собственных событий
+	seqMu    sync.Mutex
+	lastSeq  uint64
+	reserved map[uint64]struct{}
+
+	// remoteMu защищает журнал событий других экземпляров и их отметки записи
+	remoteMu   sync.Mutex
+	remoteLog  []remoteEvent
+	watermarks map[string]uint64
+}
+
+// New создает кеш из shards шардов; 0 - значение по умолчанию. decay задает период
+// полураспада затухающих счетчиков слота; nil - без затухания.
+func New(shards int, decay DecayFunc) *Cache {
+	if shards <= 0 {
+		shards = defaultShards
+	}
+	if decay == nil {
+		decay = func(int) multiarmedbandit.Decay { return multiarmedbandit.Decay{} }
+	}
+
+	c := &Cache{
+		shards:     make([]*shard, shards),
+		decay:      decay,
+		reserved:   make(map[uint64]struct{}),
+		watermarks: make(map[string]uint64),
+	}
+	for i := range c.shards {
+		c.shards[i] = &shard{slots: make(map[int]map[Key]*entry)}
+	}
+	return c
+}
+
+func (c *Cache) shardFor(slotID int) *shard {
+	return c.shards[uint64(slotID)%uint64(len(c.shards))]
+}
+
+// Add учитывает показы и клики тройки, случившиеся в момент now.
+func (c *Cache) Add(key Key, impressions, clicks int64, now time.Time) {
+	c.add(key, impressions, clicks, now, func(e *entry) *Counters { return &e.pending })
+}
+
+// Reserve выдает номер следующему собственному событию; номер передается другим экземплярам
+// в уведомлении. Событие учитывается AddReserved или отменяется Release: пока номер занят,
+// отметка записи (см. Drain) не доходит до него.
+func (c *Cache) Reserve() uint64 {
+	c.seqMu.Lock()
+	defer c.seqMu.Unlock()
+
+	c.lastSeq++
+	c.reserved[c.lastSeq] = struct{}{}
+	return c.lastSeq
+}
+
+// AddReserved учитывает событие с номером seq, выданным Reserve.
+func (c *Cache) AddReserved(seq uint64, key Key, impressions, clicks int64, now time.Time) {
+	// Сначала прирост, потом освобождение номера: Drain с отметкой seq уже увидит прирост
+	c.Add(key, impressions, clicks, now)
+	c.Release(seq)
+}
+
+// Release освобождает номер события, которое не случилось.
+func (c *Cache) Release(seq uint64) {
+	c.seqMu.Lock()
+	defer c.seqMu.Unlock()
+
+	delete(c.reserved, seq)
+}
+
+// watermark возвращает наибольший номер, до которого все события уже учтены или отменены.
+func (c *Cache) watermark() uint64 {
+	c.seqMu.Lock()
+	defer c.seqMu.Unlock()
+
+	mark := c.lastSeq
+	for seq := range c.reserved {
+		if seq <= mark {
+			mark = seq - 1
+		}
+	}
+	return mark
+}
+
+// ApplyRemote учитывает показы и клики тройки из события seq экземпляра origin, случившиеся
+// в момент at. Они не возвращаются Drain: их записывает сам origin. Событие, которое уже вошло
+// в загруженный снимок (seq не больше отметки origin), пропускается; остальные действуют,
+// пока снимок не покажет, что origin их записал. Событие без номера действует до следующего Load.
+func (c *Cache) ApplyRemote(origin string, seq uint64, key Key, impressions, clicks int64, at time.Time) {
+	c.remoteMu.Lock()
+	defer c.remoteMu.Unlock()
+
+	if seq != 0 && seq <= c.watermarks[origin] {
+		return
+	}
+	event := remoteEvent{origin: origin, seq: seq, key: key, impressions: impressions, clicks: clicks, at: at}
+	c.remoteLog = append(c.remoteLog, event)
+	c.applyRemote(event)
+}
+
+// applyRemote добавляет событие к приростам других экземпляров. Вызывается под remoteMu.
+func (c *Cache) applyRemote(e remoteEvent) {
+	c.add(e.key, e.impressions, e.clicks, e.at, func(e *entry) *Counters { return &e.remote })
+}
+
+func (c *Cache) add(key Key, impressions, clicks int64, at time.Time, layer func(e *entry) *Counters) {
+	sh := c.shardFor(key.SlotID)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	counters := layer(sh.entry(key))
+	*counters = counters.merge(Counters{
+		Impressions:        impressions,
+		Clicks:             clicks,
+		DecayedImpressions: float64(impressions),
+		DecayedClicks:      float64(clicks),
+		At:                 at,
+	}, c.decay(key.SlotID))
+}
+
+// Get возвращает счетчики тройки с учетом еще не записанных приростов.
+func (c *Cache) Get(key Key) Counters {
+	sh := c.shardFor(key.SlotID)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	if e, ok := sh.slots[key.SlotID][key]; ok {
+		return e.total(c.decay(key.SlotID))
+	}
+	return Counters{}
+}
+
+// Slot возвращает счетчики всех троек слота.
+func (c *Cache) Slot(slotID int) map[Key]Counters {
+	decay := c.decay(slotID)
+	sh := c.shardFor(slotID)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	entries := sh.slots[slotID]
+	counters := make(map[Key]Counters, len(entries))
+	for key, e := range entries {
+		counters[key] = e.total(decay)
+	}
+	return counters
+}
+
+// Load заменяет сохраненные счетчики снимком хранилища. Из приростов других экземпляров
+// остаются только события, которых еще нет в снимке (номер больше отметки экземпляра).
+// Тройки, которых нет в снимке, удалены из хранилища: их сохраненные счетчики обнуляются.
+func (c *Cache) Load(snapshot Snapshot) {
+	c.remoteMu.Lock()
+	defer c.remoteMu.Unlock()
+
+	// Отметки в хранилище только растут, но снимок мог быть прочитан раньше предыдущего
+	for origin, seq := range snapshot.Watermarks {
+		if seq > c.watermarks[origin] {
+			c.watermarks[origin] = seq
+		}
+	}
+	retained := c.remoteLog[:0]
+	for _, event := range c.remoteLog {
+		if event.seq != 0 && event.seq > c.watermarks[event.origin] {
+			retained = append(retained, event)
+		}
+	}
+	c.remoteLog = retained
+
+	for _, sh := range c.shards {
+		sh.mu.Lock()
+		for _, entries := range sh.slots {
+			for key, e := range entries {
+				e.remote = Counters{}
+				if _, ok := snapshot.Counters[key]; ok {
+					continue
+				}
+				e.base = Counters{}
+				if e.inflight.isZero() && e.pending.isZero() {
+					sh.remove(key)
+				}
+			}
+		}
+		sh.mu.Unlock()
+	}
+
+	for key, counters := range snapshot.Counters {
+		sh := c.shardFor(key.SlotID)
+		sh.mu.Lock()
+		sh.entry(key).base = counters
+		sh.mu.Unlock()
+	}
+
+	for _, event := range c.remoteLog {
+		c.applyRemote(event)
+	}
+}
+
+// Drain возвращает приросты для записи в хранилище и отметку записи: все собственные события
+// с номером не больше нее входят в приросты или уже записаны. До Commit приросты учитываются
+// в Get; если запись не удалась, следующий Drain вернет их снова вместе с новыми.
+func (c *Cache) Drain() ([]Delta, uint64) {
+	// Отметка берется до сбора приростов: события до нее уже добавлены в pending
+	watermark := c.watermark()
+
+	deltas := make([]Delta, 0)
+	for _, sh := range c.shards {
+		sh.mu.Lock()
+		for slotID, entries := range sh.slots {
+			decay := c.decay(slotID)
+			for key, e := range entries {
+				if e.pending.isZero() && e.inflight.isZero() {
+					continue
+				}
+				e.inflight = e.inflight.merge(e.pending, decay)
+				e.pending = Counters{}
+				deltas = append(deltas, Delta{Key: key, Counters: e.inflight})
+			}
+		}
+		sh.mu.Unlock()
+	}
+	return deltas, watermark
+}
+
+// Commit переносит записанные в хранилище приросты в сохраненные счетчики.
+func (c *Cache) Commit() {
+	for _, sh := range c.shards {
+		sh.mu.Lock()
+		for slotID, entries := range sh.slots {
+			decay := c.decay(slotID)
+			for _, e := range entries {
+				if !e.inflight.isZero() {
+					e.base = e.base.merge(e.inflight, decay)
+					e.inflight = Counters{}
+				}
+			}
+		}
+		sh.mu.Unlock()
+	}
+}
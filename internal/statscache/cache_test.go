@@ -0,0 +1,188 @@
+package statscache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheAddGet(t *testing.T) {
+	cache := New(4, nil)
+	key := Key{SlotID: 1, BannerID: 2, UserGroupID: 3}
+	now := time.Now()
+
+	cache.Load(Snapshot{Counters: map[Key]Counters{
+		key: {Impressions: 10, Clicks: 2, DecayedImpressions: 10, DecayedClicks: 2, At: now},
+	}})
+	cache.Add(key, 1, 0, now)
+	cache.Add(key, 0, 1, now)
+	cache.Add(Key{SlotID: 2, BannerID: 2, UserGroupID: 3}, 1, 0, now)
+	// Слот 5 попадает в тот же шард, что и слот 1
+	cache.Add(Key{SlotID: 5, BannerID: 2, UserGroupID: 3}, 1, 0, now)
+
+	counters := cache.Get(key)
+	require.Equal(t, int64(11), counters.Impressions)
+	require.Equal(t, int64(3), counters.Clicks)
+	require.Len(t, cache.Slot(1), 1)
+	require.Len(t, cache.Slot(5), 1)
+	require.Equal(t, Counters{}, cache.Get(Key{SlotID: 5}))
+}
+
+func TestCacheDecay(t *testing.T) {
+	decay := func(slotID int) multiarmedbandit.Decay {
+		return multiarmedbandit.Decay{HalfLife: time.Hour}
+	}
+	cache := New(1, decay)
+	key := Key{SlotID: 1, BannerID: 1, UserGroupID: 1}
+	now := time.Now()
+
+	cache.Load(Snapshot{Counters: map[Key]Counters{key: {Impressions: 4, DecayedImpressions: 4, At: now.Add(-time.Hour)}}})
+	cache.Add(key, 1, 0, now)
+
+	// Сохраненные 4 показа за период полураспада затухли до 2
+	impressions, _ := cache.Get(key).DecayedAt(now, decay(1))
+	require.InDelta(t, 3, impressions, 1e-6)
+	require.Equal(t, int64(5), cache.Get(key).Impressions)
+}
+
+func TestCacheDrainCommit(t *testing.T) {
+	cache := New(2, nil)
+	key := Key{SlotID: 1, BannerID: 1, UserGroupID: 1}
+	now := time.Now()
+
+	cache.Add(key, 2, 1, now)
+	deltas, _ := cache.Drain()
+	require.Len(t, deltas, 1)
+	require.Equal(t, int64(2), deltas[0].Impressions)
+
+	// Пока запись не подтверждена, приросты учитываются и возвращаются повторно вместе с новыми
+	cache.Add(key, 1, 0, now)
+	require.Equal(t, int64(3), cache.Get(key).Impressions)
+	deltas, _ = cache.Drain()
+	require.Len(t, deltas, 1)
+	require.Equal(t, int64(3), deltas[0].Impressions)
+	require.Equal(t, int64(1), deltas[0].Clicks)
+
+	cache.Commit()
+	deltas, _ = cache.Drain()
+	require.Empty(t, deltas)
+	require.Equal(t, int64(3), cache.Get(key).Impressions)
+
+	// Снимок хранилища заменяет сохраненные счетчики, тройки без снимка и приростов удаляются
+	cache.Add(Key{SlotID: 2}, 1, 0, now)
+	cache.Load(Snapshot{Counters: map[Key]Counters{key: {Impressions: 7}}})
+	require.Equal(t, int64(7), cache.Get(key).Impressions)
+	require.Equal(t, int64(1), cache.Get(Key{SlotID: 2}).Impressions)
+	require.Len(t, cache.Slot(1), 1)
+}
+
+func TestCacheApplyRemote(t *testing.T) {
+	cache := New(2, nil)
+	key := Key{SlotID: 1, BannerID: 1, UserGroupID: 1}
+	now := time.Now()
+
+	cache.Load(Snapshot{Counters: map[Key]Counters{key: {Impressions: 10}}})
+	cache.Add(key, 1, 0, now)
+	cache.ApplyRemote("b", 1, key, 2, 1, now)
+	cache.ApplyRemote("b", 2, Key{SlotID: 1, BannerID: 2, UserGroupID: 1}, 1, 0, now)
+	require.Equal(t, int64(13), cache.Get(key).Impressions)
+	require.Equal(t, int64(1), cache.Get(key).Clicks)
+	require.Len(t, cache.Slot(1), 2)
+
+	// Приросты других экземпляров записывают они сами
+	deltas, _ := cache.Drain()
+	require.Len(t, deltas, 1)
+	require.Equal(t, int64(1), deltas[0].Impressions)
+	cache.Commit()
+
+	// Снимок хранилища уже содержит записанные другими экземплярами приросты
+	cache.Load(Snapshot{
+		Counters:   map[Key]Counters{key: {Impressions: 14, Clicks: 1}},
+		Watermarks: map[string]uint64{"b": 2},
+	})
+	require.Equal(t, int64(14), cache.Get(key).Impressions)
+	require.Len(t, cache.Slot(1), 1)
+}
+
+func TestCacheLoadKeepsUnflushedRemote(t *testing.T) {
+	cache := New(2, nil)
+	key := Key{SlotID: 1, BannerID: 1, UserGroupID: 1}
+	now := time.Now()
+
+	cache.ApplyRemote("b", 1, key, 1, 0, now)
+	cache.ApplyRemote("b", 2, key, 1, 0, now)
+	cache.ApplyRemote("c", 1, key, 0, 1, now)
+
+	// b записал только первое событие, c - ничего: остальные приросты остаются поверх снимка
+	cache.Load(Snapshot{
+		Counters:   map[Key]Counters{key: {Impressions: 1}},
+		Watermarks: map[string]uint64{"b": 1},
+	})
+	require.Equal(t, int64(2), cache.Get(key).Impressions)
+	require.Equal(t, int64(1), cache.Get(key).Clicks)
+
+	// Уведомление, пришедшее после снимка, в который событие уже вошло, не учитывается повторно
+	cache.ApplyRemote("b", 1, key, 1, 0, now)
+	require.Equal(t, int64(2), cache.Get(key).Impressions)
+
+	// Более старый снимок не откатывает отметку
+	cache.Load(Snapshot{
+		Counters:   map[Key]Counters{key: {Impressions: 3, Clicks: 1}},
+		Watermarks: map[string]uint64{"b": 2, "c": 1},
+	})
+	cache.Load(Snapshot{
+		Counters:   map[Key]Counters{key: {Impressions: 3, Clicks: 1}},
+		Watermarks: map[string]uint64{"b": 1},
+	})
+	require.Equal(t, int64(3), cache.Get(key).Impressions)
+	require.Equal(t, int64(1), cache.Get(key).Clicks)
+}
+
+func TestCacheReserve(t *testing.T) {
+	cache := New(2, nil)
+	key := Key{SlotID: 1, BannerID: 1, UserGroupID: 1}
+	now := time.Now()
+
+	first, second, third := cache.Reserve(), cache.Reserve(), cache.Reserve()
+	cache.AddReserved(first, key, 1, 0, now)
+	cache.AddReserved(third, key, 1, 0, now)
+
+	// Пока второе событие не завершено, отметка не доходит до него
+	deltas, seq := cache.Drain()
+	require.Len(t, deltas, 1)
+	require.Equal(t, first, seq)
+	cache.Commit()
+
+	cache.Release(second)
+	deltas, seq = cache.Drain()
+	require.Empty(t, deltas)
+	require.Equal(t, third, seq)
+	require.Equal(t, int64(2), cache.Get(key).Impressions)
+}
+
+func TestCacheConcurrentAdd(t *testing.T) {
+	cache := New(8, nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(groupID int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				cache.Add(Key{SlotID: 1, BannerID: j % 4, UserGroupID: groupID}, 1, 0, time.Now())
+				if j%100 == 0 {
+					cache.Drain()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var total int64
+	for _, counters := range cache.Slot(1) {
+		total += counters.Impressions
+	}
+	require.Equal(t, int64(8000), total)
+}
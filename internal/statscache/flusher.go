@@ -0,0 +1,123 @@
+package statscache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/interfaces"
+	"github.com/pkg/errors"
+)
+
+// Store - хранилище счетчиков, из которого кеш прогревается и в которое записывает приросты.
+type Store interface {
+	// LoadStats возвращает все счетчики и отметки записи экземпляров одним снимком;
+	// затухающие счетчики приведены к моменту Counters.At.
+	LoadStats(ctx context.Context) (Snapshot, error)
+	// FlushStats атомарно добавляет приросты к счетчикам и сохраняет отметку записи seq
+	// экземпляра (см. Cache.Drain). Приросты удаленных слотов, баннеров и групп отбрасываются.
+	FlushStats(ctx context.Context, deltas []Delta, seq uint64) error
+}
+
+// Flusher периодически записывает приросты кеша в хранилище и перечитывает счетчики,
+// чтобы видеть приросты других экземпляров сервиса.
+type Flusher struct {
+	cache    *Cache
+	store    Store
+	logger   interfaces.Logger
+	interval time.Duration
+	// resync - как часто перечитывать счетчики из хранилища; 0 - при каждой записи.
+	resync time.Duration
+
+	mu       sync.Mutex
+	loadedAt time.Time
+	// flushed - последняя записанная отметка
+	flushed uint64
+}
+
+func NewFlusher(cache *Cache, store Store, logger interfaces.Logger, interval string) (*Flusher, error) {
+	intervalDur, err := time.ParseDuration(interval)
+	if err != nil {
+		return nil, errors.Wrapf(err, "stats cache flush interval parsing fail (%s)", interval)
+	}
+	if intervalDur <= 0 {
+		return nil, errors.Errorf("stats cache flush interval must be positive, got %s", interval)
+	}
+
+	return &Flusher{cache: cache, store: store, logger: logger, interval: intervalDur}, nil
+}
+
+// SetResyncInterval задает, как часто Flush перечитывает счетчики из хранилища. Если приросты
+// других экземпляров приходят через Subscriber, чтение при каждой записи не нужно: редкая сверка
+// исправляет расхождения из-за потерянных или запоздавших уведомлений. Вызывается до Run.
+func (f *Flusher) SetResyncInterval(interval string) error {
+	intervalDur, err := time.ParseDuration(interval)
+	if err != nil {
+		return errors.Wrapf(err, "stats cache resync interval parsing fail (%s)", interval)
+	}
+	if intervalDur <= 0 {
+		return errors.Errorf("stats cache resync interval must be positive, got %s", interval)
+	}
+
+	f.resync = intervalDur
+	return nil
+}
+
+// Warm загружает счетчики хранилища в кеш.
+func (f *Flusher) Warm(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.load(ctx)
+}
+
+// Run записывает приросты до отмены контекста. Последнюю запись при остановке
+// выполняет вызывающий через Flush.
+func (f *Flusher) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.Flush(ctx); err != nil {
+				f.logger.Error("Failed to flush stats cache: %v", err)
+			}
+		}
+	}
+}
+
+// Flush записывает накопленные приросты одной пачкой и обновляет кеш из хранилища,
+// если подошло время сверки.
+func (f *Flusher) Flush(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Отметка записывается и без приростов: события, попавшие в прошлую пачку позже
+	// взятия отметки, иначе так и считались бы незаписанными у других экземпляров
+	if deltas, seq := f.cache.Drain(); len(deltas) > 0 || seq > f.flushed {
+		if err := f.store.FlushStats(ctx, deltas, seq); err != nil {
+			return errors.Wrap(err, "flush stats fail")
+		}
+		f.cache.Commit()
+		f.flushed = seq
+	}
+
+	if f.resync > 0 && time.Since(f.loadedAt) < f.resync {
+		return nil
+	}
+	return f.load(ctx)
+}
+
+// load заменяет счетчики кеша снимком хранилища. Вызывается под блокировкой.
+func (f *Flusher) load(ctx context.Context) error {
+	snapshot, err := f.store.LoadStats(ctx)
+	if err != nil {
+		return errors.Wrap(err, "load stats fail")
+	}
+	f.cache.Load(snapshot)
+	f.loadedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,115 @@
+package statscache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/logger"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore хранит счетчики так же, как banner_stats, а отметки - как stats_watermarks.
+type fakeStore struct {
+	stats      map[Key]Counters
+	watermarks map[string]uint64
+	flushes    int
+	failing    bool
+}
+
+func (s *fakeStore) LoadStats(context.Context) (Snapshot, error) {
+	snapshot := Snapshot{Counters: make(map[Key]Counters, len(s.stats)), Watermarks: make(map[string]uint64)}
+	for key, counters := range s.stats {
+		snapshot.Counters[key] = counters
+	}
+	for origin, seq := range s.watermarks {
+		snapshot.Watermarks[origin] = seq
+	}
+	return snapshot, nil
+}
+
+func (s *fakeStore) FlushStats(_ context.Context, deltas []Delta, seq uint64) error {
+	if s.failing {
+		return errors.New("connection refused")
+	}
+	s.flushes++
+	if s.watermarks == nil {
+		s.watermarks = make(map[string]uint64)
+	}
+	s.watermarks["self"] = seq
+	for _, d := range deltas {
+		counters := s.stats[d.Key]
+		counters.Impressions += d.Impressions
+		counters.Clicks += d.Clicks
+		s.stats[d.Key] = counters
+	}
+	return nil
+}
+
+func TestFlusher(t *testing.T) {
+	ctx := context.Background()
+	key := Key{SlotID: 1, BannerID: 1, UserGroupID: 1}
+	other := Key{SlotID: 1, BannerID: 2, UserGroupID: 1}
+	store := &fakeStore{stats: map[Key]Counters{key: {Impressions: 10, Clicks: 1}}}
+	cache := New(0, nil)
+
+	_, err := NewFlusher(cache, store, logger.New("error", io.Discard), "0s")
+	require.Error(t, err)
+	flusher, err := NewFlusher(cache, store, logger.New("error", io.Discard), "1h")
+	require.NoError(t, err)
+
+	require.NoError(t, flusher.Warm(ctx))
+	require.Equal(t, int64(10), cache.Get(key).Impressions)
+
+	cache.AddReserved(cache.Reserve(), key, 2, 1, time.Now())
+	require.Equal(t, int64(10), store.stats[key].Impressions)
+
+	// Неудачная запись не теряет приросты
+	store.failing = true
+	require.Error(t, flusher.Flush(ctx))
+	require.Equal(t, int64(12), cache.Get(key).Impressions)
+
+	// Запись другого экземпляра видна после обновления из хранилища
+	store.failing = false
+	store.stats[other] = Counters{Impressions: 5}
+	require.NoError(t, flusher.Flush(ctx))
+	require.Equal(t, Counters{Impressions: 12, Clicks: 2}, store.stats[key])
+	require.Equal(t, int64(12), cache.Get(key).Impressions)
+	require.Equal(t, int64(5), cache.Get(other).Impressions)
+	require.Equal(t, uint64(1), store.watermarks["self"])
+
+	// Без приростов и новой отметки хранилище не пишется
+	require.NoError(t, flusher.Flush(ctx))
+	require.Equal(t, 1, store.flushes)
+}
+
+func TestFlusherResync(t *testing.T) {
+	ctx := context.Background()
+	key := Key{SlotID: 1, BannerID: 1, UserGroupID: 1}
+	store := &fakeStore{stats: map[Key]Counters{key: {Impressions: 10}}}
+	cache := New(0, nil)
+
+	flusher, err := NewFlusher(cache, store, logger.New("error", io.Discard), "1h")
+	require.NoError(t, err)
+	require.Error(t, flusher.SetResyncInterval("-1s"))
+	require.NoError(t, flusher.SetResyncInterval("1h"))
+	require.NoError(t, flusher.Warm(ctx))
+
+	// До сверки приросты другого экземпляра приходят только через ApplyRemote
+	cache.Add(key, 1, 0, time.Now())
+	cache.ApplyRemote("b", 1, key, 2, 0, time.Now())
+	cache.ApplyRemote("b", 2, key, 1, 0, time.Now())
+	store.stats[key] = Counters{Impressions: 12}
+	require.NoError(t, flusher.Flush(ctx))
+	require.Equal(t, Counters{Impressions: 13}, store.stats[key])
+	require.Equal(t, int64(14), cache.Get(key).Impressions)
+
+	// Сверка заменяет их снимком хранилища, кроме еще не записанного вторым экземпляром события
+	flusher.loadedAt = time.Now().Add(-2 * time.Hour)
+	store.stats[key] = Counters{Impressions: 20}
+	store.watermarks["b"] = 1
+	require.NoError(t, flusher.Flush(ctx))
+	require.Equal(t, int64(21), cache.Get(key).Impressions)
+}
@@ -0,0 +1,102 @@
+package statscache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/interfaces"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/streadway/amqp"
+)
+
+// resubscribeInterval - пауза перед повторной подпиской, если канал доставок закрылся.
+const resubscribeInterval = time.Second
+
+// Source - очередь уведомлений о событиях, принадлежащая этому экземпляру.
+type Source interface {
+	Consume(consumerTag string) (<-chan amqp.Delivery, error)
+}
+
+// Subscriber учитывает в кеше показы и клики других экземпляров сервиса из их уведомлений,
+// чтобы выбор баннера видел их до следующей сверки с хранилищем. Уведомления самого
+// экземпляра (с его origin) пропускаются: они уже учтены в кеше.
+type Subscriber struct {
+	cache  *Cache
+	source Source
+	logger interfaces.Logger
+
+	origin string
+}
+
+func NewSubscriber(cache *Cache, source Source, logger interfaces.Logger, origin string) *Subscriber {
+	return &Subscriber{cache: cache, source: source, logger: logger, origin: origin}
+}
+
+// Run обрабатывает доставки до отмены контекста. После переподключения к RabbitMQ
+// канал доставок закрывается, и Run подписывается заново. Уведомления, пришедшие во время
+// разрыва, теряются вместе с очередью; расхождение исправляет сверка Flusher.
+func (s *Subscriber) Run(ctx context.Context) {
+	for {
+		if err := s.consumeOnce(ctx); err != nil {
+			s.logger.Error("Subscribing to notifications failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeInterval):
+		}
+	}
+}
+
+func (s *Subscriber) consumeOnce(ctx context.Context) error {
+	// Пустой тег: брокер выдает уникальный тег подписчика
+	deliveries, err := s.source.Consume("")
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return nil
+			}
+			s.handle(delivery)
+		}
+	}
+}
+
+// handle учитывает одно уведомление. Очередь не переживает перезапуск экземпляра,
+// поэтому сообщения не возвращаются в нее: некорректные просто отбрасываются.
+func (s *Subscriber) handle(delivery amqp.Delivery) {
+	defer func() {
+		if err := delivery.Ack(false); err != nil {
+			s.logger.Error("Failed to ack notification %s: %v", delivery.MessageId, err)
+		}
+	}()
+
+	var notification storage.Notification
+	if err := json.Unmarshal(delivery.Body, &notification); err != nil {
+		s.logger.Error("Invalid notification %s: %v", delivery.MessageId, err)
+		return
+	}
+	// Свои события и события экземпляров без кеша (без origin) уже есть в кеше или в хранилище
+	if notification.Origin == "" || notification.Origin == s.origin {
+		return
+	}
+	if notification.TypeEvent == storage.EventCapReached {
+		return
+	}
+	impressions, clicks, err := notification.Counters()
+	if err != nil {
+		s.logger.Error("Invalid notification %s: %v", delivery.MessageId, err)
+		return
+	}
+
+	key := Key{SlotID: notification.SlotID, BannerID: notification.BannerID, UserGroupID: notification.UsergroupID}
+	s.cache.ApplyRemote(notification.Origin, notification.Seq, key, impressions, clicks, notification.DateTime)
+}
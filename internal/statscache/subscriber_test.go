@@ -0,0 +1,91 @@
+package statscache
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/logger"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+// countingAcknowledger считает подтвержденные доставки.
+type countingAcknowledger struct {
+	acked chan uint64
+}
+
+func (a *countingAcknowledger) Ack(tag uint64, _ bool) error {
+	a.acked <- tag
+	return nil
+}
+
+func (a *countingAcknowledger) Nack(tag uint64, _ bool, _ bool) error {
+	return nil
+}
+
+func (a *countingAcknowledger) Reject(tag uint64, _ bool) error {
+	return nil
+}
+
+type fakeSource struct {
+	deliveries chan amqp.Delivery
+}
+
+func (s *fakeSource) Consume(string) (<-chan amqp.Delivery, error) {
+	return s.deliveries, nil
+}
+
+func TestSubscriber(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cache := New(0, nil)
+	source := &fakeSource{deliveries: make(chan amqp.Delivery, 8)}
+	ack := &countingAcknowledger{acked: make(chan uint64, 8)}
+	go NewSubscriber(cache, source, logger.New("error", io.Discard), "self").Run(ctx)
+
+	event := storage.Notification{SlotID: 1, BannerID: 2, UsergroupID: 3, DateTime: time.Now(), Origin: "other"}
+	bodies := []storage.Notification{event, event, event, event, event, event}
+	bodies[0].TypeEvent = storage.EventImpress
+	bodies[1].TypeEvent = storage.EventImpress
+	bodies[2].TypeEvent = storage.EventClick
+	for i := 0; i < 3; i++ {
+		bodies[i].Seq = uint64(i + 1)
+	}
+	// Свои события, события без origin и уведомления об ограничениях не учитываются
+	bodies[3].TypeEvent, bodies[3].Origin = storage.EventImpress, "self"
+	bodies[4].TypeEvent, bodies[4].Origin = storage.EventImpress, ""
+	bodies[5].TypeEvent, bodies[5].Cap = storage.EventCapReached, "impressions"
+
+	for i, n := range bodies {
+		body, err := n.Marshal()
+		require.NoError(t, err)
+		source.deliveries <- amqp.Delivery{Acknowledger: ack, DeliveryTag: uint64(i), Body: body}
+	}
+	source.deliveries <- amqp.Delivery{Acknowledger: ack, DeliveryTag: 100, Body: []byte("{")}
+
+	// Все доставки подтверждаются, включая некорректные
+	for i := 0; i < len(bodies)+1; i++ {
+		select {
+		case <-ack.acked:
+		case <-time.After(time.Second):
+			t.Fatal("delivery was not acked")
+		}
+	}
+
+	key := Key{SlotID: 1, BannerID: 2, UserGroupID: 3}
+	counters := cache.Get(key)
+	require.Equal(t, int64(2), counters.Impressions)
+	require.Equal(t, int64(1), counters.Clicks)
+	deltas, _ := cache.Drain()
+	require.Empty(t, deltas)
+
+	// Клик еще не записан отправителем и остается поверх снимка
+	cache.Load(Snapshot{Counters: map[Key]Counters{key: {Impressions: 2}}, Watermarks: map[string]uint64{"other": 2}})
+	counters = cache.Get(key)
+	require.Equal(t, int64(2), counters.Impressions)
+	require.Equal(t, int64(1), counters.Clicks)
+}
@@ -0,0 +1,203 @@
+package statsconsumer
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/interfaces"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/pkg/errors"
+	"github.com/streadway/amqp"
+)
+
+// resubscribeInterval - пауза перед повторной подпиской, если канал доставок закрылся.
+const resubscribeInterval = time.Second
+
+type Source interface {
+	Qos(prefetchCount int) error
+	Consume(consumerTag string) (<-chan amqp.Delivery, error)
+}
+
+// Consumer читает уведомления из очереди и складывает их в почасовые агрегаты.
+// Сообщение подтверждается только после записи в хранилище.
+type Consumer struct {
+	storage interfaces.Rollups
+	source  Source
+	logger  interfaces.Logger
+
+	consumerTag   string
+	prefetchCount int
+	threads       int
+
+	// Отметки учтенных сообщений хранятся dedupTTL: срок должен превышать окно повторной
+	// доставки брокера, иначе поздний повтор будет учтен дважды.
+	dedupTTL      time.Duration
+	purgeInterval time.Duration
+}
+
+func NewConsumer(
+	storage interfaces.Rollups,
+	source Source,
+	logger interfaces.Logger,
+	consumerTag string,
+	prefetchCount, threads int,
+	dedupTTL, purgeInterval string,
+) (*Consumer, error) {
+	dedupTTLDur, err := time.ParseDuration(dedupTTL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "consumer dedup TTL parsing fail (%s)", dedupTTL)
+	}
+	purgeIntervalDur, err := time.ParseDuration(purgeInterval)
+	if err != nil {
+		return nil, errors.Wrapf(err, "consumer dedup purge interval parsing fail (%s)", purgeInterval)
+	}
+	if purgeIntervalDur <= 0 {
+		return nil, errors.Errorf("consumer dedup purge interval must be positive, got %s", purgeInterval)
+	}
+	if threads <= 0 {
+		return nil, errors.Errorf("consumer threads must be positive, got %d", threads)
+	}
+	if prefetchCount < 0 {
+		return nil, errors.Errorf("consumer prefetch count must not be negative, got %d", prefetchCount)
+	}
+
+	return &Consumer{
+		storage:       storage,
+		source:        source,
+		logger:        logger,
+		consumerTag:   consumerTag,
+		prefetchCount: prefetchCount,
+		threads:       threads,
+		dedupTTL:      dedupTTLDur,
+		purgeInterval: purgeIntervalDur,
+	}, nil
+}
+
+// Run обрабатывает доставки до отмены контекста. После переподключения к RabbitMQ
+// канал доставок закрывается, и Run подписывается заново. Раз в purgeInterval удаляются
+// отметки сообщений, учтенных раньше dedupTTL.
+func (c *Consumer) Run(ctx context.Context) error {
+	go c.purgeLoop(ctx)
+
+	for {
+		if err := c.consumeOnce(ctx); err != nil {
+			c.logger.Error("Consuming notifications failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(resubscribeInterval):
+		}
+	}
+}
+
+func (c *Consumer) purgeLoop(ctx context.Context) {
+	ticker := time.NewTicker(c.purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.PurgeOnce(ctx); err != nil {
+				c.logger.Error("Purging counted notifications failed: %v", err)
+			}
+		}
+	}
+}
+
+// PurgeOnce удаляет отметки сообщений, учтенных раньше dedupTTL.
+func (c *Consumer) PurgeOnce(ctx context.Context) error {
+	purged, err := c.storage.PurgeRollupMessages(ctx, time.Now().Add(-c.dedupTTL))
+	if err != nil {
+		return errors.Wrap(err, "purge rollup messages fail")
+	}
+	if purged > 0 {
+		c.logger.Debug("Forgot %d counted notifications", purged)
+	}
+	return nil
+}
+
+func (c *Consumer) consumeOnce(ctx context.Context) error {
+	if err := c.source.Qos(c.prefetchCount); err != nil {
+		return err
+	}
+
+	deliveries, err := c.source.Consume(c.consumerTag)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.work(ctx, deliveries)
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (c *Consumer) work(ctx context.Context, deliveries <-chan amqp.Delivery) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return
+			}
+			c.handle(ctx, delivery)
+		}
+	}
+}
+
+// handle учитывает одно уведомление. Некорректные сообщения отбрасываются,
+// при ошибке хранилища сообщение возвращается в очередь.
+func (c *Consumer) handle(ctx context.Context, delivery amqp.Delivery) {
+	var notification storage.Notification
+	if err := json.Unmarshal(delivery.Body, &notification); err != nil {
+		c.reject(delivery, false, errors.Wrap(err, "invalid notification"))
+		return
+	}
+	// Уведомления об исчерпанных ограничениях не входят в агрегаты
+	if notification.TypeEvent == storage.EventCapReached {
+		c.ack(delivery)
+		return
+	}
+	if _, _, err := notification.Counters(); err != nil {
+		c.reject(delivery, false, err)
+		return
+	}
+
+	added, err := c.storage.AddHourlyRollup(ctx, delivery.MessageId, notification)
+	if err != nil {
+		c.reject(delivery, true, errors.Wrap(err, "add hourly rollup fail"))
+		return
+	}
+	if !added {
+		c.logger.Debug("Notification %s has already been counted", delivery.MessageId)
+	}
+
+	c.ack(delivery)
+}
+
+func (c *Consumer) ack(delivery amqp.Delivery) {
+	if err := delivery.Ack(false); err != nil {
+		c.logger.Error("Failed to ack notification %s: %v", delivery.MessageId, err)
+	}
+}
+
+func (c *Consumer) reject(delivery amqp.Delivery, requeue bool, reason error) {
+	c.logger.Error("Failed to process notification %s (requeue: %t): %v", delivery.MessageId, requeue, reason)
+	if err := delivery.Nack(false, requeue); err != nil {
+		c.logger.Error("Failed to nack notification %s: %v", delivery.MessageId, err)
+	}
+}
@@ -0,0 +1,175 @@
+package statsconsumer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/logger"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage/memory"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAcknowledger запоминает, как была завершена обработка каждой доставки.
+type fakeAcknowledger struct {
+	mu      sync.Mutex
+	acked   []uint64
+	nacked  []uint64
+	requeue []bool
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, _ bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.acked = append(a.acked, tag)
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, _ bool, requeue bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.nacked = append(a.nacked, tag)
+	a.requeue = append(a.requeue, requeue)
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func (a *fakeAcknowledger) done() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.acked) + len(a.nacked)
+}
+
+type fakeSource struct {
+	deliveries chan amqp.Delivery
+}
+
+func (s *fakeSource) Qos(int) error {
+	return nil
+}
+
+func (s *fakeSource) Consume(string) (<-chan amqp.Delivery, error) {
+	return s.deliveries, nil
+}
+
+// failingRollups имитирует недоступное хранилище.
+type failingRollups struct{}
+
+func (failingRollups) AddHourlyRollup(context.Context, string, storage.Notification) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func (failingRollups) PurgeRollupMessages(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (failingRollups) HourlyRollups(context.Context, storage.StatsFilter) ([]storage.HourlyRollup, error) {
+	return nil, nil
+}
+
+func delivery(ack amqp.Acknowledger, tag uint64, messageID string, body string) amqp.Delivery {
+	return amqp.Delivery{Acknowledger: ack, DeliveryTag: tag, MessageId: messageID, Body: []byte(body)}
+}
+
+func TestConsumerAggregatesNotifications(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rollups := memory.New(nil)
+	source := &fakeSource{deliveries: make(chan amqp.Delivery, 10)}
+	ack := &fakeAcknowledger{}
+
+	consumer, err := NewConsumer(rollups, source, logger.New("error", io.Discard), "test", 10, 3, "24h", "1h")
+	require.NoError(t, err)
+
+	source.deliveries <- delivery(ack, 1, "1",
+		`{"type_event":"impress","slot_id":1,"banner_id":2,"usergroup_id":3,"date_time":"2023-12-15T10:05:00Z"}`)
+	source.deliveries <- delivery(ack, 2, "2",
+		`{"type_event":"impress","slot_id":1,"banner_id":2,"usergroup_id":3,"date_time":"2023-12-15T10:55:00Z"}`)
+	source.deliveries <- delivery(ack, 3, "3",
+		`{"type_event":"click","slot_id":1,"banner_id":2,"usergroup_id":3,"date_time":"2023-12-15T10:56:00Z"}`)
+	source.deliveries <- delivery(ack, 4, "4",
+		`{"type_event":"impress","slot_id":1,"banner_id":2,"usergroup_id":3,"date_time":"2023-12-15T11:00:00Z"}`)
+	// Повторная доставка уже учтенного сообщения
+	source.deliveries <- delivery(ack, 5, "3",
+		`{"type_event":"click","slot_id":1,"banner_id":2,"usergroup_id":3,"date_time":"2023-12-15T10:56:00Z"}`)
+	source.deliveries <- delivery(ack, 6, "6", `not a json`)
+	source.deliveries <- delivery(ack, 7, "7", `{"type_event":"view","slot_id":1}`)
+
+	go func() {
+		_ = consumer.Run(ctx)
+	}()
+	require.Eventually(t, func() bool { return ack.done() == 7 }, time.Second, 5*time.Millisecond)
+
+	require.ElementsMatch(t, []uint64{1, 2, 3, 4, 5}, ack.acked)
+	require.ElementsMatch(t, []uint64{6, 7}, ack.nacked)
+	require.Equal(t, []bool{false, false}, ack.requeue)
+
+	got, err := rollups.HourlyRollups(ctx, storage.StatsFilter{SlotID: 1})
+	require.NoError(t, err)
+	require.Equal(t, []storage.HourlyRollup{
+		{
+			Hour:   time.Date(2023, 12, 15, 10, 0, 0, 0, time.UTC),
+			SlotID: 1, BannerID: 2, UserGroupID: 3, Impressions: 2, Clicks: 1,
+		},
+		{
+			Hour:   time.Date(2023, 12, 15, 11, 0, 0, 0, time.UTC),
+			SlotID: 1, BannerID: 2, UserGroupID: 3, Impressions: 1,
+		},
+	}, got)
+}
+
+func TestConsumerRequeuesOnStorageError(t *testing.T) {
+	ack := &fakeAcknowledger{}
+	consumer, err := NewConsumer(failingRollups{}, &fakeSource{}, logger.New("error", io.Discard),
+		"test", 1, 1, "24h", "1h")
+	require.NoError(t, err)
+
+	consumer.handle(context.Background(), delivery(ack, 1, "1",
+		`{"type_event":"click","slot_id":1,"banner_id":2,"usergroup_id":3,"date_time":"2023-12-15T10:56:00Z"}`))
+
+	require.Empty(t, ack.acked)
+	require.Equal(t, []uint64{1}, ack.nacked)
+	require.Equal(t, []bool{true}, ack.requeue)
+}
+
+func TestConsumerPurgesCountedMessages(t *testing.T) {
+	ctx := context.Background()
+	rollups := memory.New(nil)
+	ack := &fakeAcknowledger{}
+	body := `{"type_event":"click","slot_id":1,"banner_id":2,"usergroup_id":3,"date_time":"2023-12-15T10:56:00Z"}`
+
+	keeping, err := NewConsumer(rollups, &fakeSource{}, logger.New("error", io.Discard), "test", 1, 1, "24h", "1h")
+	require.NoError(t, err)
+	keeping.handle(ctx, delivery(ack, 1, "1", body))
+	require.NoError(t, keeping.PurgeOnce(ctx))
+	keeping.handle(ctx, delivery(ack, 2, "1", body))
+
+	// После срока хранения отметка удалена, и повтор учитывается снова
+	purging, err := NewConsumer(rollups, &fakeSource{}, logger.New("error", io.Discard), "test", 1, 1, "0s", "1h")
+	require.NoError(t, err)
+	require.NoError(t, purging.PurgeOnce(ctx))
+	purging.handle(ctx, delivery(ack, 3, "1", body))
+
+	got, err := rollups.HourlyRollups(ctx, storage.StatsFilter{SlotID: 1})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, int64(2), got[0].Clicks)
+}
+
+func TestNewConsumerValidation(t *testing.T) {
+	_, err := NewConsumer(memory.New(nil), &fakeSource{}, logger.New("error", io.Discard), "test", 10, 0, "24h", "1h")
+	require.Error(t, err)
+	_, err = NewConsumer(memory.New(nil), &fakeSource{}, logger.New("error", io.Discard), "test", 10, 1, "1d", "1h")
+	require.Error(t, err)
+	_, err = NewConsumer(memory.New(nil), &fakeSource{}, logger.New("error", io.Discard), "test", 10, 1, "24h", "0s")
+	require.Error(t, err)
+}
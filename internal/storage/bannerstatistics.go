@@ -1,5 +1,7 @@
 package storage
 
+import "time"
+
 type BannerStatistics struct {
 	BannerID    int `db:"banner_id"`
 	Impressions int `db:"impressions"`
@@ -17,3 +19,28 @@ func (b *BannerStatistics) GetImpressions() float64 {
 func (b *BannerStatistics) GetClicks() float64 {
 	return float64(b.Clicks)
 }
+
+// StatsFilter - условия выборки статистики по баннерам слота.
+// Нулевой UserGroupID означает все группы, нулевые From и To - отсутствие границ.
+type StatsFilter struct {
+	SlotID      int
+	UserGroupID int
+	From        time.Time
+	To          time.Time
+}
+
+// HasWindow сообщает, ограничена ли выборка по времени.
+func (f StatsFilter) HasWindow() bool {
+	return !f.From.IsZero() || !f.To.IsZero()
+}
+
+// InWindow проверяет, попадает ли момент времени в полуинтервал [From, To).
+func (f StatsFilter) InWindow(t time.Time) bool {
+	if !f.From.IsZero() && t.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !t.Before(f.To) {
+		return false
+	}
+	return true
+}
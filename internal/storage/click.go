@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Причины отклонения перехода.
+const (
+	// RejectDuplicateImpression - по этому показу уже был переход в пределах окна.
+	RejectDuplicateImpression = "duplicate_impression"
+	// RejectDuplicateClient - клиент уже переходил по баннеру в слоте в пределах окна.
+	RejectDuplicateClient = "duplicate_client"
+	// RejectNoImpression - переходу не предшествовал показ баннера в слоте для группы.
+	RejectNoImpression = "no_impression"
+)
+
+// ClickAttempt - переход, который нужно проверить и записать.
+type ClickAttempt struct {
+	BannerID    int
+	SlotID      int
+	UserGroupID int
+	// ImpressionTokenID - идентификатор токена показа; пустой, если переход пришел без токена.
+	ImpressionTokenID string
+	// ClientKey - IP-адрес клиента; пустой отключает проверку по клиенту.
+	ClientKey string
+}
+
+// ClickGuard - правила отсечения повторных и подозрительных переходов.
+// Нулевое окно отключает соответствующую проверку.
+type ClickGuard struct {
+	// ImpressionWindow - окно, в котором второй переход по тому же показу отклоняется.
+	ImpressionWindow time.Duration
+	// ClientWindow - окно, в котором повторный переход клиента по баннеру в слоте отклоняется.
+	ClientWindow time.Duration
+	// RequireImpression отклоняет переходы без токена, если баннер не показывался в слоте
+	// для группы в пределах ImpressionMaxAge.
+	RequireImpression bool
+	// ImpressionMaxAge - сколько после показа баннера принимаются переходы без токена.
+	ImpressionMaxAge time.Duration
+}
+
+// NewClickGuard разбирает окна дедупликации из конфигурации. Пустая строка отключает окно.
+// Для requireImpression окно impressionMaxAge обязательно.
+func NewClickGuard(
+	impressionWindow, clientWindow string,
+	requireImpression bool,
+	impressionMaxAge string,
+) (ClickGuard, error) {
+	guard := ClickGuard{RequireImpression: requireImpression}
+
+	var err error
+	if guard.ImpressionWindow, err = parseWindow(impressionWindow); err != nil {
+		return ClickGuard{}, fmt.Errorf("invalid impression dedup window: %w", err)
+	}
+	if guard.ClientWindow, err = parseWindow(clientWindow); err != nil {
+		return ClickGuard{}, fmt.Errorf("invalid client dedup window: %w", err)
+	}
+	if guard.ImpressionMaxAge, err = parseWindow(impressionMaxAge); err != nil {
+		return ClickGuard{}, fmt.Errorf("invalid impression max age: %w", err)
+	}
+	if guard.RequireImpression && guard.ImpressionMaxAge == 0 {
+		return ClickGuard{}, fmt.Errorf("impression max age is required to require impressions")
+	}
+
+	return guard, nil
+}
+
+func parseWindow(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if window < 0 {
+		return 0, fmt.Errorf("negative window %s", value)
+	}
+	return window, nil
+}
+
+// RejectedClick - отклоненный переход, сохраненный для аудита.
+type RejectedClick struct {
+	ID           int
+	SlotID       int
+	BannerID     int
+	UserGroupID  int
+	ImpressionID int
+	ClientKey    string
+	Reason       string
+	CreatedAt    time.Time
+}
+
+// RejectedClickError возвращается, когда переход отклонен и записан в журнал отклоненных переходов.
+type RejectedClickError struct {
+	Reason string
+}
+
+func (e *RejectedClickError) Error() string {
+	return "click rejected: " + e.Reason
+}
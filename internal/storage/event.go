@@ -1,6 +1,16 @@
 package storage
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventClick   = "click"
+	EventImpress = "impress"
+	// EventCapReached - баннер исчерпал ограничение ротации, указанное в Notification.Cap.
+	EventCapReached = "cap_reached"
+)
 
 type Click struct {
 	ID          int       `json:"id"`
@@ -8,6 +18,12 @@ type Click struct {
 	BannerID    int       `json:"banner_id"`    //nolint:tagliatelle
 	UserGroupID int       `json:"usergroup_id"` //nolint:tagliatelle
 	CreatedAt   time.Time `json:"created_at"`   //nolint:tagliatelle
+	// ImpressionID - показ, по которому сделан переход; 0, если переход пришел без токена показа.
+	ImpressionID int `json:"impression_id,omitempty"` //nolint:tagliatelle
+	// ClientKey - пользователь или IP-адрес, с которого пришел переход.
+	ClientKey string `json:"client_key,omitempty"` //nolint:tagliatelle
+	// CapsReached - ограничения ротации, исчерпанные этим переходом.
+	CapsReached []string `json:"-"`
 }
 
 type Impress struct {
@@ -16,6 +32,10 @@ type Impress struct {
 	BannerID    int       `json:"banner_id"`    //nolint:tagliatelle
 	UserGroupID int       `json:"usergroup_id"` //nolint:tagliatelle
 	CreatedAt   time.Time `json:"created_at"`   //nolint:tagliatelle
+	// TokenID - идентификатор токена, которым подтвержден показ.
+	TokenID string `json:"token_id,omitempty"` //nolint:tagliatelle
+	// CapsReached - ограничения ротации, исчерпанные этим показом.
+	CapsReached []string `json:"-"`
 }
 
 type Notification struct {
@@ -24,4 +44,54 @@ type Notification struct {
 	BannerID    int       `json:"banner_id"`    //nolint:tagliatelle
 	UsergroupID int       `json:"usergroup_id"` //nolint:tagliatelle
 	DateTime    time.Time `json:"date_time"`    //nolint:tagliatelle
+	// Cap - исчерпанное ограничение для EventCapReached.
+	Cap string `json:"cap,omitempty"`
+	// Origin - экземпляр сервиса, записавший событие; пусто, если экземпляры не различаются.
+	Origin string `json:"origin,omitempty"`
+	// Seq - номер события у экземпляра Origin; по нему другие экземпляры понимают,
+	// записал ли Origin событие в хранилище. 0 - номер не выдавался.
+	Seq uint64 `json:"seq,omitempty"`
+}
+
+// OutboxMessage - уведомление, ожидающее отправки в очередь.
+type OutboxMessage struct {
+	ID        int64
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+func NewClickNotification(click *Click) Notification {
+	return Notification{
+		TypeEvent:   EventClick,
+		SlotID:      click.SlotID,
+		BannerID:    click.BannerID,
+		UsergroupID: click.UserGroupID,
+		DateTime:    click.CreatedAt,
+	}
+}
+
+func NewImpressNotification(impress *Impress) Notification {
+	return Notification{
+		TypeEvent:   EventImpress,
+		SlotID:      impress.SlotID,
+		BannerID:    impress.BannerID,
+		UsergroupID: impress.UserGroupID,
+		DateTime:    impress.CreatedAt,
+	}
+}
+
+// NewCapNotifications возвращает уведомления об ограничениях, исчерпанных событием event.
+func NewCapNotifications(event Notification, caps []string) []Notification {
+	notifications := make([]Notification, 0, len(caps))
+	for _, c := range caps {
+		n := event
+		n.TypeEvent = EventCapReached
+		n.Cap = c
+		notifications = append(notifications, n)
+	}
+	return notifications
+}
+
+func (n Notification) Marshal() ([]byte, error) {
+	return json.Marshal(n)
 }
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var ErrInvalidFeatures = errors.New("invalid usergroup features")
+
+// Features - числовые признаки группы пользователей по имени, например
+// {"age_18_24": 1, "gender_female": 1, "interest_sport": 0.5}. Незаданный признак равен 0.
+type Features map[string]float64
+
+func (f Features) Validate() error {
+	for name, value := range f {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: empty feature name", ErrInvalidFeatures)
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return fmt.Errorf("%w: feature %q is not a finite number", ErrInvalidFeatures, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrAlreadyRecorded = errors.New("impression is already recorded")
+	ErrNoActiveBanners = errors.New("no active banners for a given slot")
+)
+
+type Slot struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"` //nolint:tagliatelle
+}
+
+type Banner struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"` //nolint:tagliatelle
+}
+
+type UserGroup struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"` //nolint:tagliatelle
+}
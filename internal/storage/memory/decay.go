@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+)
+
+// decayedCounts - счетчики с экспоненциальным затуханием, приведенные к моменту at.
+type decayedCounts struct {
+	impressions float64
+	clicks      float64
+	at          time.Time
+}
+
+// countEvent увеличивает счетчики тройки слот-баннер-группа, включая затухающие
+// с периодом полураспада слота. Вызывается под блокировкой.
+func (s *Storage) countEvent(slotID, bannerID, userGroupID, impressions, clicks int, now time.Time) {
+	st := s.statsFor(slotID, bannerID, userGroupID)
+	st.Impressions += impressions
+	st.Clicks += clicks
+
+	key := statsKey{slotID: slotID, bannerID: bannerID, userGroupID: userGroupID}
+	dc, ok := s.decayed[key]
+	if !ok {
+		dc = &decayedCounts{at: now}
+		s.decayed[key] = dc
+	}
+	factor := s.strategies.DecayForSlot(slotID).Factor(now.Sub(dc.at))
+	dc.impressions = dc.impressions*factor + float64(impressions)
+	dc.clicks = dc.clicks*factor + float64(clicks)
+	dc.at = now
+}
+
+// slotBanners возвращает активные баннеры слота с условиями показа, числом подтвержденных показов
+// и счетчиками для группы с учетом забывания статистики слота. Вызывается под блокировкой.
+func (s *Storage) slotBanners(slotID, userGroupID int) []multiarmedbandit.Banner {
+	now := time.Now()
+	ids := make([]int, 0)
+	for key, r := range s.rotations {
+		if key.slotID == slotID && r.activeAt(now) {
+			ids = append(ids, key.bannerID)
+		}
+	}
+	sort.Ints(ids)
+
+	decay := s.strategies.DecayForSlot(slotID)
+	counts := make(map[int]*multiarmedbandit.Counts, len(ids))
+	banners := make([]multiarmedbandit.Banner, 0, len(ids))
+	for _, id := range ids {
+		r := s.rotations[rotationKey{slotID: slotID, bannerID: id}]
+		settings := r.settings
+		bnr := &multiarmedbandit.Counts{
+			BannerID: id,
+			Served:   r.served.Impressions,
+			Priority: multiarmedbandit.Priority{
+				Pinned:   settings.Pinned,
+				Weight:   settings.Weight,
+				MinShare: settings.MinShare,
+			},
+		}
+		key := statsKey{slotID: slotID, bannerID: id, userGroupID: userGroupID}
+		switch {
+		case decay.HalfLife > 0:
+			if dc, ok := s.decayed[key]; ok {
+				factor := decay.Factor(now.Sub(dc.at))
+				bnr.Impressions = dc.impressions * factor
+				bnr.Clicks = dc.clicks * factor
+			}
+		case decay.Window == 0:
+			if st, ok := s.stats[key]; ok {
+				bnr.Impressions = float64(st.Impressions)
+				bnr.Clicks = float64(st.Clicks)
+			}
+		}
+		counts[id] = bnr
+		banners = append(banners, bnr)
+	}
+
+	if decay.Window > 0 {
+		from := now.Add(-decay.Window)
+		for _, i := range s.impressions {
+			if bnr, ok := counts[i.BannerID]; ok && i.SlotID == slotID && i.UserGroupID == userGroupID &&
+				!i.CreatedAt.Before(from) {
+				bnr.Impressions++
+			}
+		}
+		for _, c := range s.clicks {
+			if bnr, ok := counts[c.BannerID]; ok && c.SlotID == slotID && c.UserGroupID == userGroupID &&
+				!c.CreatedAt.Before(from) {
+				bnr.Clicks++
+			}
+		}
+	}
+
+	return banners
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlotBannersHalfLife(t *testing.T) {
+	ctx := context.Background()
+	selector := multiarmedbandit.NewSelector(nil)
+	selector.SetSlotDecay(1, multiarmedbandit.Decay{HalfLife: time.Hour})
+	s := New(selector)
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{}))
+
+	for i := 0; i < 4; i++ {
+		_, err := s.ImpressBanner(ctx, 1, 1, 1)
+		require.NoError(t, err)
+	}
+	_, err := s.ClickBanner(ctx, clickAttempt(1, 1, 1, ""), storage.ClickGuard{})
+	require.NoError(t, err)
+
+	// Спустя период полураспада счетчики уменьшаются вдвое, а общий счетчик не меняется
+	s.mu.Lock()
+	s.decayed[statsKey{slotID: 1, bannerID: 1, userGroupID: 1}].at = time.Now().Add(-time.Hour)
+	s.mu.Unlock()
+
+	s.mu.RLock()
+	banners := s.slotBanners(1, 1)
+	s.mu.RUnlock()
+	require.Len(t, banners, 1)
+	require.InDelta(t, 2, banners[0].GetImpressions(), 0.01)
+	require.InDelta(t, 0.5, banners[0].GetClicks(), 0.01)
+	require.Equal(t, 4, s.stats[statsKey{slotID: 1, bannerID: 1, userGroupID: 1}].Impressions)
+
+	// Новое событие добавляется к уже затухшему значению
+	_, err = s.ImpressBanner(ctx, 1, 1, 1)
+	require.NoError(t, err)
+	s.mu.RLock()
+	banners = s.slotBanners(1, 1)
+	s.mu.RUnlock()
+	require.InDelta(t, 3, banners[0].GetImpressions(), 0.01)
+}
+
+func TestSlotBannersWindow(t *testing.T) {
+	ctx := context.Background()
+	selector := multiarmedbandit.NewSelector(nil)
+	selector.SetSlotDecay(1, multiarmedbandit.Decay{Window: time.Hour})
+	s := New(selector)
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{}))
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{}))
+
+	// Баннер 1 был популярен давно, баннер 2 - сейчас
+	for i := 0; i < 10; i++ {
+		_, err := s.ImpressBanner(ctx, 1, 1, 1)
+		require.NoError(t, err)
+		_, err = s.ClickBanner(ctx, clickAttempt(1, 1, 1, ""), storage.ClickGuard{})
+		require.NoError(t, err)
+	}
+	s.mu.Lock()
+	for i := range s.impressions {
+		s.impressions[i].CreatedAt = s.impressions[i].CreatedAt.Add(-2 * time.Hour)
+	}
+	for i := range s.clicks {
+		s.clicks[i].CreatedAt = s.clicks[i].CreatedAt.Add(-2 * time.Hour)
+	}
+	s.mu.Unlock()
+
+	_, err := s.ImpressBanner(ctx, 2, 1, 1)
+	require.NoError(t, err)
+	_, err = s.ClickBanner(ctx, clickAttempt(2, 1, 1, ""), storage.ClickGuard{})
+	require.NoError(t, err)
+	// Событие другой группы не учитывается
+	_, err = s.ImpressBanner(ctx, 2, 1, 2)
+	require.NoError(t, err)
+
+	s.mu.RLock()
+	banners := s.slotBanners(1, 1)
+	s.mu.RUnlock()
+	require.Len(t, banners, 2)
+	require.Zero(t, banners[0].GetImpressions())
+	require.Zero(t, banners[0].GetClicks())
+	require.Equal(t, 1.0, banners[1].GetImpressions())
+	require.Equal(t, 1.0, banners[1].GetClicks())
+}
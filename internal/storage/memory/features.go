@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+// SetUserGroupFeatures заменяет признаки группы. Если группы нет, возвращается storage.ErrNotFound.
+func (s *Storage) SetUserGroupFeatures(ctx context.Context, userGroupID int, features storage.Features) error {
+	_ = ctx
+	if err := features.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.userGroups.get(userGroupID); err != nil {
+		return err
+	}
+	stored := make(storage.Features, len(features))
+	for name, value := range features {
+		stored[name] = value
+	}
+	s.features[userGroupID] = stored
+	return nil
+}
+
+// UserGroupFeatures возвращает признаки группы. Если группы нет, возвращается storage.ErrNotFound.
+func (s *Storage) UserGroupFeatures(ctx context.Context, userGroupID int) (storage.Features, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, err := s.userGroups.get(userGroupID); err != nil {
+		return nil, err
+	}
+	features := make(storage.Features, len(s.features[userGroupID]))
+	for name, value := range s.features[userGroupID] {
+		features[name] = value
+	}
+	return features, nil
+}
+
+// attachContext добавляет баннерам признаки группы и статистику слота по всем группам,
+// если стратегия слота контекстная. Вызывается под блокировкой.
+func (s *Storage) attachContext(slotID, userGroupID int, banners []multiarmedbandit.Banner) {
+	if !s.strategies.NeedsContext(slotID) || len(banners) == 0 {
+		return
+	}
+
+	features := make(map[int]map[string]float64, len(s.features))
+	for groupID, groupFeatures := range s.features {
+		features[groupID] = groupFeatures
+	}
+	multiarmedbandit.AttachContext(banners, userGroupID, features, s.groupStats(slotID, time.Now()))
+}
+
+// groupStats возвращает счетчики баннеров слота в разрезе групп с учетом забывания статистики слота.
+// Вызывается под блокировкой.
+func (s *Storage) groupStats(slotID int, now time.Time) map[int][]multiarmedbandit.GroupStats {
+	decay := s.strategies.DecayForSlot(slotID)
+	counts := make(map[statsKey]*multiarmedbandit.GroupStats)
+	countFor := func(key statsKey) *multiarmedbandit.GroupStats {
+		st, ok := counts[key]
+		if !ok {
+			st = &multiarmedbandit.GroupStats{UserGroupID: key.userGroupID}
+			counts[key] = st
+		}
+		return st
+	}
+
+	switch {
+	case decay.HalfLife > 0:
+		for key, dc := range s.decayed {
+			if key.slotID == slotID {
+				factor := decay.Factor(now.Sub(dc.at))
+				st := countFor(key)
+				st.Impressions = dc.impressions * factor
+				st.Clicks = dc.clicks * factor
+			}
+		}
+	case decay.Window > 0:
+		from := now.Add(-decay.Window)
+		for _, i := range s.impressions {
+			if i.SlotID == slotID && !i.CreatedAt.Before(from) {
+				countFor(statsKey{slotID: slotID, bannerID: i.BannerID, userGroupID: i.UserGroupID}).Impressions++
+			}
+		}
+		for _, c := range s.clicks {
+			if c.SlotID == slotID && !c.CreatedAt.Before(from) {
+				countFor(statsKey{slotID: slotID, bannerID: c.BannerID, userGroupID: c.UserGroupID}).Clicks++
+			}
+		}
+	default:
+		for key, bs := range s.stats {
+			if key.slotID == slotID {
+				st := countFor(key)
+				st.Impressions = float64(bs.Impressions)
+				st.Clicks = float64(bs.Clicks)
+			}
+		}
+	}
+
+	stats := make(map[int][]multiarmedbandit.GroupStats)
+	for key, st := range counts {
+		stats[key.bannerID] = append(stats[key.bannerID], *st)
+	}
+	return stats
+}
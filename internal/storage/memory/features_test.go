@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserGroupFeatures(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	require.NoError(t, s.Migrate(ctx, ""))
+
+	features, err := s.UserGroupFeatures(ctx, 1)
+	require.NoError(t, err)
+	require.Empty(t, features)
+
+	require.NoError(t, s.SetUserGroupFeatures(ctx, 1, storage.Features{"age_18_24": 1, "gender_female": 1}))
+	require.NoError(t, s.SetUserGroupFeatures(ctx, 1, storage.Features{"interest_sport": 0.5}))
+	features, err = s.UserGroupFeatures(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, storage.Features{"interest_sport": 0.5}, features)
+
+	require.ErrorIs(t, s.SetUserGroupFeatures(ctx, 1, storage.Features{"": 1}), storage.ErrInvalidFeatures)
+	require.ErrorIs(t, s.SetUserGroupFeatures(ctx, 100, storage.Features{"age_18_24": 1}), storage.ErrNotFound)
+	_, err = s.UserGroupFeatures(ctx, 100)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	// Признаки удаляются вместе с группой
+	require.NoError(t, s.DeleteUserGroup(ctx, 1))
+	require.NotContains(t, s.features, 1)
+}
+
+func TestPickBannerLinUCB(t *testing.T) {
+	ctx := context.Background()
+	selector := multiarmedbandit.NewSelector(multiarmedbandit.NewLinUCB(1))
+	s := New(selector)
+	for i := 0; i < 4; i++ {
+		_, err := s.CreateUserGroup(ctx, "group")
+		require.NoError(t, err)
+	}
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{}))
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{}))
+
+	young := storage.Features{"age_18_24": 1}
+	senior := storage.Features{"age_55_plus": 1}
+	require.NoError(t, s.SetUserGroupFeatures(ctx, 1, young))
+	require.NoError(t, s.SetUserGroupFeatures(ctx, 2, senior))
+	require.NoError(t, s.SetUserGroupFeatures(ctx, 3, young))
+	require.NoError(t, s.SetUserGroupFeatures(ctx, 4, senior))
+
+	// Группа 1 кликает по баннеру 2, группа 2 - по баннеру 1
+	for i := 0; i < 200; i++ {
+		for _, bannerID := range []int{1, 2} {
+			for _, userGroupID := range []int{1, 2} {
+				_, err := s.ImpressBanner(ctx, bannerID, 1, userGroupID)
+				require.NoError(t, err)
+			}
+		}
+		if i%4 == 0 {
+			_, err := s.ClickBanner(ctx, clickAttempt(2, 1, 1, ""), storage.ClickGuard{})
+			require.NoError(t, err)
+			_, err = s.ClickBanner(ctx, clickAttempt(1, 1, 2, ""), storage.ClickGuard{})
+			require.NoError(t, err)
+		}
+	}
+
+	// Группы 3 и 4 еще без показов, но получают баннер, который нравится похожей группе
+	for i := 0; i < 5; i++ {
+		bannerID, err := s.PickBanner(ctx, 1, 3, nil)
+		require.NoError(t, err)
+		require.Equal(t, 2, bannerID)
+		bannerID, err = s.PickBanner(ctx, 1, 4, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, bannerID)
+	}
+}
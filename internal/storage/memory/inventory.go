@@ -0,0 +1,298 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+// record - общая структура записей справочников (слоты, баннеры, группы).
+type record struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+}
+
+// registry - справочник с автоинкрементным ID. Доступ к нему защищен мьютексом Storage.
+type registry struct {
+	records map[int]record
+	lastID  int
+}
+
+func newRegistry() *registry {
+	return &registry{records: make(map[int]record)}
+}
+
+func (r *registry) create(name string) record {
+	r.lastID++
+	rec := record{ID: r.lastID, Name: name, CreatedAt: time.Now()}
+	r.records[rec.ID] = rec
+	return rec
+}
+
+func (r *registry) get(id int) (record, error) {
+	rec, ok := r.records[id]
+	if !ok {
+		return record{}, storage.ErrNotFound
+	}
+	return rec, nil
+}
+
+func (r *registry) list() []record {
+	list := make([]record, 0, len(r.records))
+	for _, rec := range r.records {
+		list = append(list, rec)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func (r *registry) update(id int, name string) (record, error) {
+	rec, ok := r.records[id]
+	if !ok {
+		return record{}, storage.ErrNotFound
+	}
+	rec.Name = name
+	r.records[id] = rec
+	return rec, nil
+}
+
+func (r *registry) delete(id int) error {
+	if _, ok := r.records[id]; !ok {
+		return storage.ErrNotFound
+	}
+	delete(r.records, id)
+	return nil
+}
+
+// seed заполняет пустой справочник записями с именами по шаблону.
+func (r *registry) seed(nameFormat string, count int) {
+	if len(r.records) > 0 {
+		return
+	}
+	for i := 1; i <= count; i++ {
+		r.create(fmt.Sprintf(nameFormat, i))
+	}
+}
+
+func (s *Storage) CreateSlot(ctx context.Context, name string) (*storage.Slot, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	slot := storage.Slot(s.slots.create(name))
+	return &slot, nil
+}
+
+func (s *Storage) GetSlot(ctx context.Context, slotID int) (*storage.Slot, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rec, err := s.slots.get(slotID)
+	if err != nil {
+		return nil, err
+	}
+	slot := storage.Slot(rec)
+	return &slot, nil
+}
+
+func (s *Storage) ListSlots(ctx context.Context) ([]storage.Slot, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := s.slots.list()
+	slots := make([]storage.Slot, 0, len(records))
+	for _, rec := range records {
+		slots = append(slots, storage.Slot(rec))
+	}
+	return slots, nil
+}
+
+func (s *Storage) UpdateSlot(ctx context.Context, slotID int, name string) (*storage.Slot, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, err := s.slots.update(slotID, name)
+	if err != nil {
+		return nil, err
+	}
+	slot := storage.Slot(rec)
+	return &slot, nil
+}
+
+func (s *Storage) DeleteSlot(ctx context.Context, slotID int) error {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.slots.delete(slotID); err != nil {
+		return err
+	}
+	s.cascade(func(slot, _, _ int) bool { return slot == slotID })
+	return nil
+}
+
+func (s *Storage) CreateBanner(ctx context.Context, name string) (*storage.Banner, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	banner := storage.Banner(s.banners.create(name))
+	return &banner, nil
+}
+
+func (s *Storage) GetBanner(ctx context.Context, bannerID int) (*storage.Banner, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rec, err := s.banners.get(bannerID)
+	if err != nil {
+		return nil, err
+	}
+	banner := storage.Banner(rec)
+	return &banner, nil
+}
+
+func (s *Storage) ListBanners(ctx context.Context) ([]storage.Banner, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := s.banners.list()
+	banners := make([]storage.Banner, 0, len(records))
+	for _, rec := range records {
+		banners = append(banners, storage.Banner(rec))
+	}
+	return banners, nil
+}
+
+func (s *Storage) UpdateBanner(ctx context.Context, bannerID int, name string) (*storage.Banner, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, err := s.banners.update(bannerID, name)
+	if err != nil {
+		return nil, err
+	}
+	banner := storage.Banner(rec)
+	return &banner, nil
+}
+
+func (s *Storage) DeleteBanner(ctx context.Context, bannerID int) error {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.banners.delete(bannerID); err != nil {
+		return err
+	}
+	s.cascade(func(_, banner, _ int) bool { return banner == bannerID })
+	return nil
+}
+
+func (s *Storage) CreateUserGroup(ctx context.Context, name string) (*storage.UserGroup, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group := storage.UserGroup(s.userGroups.create(name))
+	return &group, nil
+}
+
+func (s *Storage) GetUserGroup(ctx context.Context, userGroupID int) (*storage.UserGroup, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rec, err := s.userGroups.get(userGroupID)
+	if err != nil {
+		return nil, err
+	}
+	group := storage.UserGroup(rec)
+	return &group, nil
+}
+
+func (s *Storage) ListUserGroups(ctx context.Context) ([]storage.UserGroup, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := s.userGroups.list()
+	groups := make([]storage.UserGroup, 0, len(records))
+	for _, rec := range records {
+		groups = append(groups, storage.UserGroup(rec))
+	}
+	return groups, nil
+}
+
+func (s *Storage) UpdateUserGroup(ctx context.Context, userGroupID int, name string) (*storage.UserGroup, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, err := s.userGroups.update(userGroupID, name)
+	if err != nil {
+		return nil, err
+	}
+	group := storage.UserGroup(rec)
+	return &group, nil
+}
+
+func (s *Storage) DeleteUserGroup(ctx context.Context, userGroupID int) error {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.userGroups.delete(userGroupID); err != nil {
+		return err
+	}
+	s.cascade(func(_, _, group int) bool { return group == userGroupID })
+	delete(s.features, userGroupID)
+	return nil
+}
+
+// cascade удаляет ротации, события и статистику, подходящие под условие,
+// повторяя каскадное удаление внешних ключей в SQL. Вызывается под блокировкой.
+// Для ротаций группа не определена и передается как 0.
+func (s *Storage) cascade(match func(slotID, bannerID, userGroupID int) bool) {
+	for r := range s.rotations {
+		if match(r.slotID, r.bannerID, 0) {
+			delete(s.rotations, r)
+		}
+	}
+	for k := range s.stats {
+		if match(k.slotID, k.bannerID, k.userGroupID) {
+			delete(s.stats, k)
+			delete(s.decayed, k)
+		}
+	}
+
+	impressions := s.impressions[:0]
+	for _, i := range s.impressions {
+		if !match(i.SlotID, i.BannerID, i.UserGroupID) {
+			impressions = append(impressions, i)
+		}
+	}
+	s.impressions = impressions
+	for token, i := range s.impressionTokens {
+		if match(i.SlotID, i.BannerID, i.UserGroupID) {
+			delete(s.impressionTokens, token)
+		}
+	}
+
+	clicks := s.clicks[:0]
+	for _, c := range s.clicks {
+		if !match(c.SlotID, c.BannerID, c.UserGroupID) {
+			clicks = append(clicks, c)
+		}
+	}
+	s.clicks = clicks
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlotsCRUD(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+
+	slot, err := s.CreateSlot(ctx, "Main page")
+	require.NoError(t, err)
+	require.Equal(t, 1, slot.ID)
+	require.True(t, s.SlotExists(ctx, slot.ID))
+
+	slot, err = s.UpdateSlot(ctx, slot.ID, "Sidebar")
+	require.NoError(t, err)
+	require.Equal(t, "Sidebar", slot.Name)
+
+	got, err := s.GetSlot(ctx, slot.ID)
+	require.NoError(t, err)
+	require.Equal(t, *slot, *got)
+
+	slots, err := s.ListSlots(ctx)
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+
+	require.NoError(t, s.DeleteSlot(ctx, slot.ID))
+	_, err = s.GetSlot(ctx, slot.ID)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	require.ErrorIs(t, s.DeleteSlot(ctx, slot.ID), storage.ErrNotFound)
+}
+
+func TestDeleteBannerCascades(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	require.NoError(t, s.Migrate(ctx, ""))
+
+	bannerID := pickAndShow(t, s, 1, 1)
+	_, err := s.ClickBanner(ctx, clickAttempt(bannerID, 1, 1, ""), storage.ClickGuard{})
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteBanner(ctx, bannerID))
+
+	assigned, err := s.IsBannerAssignedToSlot(ctx, bannerID, 1)
+	require.NoError(t, err)
+	require.False(t, assigned)
+	require.Empty(t, s.impressions)
+	require.Empty(t, s.clicks)
+	require.Empty(t, s.stats)
+
+	groups, err := s.ListUserGroups(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 5)
+	require.Equal(t, "Groups 1", groups[0].Name)
+}
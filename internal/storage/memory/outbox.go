@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+type outboxEntry struct {
+	message     storage.OutboxMessage
+	lockedUntil time.Time
+}
+
+// addOutbox сохраняет уведомление в outbox. Вызывается под блокировкой.
+func (s *Storage) addOutbox(notification storage.Notification) error {
+	payload, err := notification.Marshal()
+	if err != nil {
+		return err
+	}
+
+	s.lastOutboxID++
+	s.outbox = append(s.outbox, &outboxEntry{
+		message: storage.OutboxMessage{ID: s.lastOutboxID, Payload: payload, CreatedAt: time.Now()},
+	})
+	return nil
+}
+
+// addEventOutbox сохраняет уведомление о событии и об исчерпанных им ограничениях.
+// Вызывается под блокировкой.
+func (s *Storage) addEventOutbox(event storage.Notification, caps []string) error {
+	if err := s.addOutbox(event); err != nil {
+		return err
+	}
+	for _, n := range storage.NewCapNotifications(event, caps) {
+		if err := s.addOutbox(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Storage) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]storage.OutboxMessage, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	messages := make([]storage.OutboxMessage, 0)
+	for _, entry := range s.outbox {
+		if len(messages) == limit {
+			break
+		}
+		if entry.lockedUntil.After(now) {
+			continue
+		}
+		entry.lockedUntil = now.Add(lease)
+		messages = append(messages, entry.message)
+	}
+	return messages, nil
+}
+
+func (s *Storage) MarkOutboxSent(ctx context.Context, ids []int64) error {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sent := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		sent[id] = struct{}{}
+	}
+
+	// Отправленные записи больше не нужны: в памяти их не храним
+	pending := s.outbox[:0]
+	for _, entry := range s.outbox {
+		if _, ok := sent[entry.message.ID]; !ok {
+			pending = append(pending, entry)
+		}
+	}
+	s.outbox = pending
+	return nil
+}
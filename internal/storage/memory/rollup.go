@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+type rollupKey struct {
+	hour        time.Time
+	slotID      int
+	bannerID    int
+	userGroupID int
+}
+
+func (s *Storage) AddHourlyRollup(
+	ctx context.Context,
+	messageID string,
+	notification storage.Notification,
+) (bool, error) {
+	_ = ctx
+	impressions, clicks, err := notification.Counters()
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if messageID != "" {
+		if _, ok := s.rollupMessages[messageID]; ok {
+			return false, nil
+		}
+		s.rollupMessages[messageID] = time.Now()
+	}
+
+	key := rollupKey{
+		hour:        storage.RollupHour(notification.DateTime),
+		slotID:      notification.SlotID,
+		bannerID:    notification.BannerID,
+		userGroupID: notification.UsergroupID,
+	}
+	rollup, ok := s.rollups[key]
+	if !ok {
+		rollup = &storage.HourlyRollup{
+			Hour:        key.hour,
+			SlotID:      key.slotID,
+			BannerID:    key.bannerID,
+			UserGroupID: key.userGroupID,
+		}
+		s.rollups[key] = rollup
+	}
+	rollup.Impressions += impressions
+	rollup.Clicks += clicks
+
+	return true, nil
+}
+
+func (s *Storage) PurgeRollupMessages(ctx context.Context, before time.Time) (int64, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var purged int64
+	for messageID, processedAt := range s.rollupMessages {
+		if processedAt.Before(before) {
+			delete(s.rollupMessages, messageID)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// HourlyRollups возвращает почасовые агрегаты. Нулевой SlotID означает все слоты.
+func (s *Storage) HourlyRollups(ctx context.Context, filter storage.StatsFilter) ([]storage.HourlyRollup, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rollups := make([]storage.HourlyRollup, 0)
+	for key, rollup := range s.rollups {
+		if filter.SlotID != 0 && key.slotID != filter.SlotID {
+			continue
+		}
+		if filter.UserGroupID != 0 && key.userGroupID != filter.UserGroupID {
+			continue
+		}
+		if !filter.InWindow(key.hour) {
+			continue
+		}
+		rollups = append(rollups, *rollup)
+	}
+
+	sort.Slice(rollups, func(i, j int) bool {
+		a, b := rollups[i], rollups[j]
+		if !a.Hour.Equal(b.Hour) {
+			return a.Hour.Before(b.Hour)
+		}
+		if a.SlotID != b.SlotID {
+			return a.SlotID < b.SlotID
+		}
+		if a.BannerID != b.BannerID {
+			return a.BannerID < b.BannerID
+		}
+		return a.UserGroupID < b.UserGroupID
+	})
+	return rollups, nil
+}
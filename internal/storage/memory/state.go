@@ -0,0 +1,278 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+// put сохраняет запись с заданным ID; автоинкремент продолжается после него.
+func (r *registry) put(rec record) {
+	r.records[rec.ID] = rec
+	if rec.ID > r.lastID {
+		r.lastID = rec.ID
+	}
+}
+
+// ExportState возвращает снимок справочников, ротаций и счетчиков.
+func (s *Storage) ExportState(ctx context.Context) (*storage.State, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state := &storage.State{}
+	for _, rec := range s.slots.list() {
+		state.Slots = append(state.Slots, storage.Slot(rec))
+	}
+	for _, rec := range s.banners.list() {
+		state.Banners = append(state.Banners, storage.Banner(rec))
+	}
+	for _, rec := range s.userGroups.list() {
+		group := storage.UserGroupState{UserGroup: storage.UserGroup(rec)}
+		if features := s.features[rec.ID]; len(features) > 0 {
+			group.Features = make(storage.Features, len(features))
+			for name, value := range features {
+				group.Features[name] = value
+			}
+		}
+		state.UserGroups = append(state.UserGroups, group)
+	}
+
+	state.Rotations = make([]storage.RotationState, 0, len(s.rotations))
+	for key, r := range s.rotations {
+		state.Rotations = append(state.Rotations, storage.RotationState{
+			SlotID:    key.slotID,
+			BannerID:  key.bannerID,
+			Settings:  r.settings,
+			Paused:    r.paused,
+			Served:    r.served,
+			CreatedAt: r.createdAt,
+		})
+	}
+	sort.Slice(state.Rotations, func(i, j int) bool {
+		a, b := state.Rotations[i], state.Rotations[j]
+		return a.SlotID < b.SlotID || a.SlotID == b.SlotID && a.BannerID < b.BannerID
+	})
+
+	state.Stats = make([]storage.StatsState, 0, len(s.stats))
+	for key, st := range s.stats {
+		stats := storage.StatsState{
+			SlotID:      key.slotID,
+			BannerID:    key.bannerID,
+			UserGroupID: key.userGroupID,
+			Impressions: int64(st.Impressions),
+			Clicks:      int64(st.Clicks),
+		}
+		if dc, ok := s.decayed[key]; ok {
+			stats.DecayedImpressions, stats.DecayedClicks, stats.DecayedAt = dc.impressions, dc.clicks, dc.at
+		}
+		state.Stats = append(state.Stats, stats)
+	}
+	sort.Slice(state.Stats, func(i, j int) bool {
+		a, b := state.Stats[i], state.Stats[j]
+		if a.SlotID != b.SlotID {
+			return a.SlotID < b.SlotID
+		}
+		if a.BannerID != b.BannerID {
+			return a.BannerID < b.BannerID
+		}
+		return a.UserGroupID < b.UserGroupID
+	})
+
+	return state, nil
+}
+
+// ImportState загружает снимок с сохранением ID. Записи, которые уже есть в хранилище,
+// обрабатываются по opts.OnConflict. Снимок сначала целиком проверяется и только потом
+// записывается, поэтому ошибка не оставляет хранилище частично измененным.
+func (s *Storage) ImportState(
+	ctx context.Context,
+	state *storage.State,
+	opts storage.ImportOptions,
+) (*storage.ImportResult, error) {
+	_ = ctx
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := state.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.importState(state, opts, false)
+	if err != nil || opts.DryRun {
+		return result, err
+	}
+	return s.importState(state, opts, true)
+}
+
+// importState проходит по записям снимка; с write=false только проверяет и считает их.
+// Вызывается под блокировкой.
+func (s *Storage) importState(
+	state *storage.State,
+	opts storage.ImportOptions,
+	write bool,
+) (*storage.ImportResult, error) {
+	result := &storage.ImportResult{}
+	now := time.Now()
+
+	// Записи справочников из снимка доступны ротациям и статистике и до записи
+	slots := make(map[int]struct{}, len(state.Slots))
+	for _, slot := range state.Slots {
+		slots[slot.ID] = struct{}{}
+		if _, err := importRecord(s.slots, opts, &result.Slots, write, "slot", record(slot), now); err != nil {
+			return nil, err
+		}
+	}
+	banners := make(map[int]struct{}, len(state.Banners))
+	for _, banner := range state.Banners {
+		banners[banner.ID] = struct{}{}
+		if _, err := importRecord(s.banners, opts, &result.Banners, write, "banner", record(banner), now); err != nil {
+			return nil, err
+		}
+	}
+	groups := make(map[int]struct{}, len(state.UserGroups))
+	for _, group := range state.UserGroups {
+		groups[group.ID] = struct{}{}
+		written, err := importRecord(s.userGroups, opts, &result.UserGroups, write, "usergroup",
+			record(group.UserGroup), now)
+		if err != nil {
+			return nil, err
+		}
+		if write && written {
+			s.setFeatures(group.ID, group.Features)
+		}
+	}
+	exists := func(reg *registry, imported map[int]struct{}, id int) bool {
+		_, ok := imported[id]
+		_, err := reg.get(id)
+		return ok || err == nil
+	}
+
+	shares := make(map[int]float64)
+	for key, r := range s.rotations {
+		shares[key.slotID] += r.settings.MinShare
+	}
+	for _, r := range state.Rotations {
+		name := fmt.Sprintf("rotation %d/%d", r.SlotID, r.BannerID)
+		if !exists(s.slots, slots, r.SlotID) || !exists(s.banners, banners, r.BannerID) {
+			return nil, fmt.Errorf("%w: %s references a missing slot or banner", storage.ErrInvalidState, name)
+		}
+		key := rotationKey{slotID: r.SlotID, bannerID: r.BannerID}
+		existing, ok := s.rotations[key]
+		overwrite := false
+		if ok {
+			var err error
+			if overwrite, err = opts.Resolve(name); err != nil {
+				return nil, err
+			}
+		}
+		result.Rotations.Count(!ok, overwrite)
+		if ok && !overwrite {
+			continue
+		}
+
+		createdAt := r.CreatedAt
+		if ok {
+			shares[r.SlotID] -= existing.settings.MinShare
+			if createdAt.IsZero() {
+				createdAt = existing.createdAt
+			}
+		}
+		if createdAt.IsZero() {
+			createdAt = now
+		}
+		shares[r.SlotID] += r.Settings.MinShare
+		if shares[r.SlotID] > 1+storage.ShareTolerance {
+			return nil, fmt.Errorf("%w: slot %d", storage.ErrShareExceeded, r.SlotID)
+		}
+		if write {
+			s.rotations[key] = &rotation{settings: r.Settings, served: r.Served, paused: r.Paused, createdAt: createdAt}
+		}
+	}
+
+	for _, st := range state.Stats {
+		name := fmt.Sprintf("stats %d/%d/%d", st.SlotID, st.BannerID, st.UserGroupID)
+		if !exists(s.slots, slots, st.SlotID) || !exists(s.banners, banners, st.BannerID) ||
+			!exists(s.userGroups, groups, st.UserGroupID) {
+			return nil, fmt.Errorf("%w: %s references a missing slot, banner or usergroup", storage.ErrInvalidState, name)
+		}
+		key := statsKey{slotID: st.SlotID, bannerID: st.BannerID, userGroupID: st.UserGroupID}
+		_, ok := s.stats[key]
+		overwrite := false
+		if ok {
+			var err error
+			if overwrite, err = opts.Resolve(name); err != nil {
+				return nil, err
+			}
+		}
+		result.Stats.Count(!ok, overwrite)
+		if write && (!ok || overwrite) {
+			at := st.DecayedAt
+			if at.IsZero() {
+				at = now
+			}
+			s.stats[key] = &storage.BannerStatistics{
+				BannerID:    st.BannerID,
+				Impressions: int(st.Impressions),
+				Clicks:      int(st.Clicks),
+			}
+			s.decayed[key] = &decayedCounts{impressions: st.DecayedImpressions, clicks: st.DecayedClicks, at: at}
+		}
+	}
+
+	return result, nil
+}
+
+// importRecord импортирует запись справочника reg; true - запись создана или перезаписана.
+// Без времени создания в снимке сохраняется прежнее или подставляется now. Вызывается под блокировкой.
+func importRecord(
+	reg *registry,
+	opts storage.ImportOptions,
+	counts *storage.ImportCounts,
+	write bool,
+	kind string,
+	rec record,
+	now time.Time,
+) (bool, error) {
+	existing, err := reg.get(rec.ID)
+	created, overwrite := err != nil, false
+	if !created {
+		if overwrite, err = opts.Resolve(fmt.Sprintf("%s %d", kind, rec.ID)); err != nil {
+			return false, err
+		}
+	}
+	counts.Count(created, overwrite)
+	if !created && !overwrite {
+		return false, nil
+	}
+
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = now
+		if !created {
+			rec.CreatedAt = existing.CreatedAt
+		}
+	}
+	if write {
+		reg.put(rec)
+	}
+	return true, nil
+}
+
+// setFeatures заменяет признаки группы копией features. Вызывается под блокировкой.
+func (s *Storage) setFeatures(userGroupID int, features storage.Features) {
+	if len(features) == 0 {
+		delete(s.features, userGroupID)
+		return
+	}
+	stored := make(storage.Features, len(features))
+	for name, value := range features {
+		stored[name] = value
+	}
+	s.features[userGroupID] = stored
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportState(t *testing.T) {
+	ctx := context.Background()
+	src := New(nil)
+	require.NoError(t, src.Migrate(ctx, ""))
+	require.NoError(t, src.SetUserGroupFeatures(ctx, 2, storage.Features{"age": 1}))
+	require.NoError(t, src.AddBanner(ctx, 7, 1, storage.RotationSettings{MinShare: 0.3}))
+	_, err := src.ImpressBanner(ctx, 7, 1, 2)
+	require.NoError(t, err)
+
+	state, err := src.ExportState(ctx)
+	require.NoError(t, err)
+	require.Len(t, state.Slots, 3)
+	require.Len(t, state.Rotations, 7)
+	require.Len(t, state.Stats, 1)
+	require.Equal(t, storage.Features{"age": 1}, state.UserGroups[1].Features)
+
+	// Пустое хранилище получает те же записи с теми же ID
+	dst := New(nil)
+	result, err := dst.ImportState(ctx, state, storage.ImportOptions{})
+	require.NoError(t, err)
+	require.Equal(t, storage.ImportCounts{Created: 7}, result.Rotations)
+	require.Equal(t, storage.ImportCounts{Created: 1}, result.Stats)
+
+	exported, err := dst.ExportState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, state, exported)
+
+	// Автоинкремент продолжается после импортированных ID
+	slot, err := dst.CreateSlot(ctx, "New")
+	require.NoError(t, err)
+	require.Equal(t, 4, slot.ID)
+}
+
+func TestImportStateConflicts(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	slot, err := s.CreateSlot(ctx, "Main page")
+	require.NoError(t, err)
+
+	state := &storage.State{
+		Slots:   []storage.Slot{{ID: slot.ID, Name: "Landing"}, {ID: 5, Name: "Footer"}},
+		Banners: []storage.Banner{{ID: 1, Name: "Sale"}},
+	}
+
+	// fail - хранилище не меняется
+	_, err = s.ImportState(ctx, state, storage.ImportOptions{OnConflict: storage.ConflictFail})
+	require.ErrorIs(t, err, storage.ErrImportConflict)
+	require.False(t, s.SlotExists(ctx, 5))
+
+	// Пробный импорт только считает изменения
+	result, err := s.ImportState(ctx, state, storage.ImportOptions{OnConflict: storage.ConflictOverwrite, DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, storage.ImportCounts{Created: 1, Updated: 1}, result.Slots)
+	require.False(t, s.SlotExists(ctx, 5))
+
+	result, err = s.ImportState(ctx, state, storage.ImportOptions{OnConflict: storage.ConflictSkip})
+	require.NoError(t, err)
+	require.Equal(t, storage.ImportCounts{Created: 1, Skipped: 1}, result.Slots)
+	got, err := s.GetSlot(ctx, slot.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Main page", got.Name)
+	require.Equal(t, slot.CreatedAt, got.CreatedAt)
+
+	result, err = s.ImportState(ctx, state, storage.ImportOptions{OnConflict: storage.ConflictOverwrite})
+	require.NoError(t, err)
+	require.Equal(t, storage.ImportCounts{Updated: 2}, result.Slots)
+	got, err = s.GetSlot(ctx, slot.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Landing", got.Name)
+
+	_, err = s.ImportState(ctx, state, storage.ImportOptions{OnConflict: "merge"})
+	require.ErrorIs(t, err, storage.ErrInvalidOnConflict)
+}
+
+func TestImportStateInvalid(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	_, err := s.CreateSlot(ctx, "Main page")
+	require.NoError(t, err)
+	_, err = s.CreateBanner(ctx, "Sale")
+	require.NoError(t, err)
+
+	// Ротация ссылается на баннер, которого нет ни в хранилище, ни в снимке
+	_, err = s.ImportState(ctx, &storage.State{
+		Rotations: []storage.RotationState{{SlotID: 1, BannerID: 2}},
+	}, storage.ImportOptions{})
+	require.ErrorIs(t, err, storage.ErrInvalidState)
+
+	_, err = s.ImportState(ctx, &storage.State{
+		Slots: []storage.Slot{{ID: 2, Name: "Sidebar"}, {ID: 2, Name: "Footer"}},
+	}, storage.ImportOptions{})
+	require.ErrorIs(t, err, storage.ErrInvalidState)
+
+	// Гарантированные доли слота с учетом существующих ротаций превышают 100%
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{MinShare: 0.6}))
+	_, err = s.ImportState(ctx, &storage.State{
+		Banners:   []storage.Banner{{ID: 2, Name: "New"}},
+		Rotations: []storage.RotationState{{SlotID: 1, BannerID: 2, Settings: storage.RotationSettings{MinShare: 0.5}}},
+	}, storage.ImportOptions{})
+	require.ErrorIs(t, err, storage.ErrShareExceeded)
+	require.False(t, s.BannerExists(ctx, 2))
+}
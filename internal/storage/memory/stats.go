@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+func (s *Storage) BannerStats(ctx context.Context, filter storage.StatsFilter) ([]storage.BannerStatistics, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byBanner := make(map[int]*storage.BannerStatistics)
+	for r := range s.rotations {
+		if r.slotID == filter.SlotID {
+			byBanner[r.bannerID] = &storage.BannerStatistics{BannerID: r.bannerID}
+		}
+	}
+
+	matches := func(slotID, bannerID, userGroupID int) (*storage.BannerStatistics, bool) {
+		if slotID != filter.SlotID || (filter.UserGroupID != 0 && userGroupID != filter.UserGroupID) {
+			return nil, false
+		}
+		bnr, ok := byBanner[bannerID]
+		return bnr, ok
+	}
+
+	if filter.HasWindow() {
+		for _, i := range s.impressions {
+			if bnr, ok := matches(i.SlotID, i.BannerID, i.UserGroupID); ok && filter.InWindow(i.CreatedAt) {
+				bnr.Impressions++
+			}
+		}
+		for _, c := range s.clicks {
+			if bnr, ok := matches(c.SlotID, c.BannerID, c.UserGroupID); ok && filter.InWindow(c.CreatedAt) {
+				bnr.Clicks++
+			}
+		}
+	} else {
+		for k, st := range s.stats {
+			if bnr, ok := matches(k.slotID, k.bannerID, k.userGroupID); ok {
+				bnr.Impressions += st.Impressions
+				bnr.Clicks += st.Clicks
+			}
+		}
+	}
+
+	stats := make([]storage.BannerStatistics, 0, len(byBanner))
+	for _, bnr := range byBanner {
+		stats = append(stats, *bnr)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].BannerID < stats[j].BannerID })
+
+	return stats, nil
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBannerStats(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{}))
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{}))
+
+	_, err := s.ImpressBanner(ctx, 1, 1, 1)
+	require.NoError(t, err)
+	_, err = s.ImpressBanner(ctx, 1, 1, 2)
+	require.NoError(t, err)
+	_, err = s.ClickBanner(ctx, clickAttempt(1, 1, 2, ""), storage.ClickGuard{})
+	require.NoError(t, err)
+	// Показ в другом слоте не должен попасть в статистику
+	_, err = s.ImpressBanner(ctx, 1, 2, 1)
+	require.NoError(t, err)
+
+	stats, err := s.BannerStats(ctx, storage.StatsFilter{SlotID: 1})
+	require.NoError(t, err)
+	require.Equal(t, []storage.BannerStatistics{
+		{BannerID: 1, Impressions: 2, Clicks: 1},
+		{BannerID: 2},
+	}, stats)
+
+	stats, err = s.BannerStats(ctx, storage.StatsFilter{SlotID: 1, UserGroupID: 1})
+	require.NoError(t, err)
+	require.Equal(t, storage.BannerStatistics{BannerID: 1, Impressions: 1}, stats[0])
+
+	stats, err = s.BannerStats(ctx, storage.StatsFilter{SlotID: 1, From: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.Equal(t, storage.BannerStatistics{BannerID: 1}, stats[0])
+
+	stats, err = s.BannerStats(ctx, storage.StatsFilter{SlotID: 1, To: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.Equal(t, storage.BannerStatistics{BannerID: 1, Impressions: 2, Clicks: 1}, stats[0])
+}
+
+func TestBannerStatsSharedBanner(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	// Один баннер в двух слотах: статистика ведется отдельно для каждого слота
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{}))
+	require.NoError(t, s.AddBanner(ctx, 1, 2, storage.RotationSettings{}))
+
+	for i := 0; i < 3; i++ {
+		_, err := s.ImpressBanner(ctx, 1, 1, 1)
+		require.NoError(t, err)
+	}
+	_, err := s.ClickBanner(ctx, clickAttempt(1, 1, 1, ""), storage.ClickGuard{})
+	require.NoError(t, err)
+	_, err = s.ImpressBanner(ctx, 1, 2, 1)
+	require.NoError(t, err)
+
+	for _, filter := range []storage.StatsFilter{
+		{SlotID: 1},
+		{SlotID: 1, UserGroupID: 1},
+		{SlotID: 1, To: time.Now().Add(time.Hour)},
+	} {
+		stats, err := s.BannerStats(ctx, filter)
+		require.NoError(t, err)
+		require.Equal(t, []storage.BannerStatistics{{BannerID: 1, Impressions: 3, Clicks: 1}}, stats)
+	}
+	for _, filter := range []storage.StatsFilter{
+		{SlotID: 2},
+		{SlotID: 2, UserGroupID: 1},
+		{SlotID: 2, To: time.Now().Add(time.Hour)},
+	} {
+		stats, err := s.BannerStats(ctx, filter)
+		require.NoError(t, err)
+		require.Equal(t, []storage.BannerStatistics{{BannerID: 1, Impressions: 1}}, stats)
+	}
+
+	// Удаление баннера из одного слота не затрагивает статистику другого
+	require.NoError(t, s.RemoveBanner(ctx, 1, 1))
+	stats, err := s.BannerStats(ctx, storage.StatsFilter{SlotID: 2})
+	require.NoError(t, err)
+	require.Equal(t, []storage.BannerStatistics{{BannerID: 1, Impressions: 1}}, stats)
+}
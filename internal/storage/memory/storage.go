@@ -0,0 +1,449 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/interfaces"
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+var _ interfaces.Storage = (*Storage)(nil)
+
+type rotationKey struct {
+	slotID   int
+	bannerID int
+}
+
+// rotation - участие баннера в ротации слота.
+type rotation struct {
+	settings  storage.RotationSettings
+	served    storage.Served
+	paused    bool
+	createdAt time.Time
+}
+
+// activeAt сообщает, показывается ли баннер в момент now.
+func (r *rotation) activeAt(now time.Time) bool {
+	return !r.paused && r.settings.Flight.Contains(now) && !r.settings.Caps.Reached(r.served, now)
+}
+
+type statsKey struct {
+	slotID      int
+	bannerID    int
+	userGroupID int
+}
+
+// Storage - потокобезопасное хранилище в памяти процесса.
+type Storage struct {
+	mu         sync.RWMutex
+	strategies *multiarmedbandit.Selector
+
+	slots      *registry
+	banners    *registry
+	userGroups *registry
+	rotations  map[rotationKey]*rotation
+	stats      map[statsKey]*storage.BannerStatistics
+	decayed    map[statsKey]*decayedCounts
+	features   map[int]storage.Features
+
+	impressions      []storage.Impress
+	impressionTokens map[string]storage.Impress
+	clicks           []storage.Click
+	lastImpressionID int
+	lastClickID      int
+
+	rejectedClicks      []storage.RejectedClick
+	lastRejectedClickID int
+
+	outbox       []*outboxEntry
+	lastOutboxID int64
+
+	rollups        map[rollupKey]*storage.HourlyRollup
+	rollupMessages map[string]time.Time
+}
+
+func New(strategies *multiarmedbandit.Selector) *Storage {
+	return &Storage{
+		strategies: strategies,
+		slots:      newRegistry(),
+		banners:    newRegistry(),
+		userGroups: newRegistry(),
+		rotations:  make(map[rotationKey]*rotation),
+		stats:      make(map[statsKey]*storage.BannerStatistics),
+		decayed:    make(map[statsKey]*decayedCounts),
+		features:   make(map[int]storage.Features),
+
+		impressionTokens: make(map[string]storage.Impress),
+
+		rollups:        make(map[rollupKey]*storage.HourlyRollup),
+		rollupMessages: make(map[string]time.Time),
+	}
+}
+
+func (s *Storage) Connect(ctx context.Context, dbPort int, dbHost, dbUser, dbPassword, dbName string) error {
+	_, _, _, _, _, _ = ctx, dbPort, dbHost, dbUser, dbPassword, dbName
+	return nil
+}
+
+func (s *Storage) Close(ctx context.Context) error {
+	_ = ctx
+	return nil
+}
+
+// Migrate заполняет пустое хранилище теми же слотами, баннерами, группами
+// и ротациями, что и SQL-миграции.
+func (s *Storage) Migrate(ctx context.Context, migrate string) error {
+	_, _ = ctx, migrate
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.slots.seed("Slot %d", 3)
+	s.banners.seed("Banner %d", 10)
+	s.userGroups.seed("Groups %d", 5)
+	if len(s.rotations) == 0 {
+		for _, r := range []rotationKey{{1, 1}, {2, 2}, {3, 3}, {1, 4}, {2, 5}, {3, 6}} {
+			s.rotations[r] = &rotation{createdAt: now}
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) AddBanner(ctx context.Context, bannerID, slotID int, settings storage.RotationSettings) error {
+	_ = ctx
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	share := settings.MinShare
+	for key, r := range s.rotations {
+		if key.slotID == slotID {
+			share += r.settings.MinShare
+		}
+	}
+	if share > 1+storage.ShareTolerance {
+		return storage.ErrShareExceeded
+	}
+
+	s.rotations[rotationKey{slotID: slotID, bannerID: bannerID}] = &rotation{settings: settings, createdAt: time.Now()}
+	return nil
+}
+
+// UpdateRotation заменяет условия ротации баннера в слоте, сохраняя набранный объем.
+func (s *Storage) UpdateRotation(ctx context.Context, bannerID, slotID int, settings storage.RotationSettings) error {
+	_ = ctx
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := rotationKey{slotID: slotID, bannerID: bannerID}
+	r, ok := s.rotations[key]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	share := settings.MinShare
+	for other, o := range s.rotations {
+		if other.slotID == slotID && other != key {
+			share += o.settings.MinShare
+		}
+	}
+	if share > 1+storage.ShareTolerance {
+		return storage.ErrShareExceeded
+	}
+
+	r.settings = settings
+	return nil
+}
+
+func (s *Storage) SetBannerPaused(ctx context.Context, bannerID, slotID int, paused bool) error {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.rotations[rotationKey{slotID: slotID, bannerID: bannerID}]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	r.paused = paused
+	return nil
+}
+
+func (s *Storage) RemoveBanner(ctx context.Context, bannerID, slotID int) error {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.rotations, rotationKey{slotID: slotID, bannerID: bannerID})
+	return nil
+}
+
+// ClickBanner записывает переход, если он проходит проверки guard. Отклоненный переход
+// сохраняется в журнал, а вызывающему возвращается *storage.RejectedClickError.
+func (s *Storage) ClickBanner(
+	ctx context.Context,
+	attempt storage.ClickAttempt,
+	guard storage.ClickGuard,
+) (*storage.Click, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	reason, impressionID := s.checkClickLocked(attempt, guard, now)
+	if reason != "" {
+		s.lastRejectedClickID++
+		s.rejectedClicks = append(s.rejectedClicks, storage.RejectedClick{
+			ID:           s.lastRejectedClickID,
+			SlotID:       attempt.SlotID,
+			BannerID:     attempt.BannerID,
+			UserGroupID:  attempt.UserGroupID,
+			ImpressionID: impressionID,
+			ClientKey:    attempt.ClientKey,
+			Reason:       reason,
+			CreatedAt:    now,
+		})
+		return nil, &storage.RejectedClickError{Reason: reason}
+	}
+
+	s.lastClickID++
+	click := storage.Click{
+		ID:           s.lastClickID,
+		SlotID:       attempt.SlotID,
+		BannerID:     attempt.BannerID,
+		UserGroupID:  attempt.UserGroupID,
+		CreatedAt:    now,
+		ImpressionID: impressionID,
+		ClientKey:    attempt.ClientKey,
+	}
+	s.clicks = append(s.clicks, click)
+	s.countEvent(attempt.SlotID, attempt.BannerID, attempt.UserGroupID, 0, 1, now)
+	click.CapsReached = s.countServed(attempt.SlotID, attempt.BannerID, 0, 1, now)
+
+	if err := s.addEventOutbox(storage.NewClickNotification(&click), click.CapsReached); err != nil {
+		return nil, err
+	}
+
+	return &click, nil
+}
+
+// checkClickLocked проверяет переход по правилам guard и возвращает причину отклонения
+// (пустую, если переход принят) и показ, к которому он относится. Вызывается под блокировкой.
+func (s *Storage) checkClickLocked(
+	attempt storage.ClickAttempt,
+	guard storage.ClickGuard,
+	now time.Time,
+) (string, int) {
+	var impressionID int
+	if attempt.ImpressionTokenID != "" {
+		impress, ok := s.impressionTokens[attempt.ImpressionTokenID]
+		if !ok || impress.SlotID != attempt.SlotID || impress.BannerID != attempt.BannerID ||
+			impress.UserGroupID != attempt.UserGroupID {
+			return storage.RejectNoImpression, 0
+		}
+		impressionID = impress.ID
+	}
+
+	// Переходы хранятся в порядке записи: просматриваются только попавшие в окна
+	window := guard.ImpressionWindow
+	if guard.ClientWindow > window {
+		window = guard.ClientWindow
+	}
+	for i := len(s.clicks) - 1; i >= 0 && window > 0; i-- {
+		c := s.clicks[i]
+		age := now.Sub(c.CreatedAt)
+		if age >= window {
+			break
+		}
+		if impressionID != 0 && c.ImpressionID == impressionID && age < guard.ImpressionWindow {
+			return storage.RejectDuplicateImpression, impressionID
+		}
+		if attempt.ClientKey != "" && c.ClientKey == attempt.ClientKey &&
+			c.SlotID == attempt.SlotID && c.BannerID == attempt.BannerID && age < guard.ClientWindow {
+			return storage.RejectDuplicateClient, impressionID
+		}
+	}
+
+	// Переход с токеном уже привязан к показу
+	if guard.RequireImpression && attempt.ImpressionTokenID == "" {
+		if !s.shownSince(attempt, now.Add(-guard.ImpressionMaxAge)) {
+			return storage.RejectNoImpression, impressionID
+		}
+	}
+
+	return "", impressionID
+}
+
+// shownSince сообщает, показывался ли баннер перехода в слоте для группы позже since.
+// Показы хранятся в порядке записи, поэтому просмотр идет с конца до первого более старого.
+func (s *Storage) shownSince(attempt storage.ClickAttempt, since time.Time) bool {
+	for i := len(s.impressions) - 1; i >= 0; i-- {
+		impress := s.impressions[i]
+		if !impress.CreatedAt.After(since) {
+			return false
+		}
+		if impress.SlotID == attempt.SlotID && impress.BannerID == attempt.BannerID &&
+			impress.UserGroupID == attempt.UserGroupID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Storage) PickBanner(ctx context.Context, slotID, usergroupID int, excluded map[int]struct{}) (int, error) {
+	_ = ctx
+	s.mu.RLock()
+	banners := multiarmedbandit.Exclude(s.slotBanners(slotID, usergroupID), excluded)
+	s.attachContext(slotID, usergroupID, banners)
+	s.mu.RUnlock()
+
+	if len(banners) == 0 {
+		return 0, storage.ErrNoActiveBanners
+	}
+
+	return s.strategies.Pick(slotID, banners), nil
+}
+
+// PickBanners выбирает баннер для каждого слота страницы. Баннеры из excluded не выбираются
+// ни в одном слоте. При uniqueBanners баннер, выбранный для одного слота, не предлагается в следующих.
+// Для слота без доступных баннеров возвращается 0.
+func (s *Storage) PickBanners(
+	ctx context.Context,
+	slotIDs []int,
+	usergroupID int,
+	excluded map[int]struct{},
+	uniqueBanners bool,
+) ([]int, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	bannerIDs := make([]int, 0, len(slotIDs))
+	picked := make(map[int]struct{}, len(slotIDs))
+	for _, slotID := range slotIDs {
+		banners := s.slotBanners(slotID, usergroupID)
+		banners = multiarmedbandit.Exclude(banners, excluded)
+		if uniqueBanners {
+			banners = multiarmedbandit.Exclude(banners, picked)
+		}
+		if len(banners) == 0 {
+			bannerIDs = append(bannerIDs, 0)
+			continue
+		}
+		s.attachContext(slotID, usergroupID, banners)
+
+		bannerID := s.strategies.Pick(slotID, banners)
+		picked[bannerID] = struct{}{}
+		bannerIDs = append(bannerIDs, bannerID)
+	}
+	return bannerIDs, nil
+}
+
+func (s *Storage) RecordImpression(
+	ctx context.Context,
+	tokenID string,
+	bannerID, slotID, userGroupID int,
+) (*storage.Impress, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tokenID != "" {
+		if _, ok := s.impressionTokens[tokenID]; ok {
+			return nil, storage.ErrAlreadyRecorded
+		}
+	}
+
+	s.lastImpressionID++
+	impress := storage.Impress{
+		ID:          s.lastImpressionID,
+		SlotID:      slotID,
+		BannerID:    bannerID,
+		UserGroupID: userGroupID,
+		CreatedAt:   time.Now(),
+		TokenID:     tokenID,
+	}
+	s.impressions = append(s.impressions, impress)
+	s.countEvent(slotID, bannerID, userGroupID, 1, 0, impress.CreatedAt)
+	if tokenID != "" {
+		s.impressionTokens[tokenID] = impress
+	}
+	impress.CapsReached = s.countServed(slotID, bannerID, 1, 0, impress.CreatedAt)
+
+	if err := s.addEventOutbox(storage.NewImpressNotification(&impress), impress.CapsReached); err != nil {
+		return nil, err
+	}
+
+	return &impress, nil
+}
+
+func (s *Storage) ImpressBanner(ctx context.Context, bannerID, slotID, userGroupID int) (*storage.Impress, error) {
+	return s.RecordImpression(ctx, "", bannerID, slotID, userGroupID)
+}
+
+func (s *Storage) IsBannerAssignedToSlot(ctx context.Context, bannerID, slotID int) (bool, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.rotations[rotationKey{slotID: slotID, bannerID: bannerID}]
+	return ok, nil
+}
+
+func (s *Storage) BannerExists(ctx context.Context, bannerID int) bool {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.banners.records[bannerID]
+	return ok
+}
+
+func (s *Storage) SlotExists(ctx context.Context, slotID int) bool {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.slots.records[slotID]
+	return ok
+}
+
+func (s *Storage) UserGroupExists(ctx context.Context, userGroupID int) bool {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.userGroups.records[userGroupID]
+	return ok
+}
+
+// countServed учитывает события в объеме ротации и возвращает исчерпанные ими ограничения.
+// Вызывается под блокировкой.
+func (s *Storage) countServed(slotID, bannerID, impressions, clicks int, now time.Time) []string {
+	r, ok := s.rotations[rotationKey{slotID: slotID, bannerID: bannerID}]
+	if !ok {
+		return nil
+	}
+	return r.settings.Caps.Count(&r.served, int64(impressions), int64(clicks), now)
+}
+
+// statsFor возвращает счетчики для тройки слот-баннер-группа. Вызывается под блокировкой.
+func (s *Storage) statsFor(slotID, bannerID, userGroupID int) *storage.BannerStatistics {
+	key := statsKey{slotID: slotID, bannerID: bannerID, userGroupID: userGroupID}
+	st, ok := s.stats[key]
+	if !ok {
+		st = &storage.BannerStatistics{BannerID: bannerID}
+		s.stats[key] = st
+	}
+	return st
+}
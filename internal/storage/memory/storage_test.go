@@ -0,0 +1,443 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateSeedsInventory(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	require.NoError(t, s.Migrate(ctx, ""))
+
+	require.True(t, s.SlotExists(ctx, 3))
+	require.False(t, s.SlotExists(ctx, 4))
+	require.True(t, s.BannerExists(ctx, 10))
+	require.True(t, s.UserGroupExists(ctx, 5))
+
+	assigned, err := s.IsBannerAssignedToSlot(ctx, 4, 1)
+	require.NoError(t, err)
+	require.True(t, assigned)
+}
+
+func TestAddRemoveBanner(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{}))
+	assigned, err := s.IsBannerAssignedToSlot(ctx, 2, 1)
+	require.NoError(t, err)
+	require.True(t, assigned)
+
+	require.NoError(t, s.RemoveBanner(ctx, 2, 1))
+	assigned, err = s.IsBannerAssignedToSlot(ctx, 2, 1)
+	require.NoError(t, err)
+	require.False(t, assigned)
+}
+
+func TestPickBanner(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+
+	_, err := s.PickBanner(ctx, 1, 1, nil)
+	require.Error(t, err)
+
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{}))
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{}))
+
+	bannerID, err := s.PickBanner(ctx, 1, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, bannerID)
+
+	// Неподтвержденный выбор не считается показом
+	bannerID, err = s.PickBanner(ctx, 1, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, bannerID)
+	require.Empty(t, s.impressions)
+
+	// После нескольких показов очередь доходит до второго баннера
+	picked := map[int]bool{bannerID: true}
+	for i := 0; i < 3; i++ {
+		picked[pickAndShow(t, s, 1, 1)] = true
+	}
+	require.True(t, picked[2])
+}
+
+func TestPickBannerSkipsInactive(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	now := time.Now()
+
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{}))
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{}))
+	require.NoError(t, s.AddBanner(ctx, 3, 1, flight(now.Add(time.Hour), time.Time{})))
+	require.NoError(t, s.AddBanner(ctx, 4, 1, flight(time.Time{}, now.Add(-time.Hour))))
+	require.ErrorIs(t, s.AddBanner(ctx, 5, 1, flight(now, now)), storage.ErrInvalidFlight)
+
+	_, err := s.ImpressBanner(ctx, 2, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, s.SetBannerPaused(ctx, 2, 1, true))
+	require.ErrorIs(t, s.SetBannerPaused(ctx, 5, 1, true), storage.ErrNotFound)
+
+	for i := 0; i < 5; i++ {
+		require.Equal(t, 1, pickAndShow(t, s, 1, 1))
+	}
+
+	// После возобновления баннер выбирается со статистикой, накопленной до паузы
+	require.NoError(t, s.SetBannerPaused(ctx, 2, 1, false))
+	s.mu.RLock()
+	banners := s.slotBanners(1, 1)
+	s.mu.RUnlock()
+	require.Len(t, banners, 2)
+	require.Equal(t, 2, banners[1].GetID())
+	require.Equal(t, 1.0, banners[1].GetImpressions())
+
+	require.NoError(t, s.SetBannerPaused(ctx, 1, 1, true))
+	require.NoError(t, s.SetBannerPaused(ctx, 2, 1, true))
+	_, err = s.PickBanner(ctx, 1, 1, nil)
+	require.ErrorIs(t, err, storage.ErrNoActiveBanners)
+}
+
+func TestPickBannerSkipsCapped(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{Caps: storage.Caps{MaxImpressions: 2}}))
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{Caps: storage.Caps{MaxClicks: 1}}))
+	require.NoError(t, s.AddBanner(ctx, 3, 1, storage.RotationSettings{Caps: storage.Caps{MaxDailyImpressions: 1}}))
+	require.ErrorIs(t, s.AddBanner(ctx, 4, 1, storage.RotationSettings{Caps: storage.Caps{MaxImpressions: -1}}),
+		storage.ErrInvalidCaps)
+
+	impress, err := s.ImpressBanner(ctx, 1, 1, 1)
+	require.NoError(t, err)
+	require.Empty(t, impress.CapsReached)
+	impress, err = s.ImpressBanner(ctx, 1, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{storage.CapImpressions}, impress.CapsReached)
+
+	click, err := s.ClickBanner(ctx, clickAttempt(2, 1, 1, ""), storage.ClickGuard{})
+	require.NoError(t, err)
+	require.Equal(t, []string{storage.CapClicks}, click.CapsReached)
+
+	impress, err = s.ImpressBanner(ctx, 3, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{storage.CapDailyImpressions}, impress.CapsReached)
+
+	_, err = s.PickBanner(ctx, 1, 1, nil)
+	require.ErrorIs(t, err, storage.ErrNoActiveBanners)
+
+	// На следующие сутки дневной объем снова доступен
+	s.mu.RLock()
+	r := s.rotations[rotationKey{slotID: 1, bannerID: 3}]
+	s.mu.RUnlock()
+	require.True(t, r.settings.Caps.Reached(r.served, time.Now()))
+	require.False(t, r.settings.Caps.Reached(r.served, time.Now().Add(24*time.Hour)))
+
+	// Об исчерпанных ограничениях уведомления уходят в outbox
+	messages, err := s.ClaimOutbox(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	caps := make([]string, 0)
+	for _, m := range messages {
+		var n storage.Notification
+		require.NoError(t, json.Unmarshal(m.Payload, &n))
+		if n.TypeEvent == storage.EventCapReached {
+			caps = append(caps, n.Cap)
+		}
+	}
+	require.Equal(t, []string{storage.CapImpressions, storage.CapClicks, storage.CapDailyImpressions}, caps)
+}
+
+func TestDailyPacing(t *testing.T) {
+	caps := storage.Caps{MaxDailyImpressions: 100}
+	day := storage.PacingDay(time.Now())
+
+	// Дневной объем доступен пропорционально прошедшей доле суток
+	require.Equal(t, int64(1), caps.DailyAllowance(day))
+	require.Equal(t, int64(25), caps.DailyAllowance(day.Add(6*time.Hour)))
+	require.Equal(t, int64(100), caps.DailyAllowance(day.Add(24*time.Hour-time.Second)))
+
+	served := storage.Served{DailyImpressions: 25, Day: day}
+	require.True(t, caps.Reached(served, day.Add(6*time.Hour)))
+	require.False(t, caps.Reached(served, day.Add(7*time.Hour)))
+}
+
+func TestUpdateRotation(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{Caps: storage.Caps{MaxImpressions: 1}}))
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{MinShare: 0.5}))
+	_, err := s.ImpressBanner(ctx, 1, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, s.SetBannerPaused(ctx, 2, 1, true))
+	_, err = s.PickBanner(ctx, 1, 1, nil)
+	require.ErrorIs(t, err, storage.ErrNoActiveBanners)
+
+	// Новые ограничения учитывают уже открученный объем
+	require.NoError(t, s.UpdateRotation(ctx, 1, 1, storage.RotationSettings{Caps: storage.Caps{MaxImpressions: 2}}))
+	bannerID, err := s.PickBanner(ctx, 1, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, bannerID)
+	s.mu.RLock()
+	require.Equal(t, int64(1), s.rotations[rotationKey{slotID: 1, bannerID: 1}].served.Impressions)
+	s.mu.RUnlock()
+
+	// Собственная доля баннера не мешает ее изменить
+	require.NoError(t, s.UpdateRotation(ctx, 2, 1, storage.RotationSettings{MinShare: 0.6}))
+	require.ErrorIs(t, s.UpdateRotation(ctx, 1, 1, storage.RotationSettings{MinShare: 0.5}), storage.ErrShareExceeded)
+	require.ErrorIs(t, s.UpdateRotation(ctx, 3, 1, storage.RotationSettings{}), storage.ErrNotFound)
+	require.ErrorIs(t, s.UpdateRotation(ctx, 1, 1, storage.RotationSettings{Weight: -1}), storage.ErrInvalidPriority)
+}
+
+func TestPickBannerPriority(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{MinShare: 0.6}))
+	require.ErrorIs(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{MinShare: 0.5}), storage.ErrShareExceeded)
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{MinShare: 0.4}))
+	require.ErrorIs(t, s.AddBanner(ctx, 3, 1, storage.RotationSettings{Weight: -1}), storage.ErrInvalidPriority)
+	// Вес незакрепленного баннера ни на что не влиял бы
+	require.ErrorIs(t, s.AddBanner(ctx, 3, 1, storage.RotationSettings{Weight: 2}), storage.ErrWeightNotPinned)
+	require.NoError(t, s.AddBanner(ctx, 3, 1, storage.RotationSettings{Pinned: true}))
+
+	// Закрепленный баннер получает весь трафик
+	for i := 0; i < 5; i++ {
+		require.Equal(t, 3, pickAndShow(t, s, 1, 1))
+	}
+
+	// Без него доли 60% и 40% покрывают весь трафик слота
+	require.NoError(t, s.SetBannerPaused(ctx, 3, 1, true))
+	picked := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		bannerID, err := s.PickBanner(ctx, 1, 1, nil)
+		require.NoError(t, err)
+		picked[bannerID]++
+	}
+	require.InDelta(t, 600, picked[1], 80)
+	require.InDelta(t, 400, picked[2], 80)
+}
+
+func TestPickBannerColdStart(t *testing.T) {
+	ctx := context.Background()
+	selector := multiarmedbandit.NewSelector(multiarmedbandit.NewEpsilonGreedy(0, rand.NewSource(1)))
+	selector.SetDefaultColdStart(multiarmedbandit.ColdStart{MinImpressions: 3})
+	s := New(selector)
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{}))
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, 1, pickAndShow(t, s, 1, 1))
+	}
+	_, err := s.ClickBanner(ctx, clickAttempt(1, 1, 1, ""), storage.ClickGuard{})
+	require.NoError(t, err)
+
+	// Новый баннер получает обязательные показы в любых группах, затем конкурирует по CTR
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{}))
+	explored := 0
+	for i := 0; i < 100; i++ {
+		if pickAndShow(t, s, 1, 1+i%2) == 2 {
+			explored++
+		}
+	}
+	require.Equal(t, 3, explored)
+	require.Equal(t, 1, pickAndShow(t, s, 1, 1))
+}
+
+func TestRecordImpression(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+
+	impress, err := s.RecordImpression(ctx, "token-1", 1, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, 1, impress.BannerID)
+	require.Equal(t, 2, impress.SlotID)
+	require.Equal(t, 3, impress.UserGroupID)
+
+	_, err = s.RecordImpression(ctx, "token-1", 1, 2, 3)
+	require.ErrorIs(t, err, storage.ErrAlreadyRecorded)
+
+	click, err := s.ClickBanner(ctx, clickAttempt(1, 2, 3, "token-1"), storage.ClickGuard{})
+	require.NoError(t, err)
+	require.Equal(t, impress.ID, click.ImpressionID)
+
+	// Переход должен совпадать с показом по слоту, баннеру и группе
+	_, err = s.ClickBanner(ctx, clickAttempt(1, 2, 4, "token-1"), storage.ClickGuard{})
+	requireRejected(t, err, storage.RejectNoImpression)
+	_, err = s.ClickBanner(ctx, clickAttempt(1, 2, 3, "token-2"), storage.ClickGuard{})
+	requireRejected(t, err, storage.RejectNoImpression)
+
+	click, err = s.ClickBanner(ctx, clickAttempt(1, 2, 3, ""), storage.ClickGuard{})
+	require.NoError(t, err)
+	require.Zero(t, click.ImpressionID)
+}
+
+func TestClickGuard(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	guard := storage.ClickGuard{
+		ImpressionWindow:  time.Hour,
+		ClientWindow:      time.Hour,
+		RequireImpression: true,
+		ImpressionMaxAge:  time.Hour,
+	}
+
+	// Баннер не показывался
+	_, err := s.ClickBanner(ctx, clickAttempt(1, 2, 3, ""), guard)
+	requireRejected(t, err, storage.RejectNoImpression)
+
+	_, err = s.RecordImpression(ctx, "token-1", 1, 2, 3)
+	require.NoError(t, err)
+
+	_, err = s.ClickBanner(ctx, clickAttempt(1, 2, 3, "token-1"), guard)
+	require.NoError(t, err)
+	_, err = s.ClickBanner(ctx, clickAttempt(1, 2, 3, "token-1"), guard)
+	requireRejected(t, err, storage.RejectDuplicateImpression)
+
+	// Повтор от того же клиента отклоняется, другой клиент и другой слот проходят
+	attempt := clickAttempt(1, 2, 3, "")
+	attempt.ClientKey = "10.0.0.1"
+	_, err = s.ClickBanner(ctx, attempt, guard)
+	require.NoError(t, err)
+	_, err = s.ClickBanner(ctx, attempt, guard)
+	requireRejected(t, err, storage.RejectDuplicateClient)
+
+	attempt.ClientKey = "10.0.0.2"
+	_, err = s.ClickBanner(ctx, attempt, guard)
+	require.NoError(t, err)
+
+	// Вне окна повтор принимается
+	s.mu.Lock()
+	for i := range s.clicks {
+		s.clicks[i].CreatedAt = s.clicks[i].CreatedAt.Add(-2 * time.Hour)
+	}
+	s.mu.Unlock()
+	_, err = s.ClickBanner(ctx, attempt, guard)
+	require.NoError(t, err)
+
+	require.Len(t, s.clicks, 4)
+	require.Len(t, s.rejectedClicks, 3)
+	require.Equal(t, "10.0.0.1", s.rejectedClicks[2].ClientKey)
+	require.Equal(t, storage.RejectDuplicateClient, s.rejectedClicks[2].Reason)
+
+	require.EqualValues(t, 4, s.stats[statsKey{slotID: 2, bannerID: 1, userGroupID: 3}].Clicks)
+
+	// Давний показ не подтверждает переход без токена
+	s.mu.Lock()
+	for i := range s.impressions {
+		s.impressions[i].CreatedAt = s.impressions[i].CreatedAt.Add(-2 * time.Hour)
+	}
+	s.mu.Unlock()
+	attempt.ClientKey = "10.0.0.3"
+	_, err = s.ClickBanner(ctx, attempt, guard)
+	requireRejected(t, err, storage.RejectNoImpression)
+}
+
+func TestPickBannerPrefersClicked(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{}))
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{}))
+
+	picks := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		bannerID := pickAndShow(t, s, 1, 1)
+		picks[bannerID]++
+		if bannerID == 2 {
+			_, err := s.ClickBanner(ctx, clickAttempt(bannerID, 1, 1, ""), storage.ClickGuard{})
+			require.NoError(t, err)
+		}
+	}
+	require.Greater(t, picks[2], picks[1])
+}
+
+func TestPickBanners(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	require.NoError(t, s.AddBanner(ctx, 1, 1, storage.RotationSettings{}))
+	require.NoError(t, s.AddBanner(ctx, 2, 1, storage.RotationSettings{}))
+	require.NoError(t, s.AddBanner(ctx, 1, 2, storage.RotationSettings{}))
+
+	// Баннер 1 уже выбран для слота 2, поэтому в слоте 1 выбирается баннер 2
+	bannerIDs, err := s.PickBanners(ctx, []int{2, 1}, 3, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, bannerIDs)
+
+	// Без ограничения уникальности баннер может повторяться
+	bannerIDs, err = s.PickBanners(ctx, []int{2, 1}, 4, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 1}, bannerIDs)
+
+	// Слот без баннеров остается пустым, остальные заполняются
+	bannerIDs, err = s.PickBanners(ctx, []int{1, 3}, 5, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, 0, bannerIDs[1])
+	require.NotZero(t, bannerIDs[0])
+
+	// Исключенный баннер не выбирается ни в одном слоте
+	bannerIDs, err = s.PickBanners(ctx, []int{2, 1}, 6, map[int]struct{}{1: {}}, false)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 2}, bannerIDs)
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	s := New(nil)
+	require.NoError(t, s.Migrate(ctx, ""))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				bannerID := pickAndShow(t, s, 1, 1)
+				_, err := s.ClickBanner(ctx, clickAttempt(bannerID, 1, 1, ""), storage.ClickGuard{})
+				require.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, s.impressions, 1000)
+	require.Len(t, s.clicks, 1000)
+}
+
+// pickAndShow выбирает баннер и сразу подтверждает его показ.
+func clickAttempt(bannerID, slotID, userGroupID int, impressionTokenID string) storage.ClickAttempt {
+	return storage.ClickAttempt{
+		BannerID:          bannerID,
+		SlotID:            slotID,
+		UserGroupID:       userGroupID,
+		ImpressionTokenID: impressionTokenID,
+	}
+}
+
+func requireRejected(t *testing.T, err error, reason string) {
+	t.Helper()
+
+	var rejected *storage.RejectedClickError
+	require.ErrorAs(t, err, &rejected)
+	require.Equal(t, reason, rejected.Reason)
+}
+
+func pickAndShow(t *testing.T, s *Storage, slotID, userGroupID int) int {
+	t.Helper()
+
+	bannerID, err := s.PickBanner(context.Background(), slotID, userGroupID, nil)
+	require.NoError(t, err)
+	_, err = s.ImpressBanner(context.Background(), bannerID, slotID, userGroupID)
+	require.NoError(t, err)
+	return bannerID
+}
+
+func flight(startsAt, endsAt time.Time) storage.RotationSettings {
+	return storage.RotationSettings{Flight: storage.Flight{StartsAt: startsAt, EndsAt: endsAt}}
+}
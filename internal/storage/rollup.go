@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// HourlyRollup - число показов и переходов баннера за час.
+type HourlyRollup struct {
+	Hour        time.Time
+	SlotID      int
+	BannerID    int
+	UserGroupID int
+	Impressions int64
+	Clicks      int64
+}
+
+// RollupHour возвращает начало часа (UTC), к которому относится момент времени.
+func RollupHour(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
+
+// Counters возвращает приращения показов и переходов для уведомления.
+func (n Notification) Counters() (impressions, clicks int64, err error) {
+	switch n.TypeEvent {
+	case EventImpress:
+		return 1, 0, nil
+	case EventClick:
+		return 0, 1, nil
+	default:
+		return 0, 0, fmt.Errorf("unknown event type: %q", n.TypeEvent)
+	}
+}
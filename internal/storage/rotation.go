@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrInvalidFlight   = errors.New("flight must end after it starts")
+	ErrInvalidCaps     = errors.New("caps must not be negative")
+	ErrInvalidPriority = errors.New("weight must not be negative and min share must be between 0 and 1")
+	ErrShareExceeded   = errors.New("guaranteed shares of the slot exceed 100%")
+	ErrWeightNotPinned = errors.New("weight applies only to pinned banners")
+)
+
+// ShareTolerance - погрешность при сравнении суммы гарантированных долей слота с единицей.
+const ShareTolerance = 1e-9
+
+// Ограничения ротации, о достижении которых отправляется уведомление EventCapReached.
+const (
+	CapImpressions      = "impressions"
+	CapClicks           = "clicks"
+	CapDailyImpressions = "daily_impressions"
+)
+
+// RotationSettings - условия участия баннера в ротации слота.
+type RotationSettings struct {
+	Flight Flight
+	Caps   Caps
+	// Pinned - баннер показывается всегда, пока активен; закрепленные баннеры делят трафик по Weight.
+	Pinned bool
+	// Weight - вес среди закрепленных баннеров слота; 0 означает 1. Незакрепленные баннеры
+	// делят трафик по стратегии бандита и MinShare, поэтому вес для них не задается.
+	Weight float64
+	// MinShare - гарантированная доля трафика слота от 0 до 1. Сумма долей слота не больше 1,
+	// оставшийся трафик распределяет стратегия бандита.
+	MinShare float64
+}
+
+// Validate проверяет период показа, ограничения и условия приоритета: вес допустим только
+// у закрепленного баннера.
+func (r RotationSettings) Validate() error {
+	if err := r.Flight.Validate(); err != nil {
+		return err
+	}
+	if err := r.Caps.Validate(); err != nil {
+		return err
+	}
+	if r.Weight < 0 || r.MinShare < 0 || r.MinShare > 1 {
+		return ErrInvalidPriority
+	}
+	if r.Weight != 0 && !r.Pinned {
+		return ErrWeightNotPinned
+	}
+	return nil
+}
+
+// Flight - период, в который баннер участвует в ротации слота. Нулевые границы не ограничивают период.
+type Flight struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// Validate проверяет, что период не пустой.
+func (f Flight) Validate() error {
+	if !f.StartsAt.IsZero() && !f.EndsAt.IsZero() && !f.EndsAt.After(f.StartsAt) {
+		return ErrInvalidFlight
+	}
+	return nil
+}
+
+// Contains проверяет, попадает ли момент времени в полуинтервал [StartsAt, EndsAt).
+func (f Flight) Contains(t time.Time) bool {
+	if !f.StartsAt.IsZero() && t.Before(f.StartsAt) {
+		return false
+	}
+	if !f.EndsAt.IsZero() && !t.Before(f.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// Caps - купленный объем показов и переходов баннера в слоте. Нулевое значение не ограничивает.
+// MaxDailyImpressions распределяет показы по дням (сутки считаются по UTC) и равномерно внутри
+// суток: к каждому моменту доступна доля лимита, равная прошедшей доле суток (см. DailyAllowance),
+// чтобы дневной объем не расходовался в первые часы.
+type Caps struct {
+	MaxImpressions      int64
+	MaxClicks           int64
+	MaxDailyImpressions int64
+}
+
+// Validate проверяет, что ограничения не отрицательные.
+func (c Caps) Validate() error {
+	if c.MaxImpressions < 0 || c.MaxClicks < 0 || c.MaxDailyImpressions < 0 {
+		return ErrInvalidCaps
+	}
+	return nil
+}
+
+// Served - показы и переходы, набранные баннером в ротации слота.
+type Served struct {
+	Impressions      int64
+	Clicks           int64
+	DailyImpressions int64
+	// Day - сутки, за которые посчитаны DailyImpressions.
+	Day time.Time
+}
+
+// PacingDay возвращает начало суток (UTC), к которым относится момент времени.
+func PacingDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Reached сообщает, исчерпан ли объем к моменту now.
+func (c Caps) Reached(served Served, now time.Time) bool {
+	switch {
+	case c.MaxImpressions > 0 && served.Impressions >= c.MaxImpressions:
+		return true
+	case c.MaxClicks > 0 && served.Clicks >= c.MaxClicks:
+		return true
+	case c.MaxDailyImpressions > 0 && served.Day.Equal(PacingDay(now)) &&
+		served.DailyImpressions >= c.DailyAllowance(now):
+		return true
+	}
+	return false
+}
+
+// DailyAllowance возвращает, сколько показов за сутки доступно к моменту now: MaxDailyImpressions,
+// умноженное на прошедшую долю суток, с округлением вверх, но не меньше одного показа.
+func (c Caps) DailyAllowance(now time.Time) int64 {
+	elapsed := now.UTC().Sub(PacingDay(now))
+	allowance := int64(math.Ceil(float64(c.MaxDailyImpressions) * elapsed.Seconds() / (24 * time.Hour).Seconds()))
+	if allowance < 1 {
+		return 1
+	}
+	if allowance > c.MaxDailyImpressions {
+		return c.MaxDailyImpressions
+	}
+	return allowance
+}
+
+// Count учитывает события в served и возвращает ограничения, которые они исчерпали.
+func (c Caps) Count(served *Served, impressions, clicks int64, now time.Time) []string {
+	day := PacingDay(now)
+	if !served.Day.Equal(day) {
+		served.Day = day
+		served.DailyImpressions = 0
+	}
+	served.Impressions += impressions
+	served.Clicks += clicks
+	served.DailyImpressions += impressions
+
+	var reached []string
+	if impressions > 0 && served.Impressions == c.MaxImpressions {
+		reached = append(reached, CapImpressions)
+	}
+	if clicks > 0 && served.Clicks == c.MaxClicks {
+		reached = append(reached, CapClicks)
+	}
+	if impressions > 0 && served.DailyImpressions == c.MaxDailyImpressions {
+		reached = append(reached, CapDailyImpressions)
+	}
+	return reached
+}
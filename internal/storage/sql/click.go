@@ -0,0 +1,125 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+// checkClick проверяет переход по правилам guard и возвращает причину отклонения
+// (пустую, если переход принят) и показ, к которому он относится.
+func checkClick(
+	ctx context.Context,
+	tx *sql.Tx,
+	attempt storage.ClickAttempt,
+	guard storage.ClickGuard,
+) (string, sql.NullInt64, error) {
+	var impressionID sql.NullInt64
+
+	// Переходы по одному баннеру в слоте проверяются по очереди, иначе параллельные
+	// повторы не увидят друг друга до фиксации транзакции.
+	if guard.ImpressionWindow > 0 || guard.ClientWindow > 0 {
+		const lockQuery = `SELECT pg_advisory_xact_lock($1, $2);`
+		if _, err := tx.ExecContext(ctx, lockQuery, attempt.SlotID, attempt.BannerID); err != nil {
+			return "", impressionID, err
+		}
+	}
+
+	if attempt.ImpressionTokenID != "" {
+		const query = `
+			SELECT id
+			FROM impressions
+			WHERE token_id = $1 AND slot_id = $2 AND banner_id = $3 AND usergroup_id = $4;`
+
+		err := tx.QueryRowContext(ctx, query,
+			attempt.ImpressionTokenID, attempt.SlotID, attempt.BannerID, attempt.UserGroupID).
+			Scan(&impressionID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.RejectNoImpression, impressionID, nil
+		}
+		if err != nil {
+			return "", impressionID, err
+		}
+	}
+
+	if guard.ImpressionWindow > 0 && impressionID.Valid {
+		const query = `
+			SELECT EXISTS (
+				SELECT 1 FROM clicks
+				WHERE impression_id = $1 AND created_at > NOW() - make_interval(secs => $2)
+			);`
+
+		duplicate, err := exists(ctx, tx, query, impressionID.Int64, guard.ImpressionWindow.Seconds())
+		if err != nil {
+			return "", impressionID, err
+		}
+		if duplicate {
+			return storage.RejectDuplicateImpression, impressionID, nil
+		}
+	}
+
+	if guard.ClientWindow > 0 && attempt.ClientKey != "" {
+		const query = `
+			SELECT EXISTS (
+				SELECT 1 FROM clicks
+				WHERE client_key = $1 AND slot_id = $2 AND banner_id = $3
+					AND created_at > NOW() - make_interval(secs => $4)
+			);`
+
+		duplicate, err := exists(ctx, tx, query,
+			attempt.ClientKey, attempt.SlotID, attempt.BannerID, guard.ClientWindow.Seconds())
+		if err != nil {
+			return "", impressionID, err
+		}
+		if duplicate {
+			return storage.RejectDuplicateClient, impressionID, nil
+		}
+	}
+
+	// Переход с токеном уже привязан к показу
+	if guard.RequireImpression && attempt.ImpressionTokenID == "" {
+		const query = `
+			SELECT EXISTS (
+				SELECT 1 FROM impressions
+				WHERE slot_id = $1 AND banner_id = $2 AND usergroup_id = $3
+					AND created_at > NOW() - make_interval(secs => $4)
+			);`
+
+		shown, err := exists(ctx, tx, query,
+			attempt.SlotID, attempt.BannerID, attempt.UserGroupID, guard.ImpressionMaxAge.Seconds())
+		if err != nil {
+			return "", impressionID, err
+		}
+		if !shown {
+			return storage.RejectNoImpression, impressionID, nil
+		}
+	}
+
+	return "", impressionID, nil
+}
+
+// insertRejectedClick сохраняет отклоненный переход для аудита.
+func insertRejectedClick(
+	ctx context.Context,
+	tx *sql.Tx,
+	attempt storage.ClickAttempt,
+	impressionID sql.NullInt64,
+	reason string,
+) error {
+	const query = `
+		INSERT INTO rejected_clicks
+		(slot_id, banner_id, usergroup_id, impression_id, client_key, reason, created_at) VALUES
+		($1, $2, $3, $4, $5, $6, NOW());`
+
+	_, err := tx.ExecContext(ctx, query, attempt.SlotID, attempt.BannerID, attempt.UserGroupID,
+		impressionID, nullString(attempt.ClientKey), reason)
+	return err
+}
+
+func exists(ctx context.Context, tx *sql.Tx, query string, args ...any) (bool, error) {
+	var found bool
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&found)
+	return found, err
+}
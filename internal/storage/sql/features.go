@@ -0,0 +1,225 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+// SetUserGroupFeatures заменяет признаки группы. Если группы нет, возвращается storage.ErrNotFound.
+func (s *Storage) SetUserGroupFeatures(ctx context.Context, userGroupID int, features storage.Features) error {
+	const (
+		lockQuery   = `SELECT id FROM usergroups WHERE id = $1 FOR UPDATE;`
+		deleteQuery = `DELETE FROM usergroup_features WHERE usergroup_id = $1;`
+		insertQuery = `INSERT INTO usergroup_features (usergroup_id, name, value) VALUES ($1, $2, $3);`
+	)
+	if err := features.Validate(); err != nil {
+		return err
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var id int
+		err := tx.QueryRowContext(ctx, lockQuery, userGroupID).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, deleteQuery, userGroupID); err != nil {
+			return err
+		}
+		for _, name := range sortedNames(features) {
+			if _, err := tx.ExecContext(ctx, insertQuery, userGroupID, name, features[name]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UserGroupFeatures возвращает признаки группы. Если группы нет, возвращается storage.ErrNotFound.
+func (s *Storage) UserGroupFeatures(ctx context.Context, userGroupID int) (storage.Features, error) {
+	const query = `
+		SELECT g.id, f.name, f.value
+		FROM usergroups g
+		LEFT JOIN usergroup_features f ON f.usergroup_id = g.id
+		WHERE g.id = $1;`
+
+	rows, err := s.db.QueryContext(ctx, query, userGroupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var features storage.Features
+	for rows.Next() {
+		var (
+			id    int
+			name  sql.NullString
+			value sql.NullFloat64
+		)
+		if err := rows.Scan(&id, &name, &value); err != nil {
+			return nil, err
+		}
+		if features == nil {
+			features = make(storage.Features)
+		}
+		if name.Valid {
+			features[name.String] = value.Float64
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if features == nil {
+		return nil, storage.ErrNotFound
+	}
+	return features, nil
+}
+
+// attachContext добавляет баннерам признаки группы и статистику слота по всем группам,
+// если стратегия слота контекстная. Статистика учитывает забывание, как и в slotBanners.
+func (s *Storage) attachContext(
+	ctx context.Context,
+	slotID, userGroupID int,
+	banners []multiarmedbandit.Banner,
+) error {
+	if !s.strategies.NeedsContext(slotID) || len(banners) == 0 {
+		return nil
+	}
+
+	features, err := s.allFeatures(ctx)
+	if err != nil {
+		return err
+	}
+	stats, err := s.groupStats(ctx, slotID)
+	if err != nil {
+		return err
+	}
+	multiarmedbandit.AttachContext(banners, userGroupID, features, stats)
+	return nil
+}
+
+func (s *Storage) allFeatures(ctx context.Context) (map[int]map[string]float64, error) {
+	const query = `SELECT usergroup_id, name, value FROM usergroup_features;`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	features := make(map[int]map[string]float64)
+	for rows.Next() {
+		var (
+			groupID int
+			name    string
+			value   float64
+		)
+		if err := rows.Scan(&groupID, &name, &value); err != nil {
+			return nil, err
+		}
+		if features[groupID] == nil {
+			features[groupID] = make(map[string]float64)
+		}
+		features[groupID][name] = value
+	}
+	return features, rows.Err()
+}
+
+// groupStats возвращает счетчики баннеров слота в разрезе групп.
+func (s *Storage) groupStats(ctx context.Context, slotID int) (map[int][]multiarmedbandit.GroupStats, error) {
+	const countersQuery = `
+		SELECT banner_id, usergroup_id, impressions, clicks
+		FROM banner_stats
+		WHERE slot_id = $1;`
+
+	const halfLifeQuery = `
+		SELECT banner_id, usergroup_id,
+			decayed_impressions * f.factor AS impressions,
+			decayed_clicks * f.factor AS clicks
+		FROM banner_stats bs
+		CROSS JOIN LATERAL (
+			SELECT power(0.5, GREATEST(EXTRACT(EPOCH FROM NOW() - bs.decayed_at), 0)::float8 / $2) AS factor
+		) f
+		WHERE bs.slot_id = $1;`
+
+	const windowQuery = `
+		SELECT banner_id, usergroup_id, SUM(impressions) AS impressions, SUM(clicks) AS clicks
+		FROM (
+			SELECT banner_id, usergroup_id, 1 AS impressions, 0 AS clicks FROM impressions
+				WHERE slot_id = $1 AND created_at >= NOW() - make_interval(secs => $2)
+			UNION ALL
+			SELECT banner_id, usergroup_id, 0, 1 FROM clicks
+				WHERE slot_id = $1 AND created_at >= NOW() - make_interval(secs => $2)
+		) e
+		GROUP BY banner_id, usergroup_id;`
+
+	decay := s.strategies.DecayForSlot(slotID)
+	if s.stats != nil && decay.Window == 0 {
+		return s.cachedGroupStats(slotID, decay), nil
+	}
+
+	query := countersQuery
+	args := []any{slotID}
+	switch {
+	case decay.HalfLife > 0:
+		query = halfLifeQuery
+		args = append(args, decay.HalfLife.Seconds())
+	case decay.Window > 0:
+		query = windowQuery
+		args = append(args, decay.Window.Seconds())
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make(map[int][]multiarmedbandit.GroupStats)
+	for rows.Next() {
+		var (
+			bannerID int
+			st       multiarmedbandit.GroupStats
+		)
+		if err := rows.Scan(&bannerID, &st.UserGroupID, &st.Impressions, &st.Clicks); err != nil {
+			return nil, err
+		}
+		stats[bannerID] = append(stats[bannerID], st)
+	}
+	return stats, rows.Err()
+}
+
+// cachedGroupStats возвращает счетчики баннеров слота в разрезе групп из кеша статистики.
+func (s *Storage) cachedGroupStats(slotID int, decay multiarmedbandit.Decay) map[int][]multiarmedbandit.GroupStats {
+	now := time.Now()
+	stats := make(map[int][]multiarmedbandit.GroupStats)
+	for key, counters := range s.stats.Slot(slotID) {
+		st := multiarmedbandit.GroupStats{
+			UserGroupID: key.UserGroupID,
+			Impressions: float64(counters.Impressions),
+			Clicks:      float64(counters.Clicks),
+		}
+		if decay.HalfLife > 0 {
+			st.Impressions, st.Clicks = counters.DecayedAt(now, decay)
+		}
+		stats[key.BannerID] = append(stats[key.BannerID], st)
+	}
+	return stats
+}
+
+func sortedNames(features storage.Features) []string {
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,150 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	stor "github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+func TestSetUserGroupFeatures(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	ctx := context.Background()
+
+	// Признаки заменяются целиком в одной транзакции
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM usergroups WHERE id = (.+) FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec("DELETE FROM usergroup_features").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("INSERT INTO usergroup_features").
+		WithArgs(1, "age_18_24", 1.0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO usergroup_features").
+		WithArgs(1, "interest_sport", 0.5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = storage.SetUserGroupFeatures(ctx, 1, stor.Features{"interest_sport": 0.5, "age_18_24": 1})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Группы нет
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM usergroups WHERE id = (.+) FOR UPDATE").
+		WithArgs(100).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	err = storage.SetUserGroupFeatures(ctx, 100, stor.Features{"age_18_24": 1})
+	if !errors.Is(err, stor.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	// Пустое имя признака отклоняется без обращения к базе
+	err = storage.SetUserGroupFeatures(ctx, 1, stor.Features{" ": 1})
+	if !errors.Is(err, stor.ErrInvalidFeatures) {
+		t.Errorf("expected ErrInvalidFeatures, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserGroupFeatures(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	ctx := context.Background()
+	columns := []string{"id", "name", "value"}
+
+	mock.ExpectQuery("SELECT (.+) FROM usergroups g LEFT JOIN usergroup_features").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "age_18_24", 1.0).AddRow(1, "interest_sport", 0.5))
+	features, err := storage.UserGroupFeatures(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(features, stor.Features{"age_18_24": 1, "interest_sport": 0.5}) {
+		t.Errorf("unexpected features: %v", features)
+	}
+
+	// Группа без признаков
+	mock.ExpectQuery("SELECT (.+) FROM usergroups g LEFT JOIN usergroup_features").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, nil, nil))
+	features, err = storage.UserGroupFeatures(ctx, 2)
+	if err != nil || len(features) != 0 || features == nil {
+		t.Errorf("expected empty features, got %v (%v)", features, err)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM usergroups g LEFT JOIN usergroup_features").
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows(columns))
+	_, err = storage.UserGroupFeatures(ctx, 100)
+	if !errors.Is(err, stor.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPickBannerLinUCB(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	selector := multiarmedbandit.NewSelector(nil)
+	selector.SetSlotStrategy(2, multiarmedbandit.NewLinUCB(1))
+	storage := &Storage{db: db, strategies: selector}
+
+	// У новой группы 4 нет своей статистики, но ее признаки совпадают с группой 1,
+	// в которой лучше работает баннер 2
+	mock.ExpectQuery("SELECT (.+) FROM rotations r LEFT JOIN banner_stats").
+		WithArgs(4, 2).
+		WillReturnRows(candidateRows().AddRow(1, false, 0.0, 0.0, 0, 0, 0).AddRow(2, false, 0.0, 0.0, 0, 0, 0))
+	mock.ExpectQuery("SELECT usergroup_id, name, value FROM usergroup_features").
+		WillReturnRows(sqlmock.NewRows([]string{"usergroup_id", "name", "value"}).
+			AddRow(1, "age_18_24", 1.0).
+			AddRow(3, "age_55_plus", 1.0).
+			AddRow(4, "age_18_24", 1.0))
+	mock.ExpectQuery("SELECT banner_id, usergroup_id, impressions, clicks FROM banner_stats").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"banner_id", "usergroup_id", "impressions", "clicks"}).
+			AddRow(1, 1, 1000, 10).
+			AddRow(1, 3, 1000, 300).
+			AddRow(2, 1, 1000, 300).
+			AddRow(2, 3, 1000, 10))
+
+	bannerID, err := storage.PickBanner(context.Background(), 2, 4, nil)
+	if err != nil || bannerID != 2 {
+		t.Errorf("expected banner 2, got %d (%v)", bannerID, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
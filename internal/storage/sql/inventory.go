@@ -0,0 +1,220 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+// Таблицы справочников имеют одинаковую структуру (id, name, created_at),
+// поэтому CRUD для них реализован общими функциями.
+const (
+	slotsTable      = "slots"
+	bannersTable    = "banners"
+	userGroupsTable = "usergroups"
+)
+
+func (s *Storage) CreateSlot(ctx context.Context, name string) (*storage.Slot, error) {
+	slot := &storage.Slot{}
+	if err := s.createNamed(ctx, slotsTable, name, &slot.ID, &slot.Name, &slot.CreatedAt); err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
+
+func (s *Storage) GetSlot(ctx context.Context, slotID int) (*storage.Slot, error) {
+	slot := &storage.Slot{}
+	if err := s.getNamed(ctx, slotsTable, slotID, &slot.ID, &slot.Name, &slot.CreatedAt); err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
+
+func (s *Storage) ListSlots(ctx context.Context) ([]storage.Slot, error) {
+	slots := make([]storage.Slot, 0)
+	err := s.listNamed(ctx, slotsTable, func(rows *sql.Rows) error {
+		var slot storage.Slot
+		if err := rows.Scan(&slot.ID, &slot.Name, &slot.CreatedAt); err != nil {
+			return err
+		}
+		slots = append(slots, slot)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+func (s *Storage) UpdateSlot(ctx context.Context, slotID int, name string) (*storage.Slot, error) {
+	slot := &storage.Slot{}
+	if err := s.updateNamed(ctx, slotsTable, slotID, name, &slot.ID, &slot.Name, &slot.CreatedAt); err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
+
+func (s *Storage) DeleteSlot(ctx context.Context, slotID int) error {
+	return s.deleteNamed(ctx, slotsTable, slotID)
+}
+
+func (s *Storage) CreateBanner(ctx context.Context, name string) (*storage.Banner, error) {
+	banner := &storage.Banner{}
+	if err := s.createNamed(ctx, bannersTable, name, &banner.ID, &banner.Name, &banner.CreatedAt); err != nil {
+		return nil, err
+	}
+	return banner, nil
+}
+
+func (s *Storage) GetBanner(ctx context.Context, bannerID int) (*storage.Banner, error) {
+	banner := &storage.Banner{}
+	if err := s.getNamed(ctx, bannersTable, bannerID, &banner.ID, &banner.Name, &banner.CreatedAt); err != nil {
+		return nil, err
+	}
+	return banner, nil
+}
+
+func (s *Storage) ListBanners(ctx context.Context) ([]storage.Banner, error) {
+	banners := make([]storage.Banner, 0)
+	err := s.listNamed(ctx, bannersTable, func(rows *sql.Rows) error {
+		var banner storage.Banner
+		if err := rows.Scan(&banner.ID, &banner.Name, &banner.CreatedAt); err != nil {
+			return err
+		}
+		banners = append(banners, banner)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return banners, nil
+}
+
+func (s *Storage) UpdateBanner(ctx context.Context, bannerID int, name string) (*storage.Banner, error) {
+	banner := &storage.Banner{}
+	if err := s.updateNamed(ctx, bannersTable, bannerID, name, &banner.ID, &banner.Name, &banner.CreatedAt); err != nil {
+		return nil, err
+	}
+	return banner, nil
+}
+
+func (s *Storage) DeleteBanner(ctx context.Context, bannerID int) error {
+	return s.deleteNamed(ctx, bannersTable, bannerID)
+}
+
+func (s *Storage) CreateUserGroup(ctx context.Context, name string) (*storage.UserGroup, error) {
+	group := &storage.UserGroup{}
+	if err := s.createNamed(ctx, userGroupsTable, name, &group.ID, &group.Name, &group.CreatedAt); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (s *Storage) GetUserGroup(ctx context.Context, userGroupID int) (*storage.UserGroup, error) {
+	group := &storage.UserGroup{}
+	if err := s.getNamed(ctx, userGroupsTable, userGroupID, &group.ID, &group.Name, &group.CreatedAt); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (s *Storage) ListUserGroups(ctx context.Context) ([]storage.UserGroup, error) {
+	groups := make([]storage.UserGroup, 0)
+	err := s.listNamed(ctx, userGroupsTable, func(rows *sql.Rows) error {
+		var group storage.UserGroup
+		if err := rows.Scan(&group.ID, &group.Name, &group.CreatedAt); err != nil {
+			return err
+		}
+		groups = append(groups, group)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (s *Storage) UpdateUserGroup(ctx context.Context, userGroupID int, name string) (*storage.UserGroup, error) {
+	group := &storage.UserGroup{}
+	err := s.updateNamed(ctx, userGroupsTable, userGroupID, name, &group.ID, &group.Name, &group.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (s *Storage) DeleteUserGroup(ctx context.Context, userGroupID int) error {
+	return s.deleteNamed(ctx, userGroupsTable, userGroupID)
+}
+
+func (s *Storage) createNamed(ctx context.Context, table, name string, dest ...any) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (name, created_at)
+		VALUES ($1, NOW())
+		RETURNING id, name, created_at;`, table)
+
+	return s.db.QueryRowContext(ctx, query, name).Scan(dest...)
+}
+
+func (s *Storage) getNamed(ctx context.Context, table string, id int, dest ...any) error {
+	query := fmt.Sprintf(`SELECT id, name, created_at FROM %s WHERE id = $1;`, table)
+
+	err := s.db.QueryRowContext(ctx, query, id).Scan(dest...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrNotFound
+	}
+	return err
+}
+
+func (s *Storage) listNamed(ctx context.Context, table string, scan func(rows *sql.Rows) error) error {
+	query := fmt.Sprintf(`SELECT id, name, created_at FROM %s ORDER BY id;`, table)
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (s *Storage) updateNamed(ctx context.Context, table string, id int, name string, dest ...any) error {
+	query := fmt.Sprintf(`
+		UPDATE %s SET name = $2
+		WHERE id = $1
+		RETURNING id, name, created_at;`, table)
+
+	err := s.db.QueryRowContext(ctx, query, id, name).Scan(dest...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrNotFound
+	}
+	return err
+}
+
+// deleteNamed удаляет запись справочника. Ротации, события и статистика
+// удаляются каскадно внешними ключами.
+func (s *Storage) deleteNamed(ctx context.Context, table string, id int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1;`, table)
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	stor "github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+func TestCreateSlot(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	createdAt := time.Now()
+
+	mock.ExpectQuery("INSERT INTO slots").
+		WithArgs("Main page").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).AddRow(4, "Main page", createdAt))
+
+	slot, err := storage.CreateSlot(context.Background(), "Main page")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if *slot != (stor.Slot{ID: 4, Name: "Main page", CreatedAt: createdAt}) {
+		t.Errorf("unexpected values in Slot: %+v", slot)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetBannerNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+
+	mock.ExpectQuery("SELECT id, name, created_at FROM banners").
+		WithArgs(100).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = storage.GetBanner(context.Background(), 100)
+	if !errors.Is(err, stor.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestListUserGroups(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	createdAt := time.Now()
+
+	mock.ExpectQuery("SELECT id, name, created_at FROM usergroups ORDER BY id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).
+			AddRow(1, "Groups 1", createdAt).
+			AddRow(2, "Groups 2", createdAt))
+
+	groups, err := storage.ListUserGroups(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(groups) != 2 || groups[1].Name != "Groups 2" {
+		t.Errorf("unexpected userGroups: %+v", groups)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUpdateSlot(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+
+	mock.ExpectQuery("UPDATE slots SET name").
+		WithArgs(1, "Sidebar").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).AddRow(1, "Sidebar", time.Now()))
+
+	slot, err := storage.UpdateSlot(context.Background(), 1, "Sidebar")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if slot.Name != "Sidebar" {
+		t.Errorf("unexpected slot name: %s", slot.Name)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDeleteUserGroupNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+
+	mock.ExpectExec("DELETE FROM usergroups").
+		WithArgs(100).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = storage.DeleteUserGroup(context.Background(), 100)
+	if !errors.Is(err, stor.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,75 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/lib/pq"
+)
+
+// insertOutbox сохраняет уведомление в outbox в транзакции события. Колонка payload имеет тип json,
+// а не jsonb, поэтому публикуются ровно те байты, что вернул Notification.Marshal.
+func insertOutbox(ctx context.Context, tx *sql.Tx, notification storage.Notification) error {
+	const query = `INSERT INTO outbox (payload, created_at) VALUES ($1, NOW());`
+
+	payload, err := notification.Marshal()
+	if err != nil {
+		return fmt.Errorf("cannot serialize notification: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, query, payload)
+	return err
+}
+
+// ClaimOutbox захватывает до limit неотправленных уведомлений на время lease.
+// Пока аренда не истекла, другие экземпляры сервиса эти записи не получат.
+func (s *Storage) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]storage.OutboxMessage, error) {
+	const query = `
+		UPDATE outbox SET locked_until = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE sent_at IS NULL AND (locked_until IS NULL OR locked_until < NOW())
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, payload, created_at;`
+
+	rows, err := s.db.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := make([]storage.OutboxMessage, 0)
+	for rows.Next() {
+		var msg storage.OutboxMessage
+		if err := rows.Scan(&msg.ID, &msg.Payload, &msg.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// UPDATE ... RETURNING не гарантирует порядок строк
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages, nil
+}
+
+// MarkOutboxSent удаляет отправленные уведомления: повторно они не публикуются, а хранение
+// всех отправленных раздувало бы таблицу без ограничения.
+func (s *Storage) MarkOutboxSent(ctx context.Context, ids []int64) error {
+	const query = `DELETE FROM outbox WHERE id = ANY($1);`
+
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := s.db.ExecContext(ctx, query, pq.Array(ids))
+	return err
+}
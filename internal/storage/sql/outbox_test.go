@@ -0,0 +1,67 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func TestClaimOutbox(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	createdAt := time.Now()
+
+	mock.ExpectQuery("UPDATE outbox SET locked_until(.+)FOR UPDATE SKIP LOCKED").
+		WithArgs(10, int64(30000)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payload", "created_at"}).
+			AddRow(2, []byte(`{"type_event":"click"}`), createdAt).
+			AddRow(1, []byte(`{"type_event":"impress"}`), createdAt))
+
+	messages, err := storage.ClaimOutbox(context.Background(), 10, 30*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(messages) != 2 || messages[0].ID != 1 || messages[1].ID != 2 {
+		t.Errorf("expected messages ordered by id, got: %+v", messages)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMarkOutboxSent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+
+	mock.ExpectExec("DELETE FROM outbox WHERE id").
+		WithArgs(pq.Array([]int64{1, 2})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	if err := storage.MarkOutboxSent(context.Background(), []int64{1, 2}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Пустой список не должен приводить к запросу
+	if err := storage.MarkOutboxSent(context.Background(), nil); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,108 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+// AddHourlyRollup увеличивает почасовые счетчики в одной транзакции с отметкой о сообщении,
+// поэтому повторная доставка того же сообщения не учитывается дважды.
+func (s *Storage) AddHourlyRollup(
+	ctx context.Context,
+	messageID string,
+	notification storage.Notification,
+) (bool, error) {
+	impressions, clicks, err := notification.Counters()
+	if err != nil {
+		return false, err
+	}
+
+	added := true
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		if messageID != "" {
+			const markQuery = `
+				INSERT INTO hourly_stats_messages (message_id)
+				VALUES ($1)
+				ON CONFLICT (message_id) DO NOTHING;`
+
+			res, err := tx.ExecContext(ctx, markQuery, messageID)
+			if err != nil {
+				return err
+			}
+			affected, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if affected == 0 {
+				added = false
+				return nil
+			}
+		}
+
+		const query = `
+			INSERT INTO hourly_stats (hour, slot_id, banner_id, usergroup_id, impressions, clicks)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (hour, slot_id, banner_id, usergroup_id) DO UPDATE
+			SET impressions = hourly_stats.impressions + EXCLUDED.impressions,
+				clicks      = hourly_stats.clicks + EXCLUDED.clicks;`
+
+		_, err := tx.ExecContext(ctx, query,
+			storage.RollupHour(notification.DateTime),
+			notification.SlotID,
+			notification.BannerID,
+			notification.UsergroupID,
+			impressions,
+			clicks,
+		)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return added, nil
+}
+
+// PurgeRollupMessages удаляет отметки сообщений, учтенных раньше before.
+func (s *Storage) PurgeRollupMessages(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM hourly_stats_messages WHERE processed_at < $1;`
+
+	res, err := s.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// HourlyRollups возвращает почасовые агрегаты. Нулевой SlotID означает все слоты.
+func (s *Storage) HourlyRollups(ctx context.Context, filter storage.StatsFilter) ([]storage.HourlyRollup, error) {
+	const query = `
+		SELECT hour, slot_id, banner_id, usergroup_id, impressions, clicks
+		FROM hourly_stats
+		WHERE ($1 = 0 OR slot_id = $1)
+			AND ($2 = 0 OR usergroup_id = $2)
+			AND ($3::timestamptz IS NULL OR hour >= $3)
+			AND ($4::timestamptz IS NULL OR hour < $4)
+		ORDER BY hour, slot_id, banner_id, usergroup_id;`
+
+	rows, err := s.db.QueryContext(ctx, query,
+		filter.SlotID, filter.UserGroupID, nullTime(filter.From), nullTime(filter.To))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rollups := make([]storage.HourlyRollup, 0)
+	for rows.Next() {
+		var r storage.HourlyRollup
+		if err := rows.Scan(&r.Hour, &r.SlotID, &r.BannerID, &r.UserGroupID, &r.Impressions, &r.Clicks); err != nil {
+			return nil, err
+		}
+		rollups = append(rollups, r)
+	}
+
+	return rollups, rows.Err()
+}
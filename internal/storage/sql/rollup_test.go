@@ -0,0 +1,105 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+func TestAddHourlyRollup(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	notification := clickNotification()
+	hour := time.Date(2023, 12, 15, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO hourly_stats_messages").
+		WithArgs("42").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO hourly_stats").
+		WithArgs(hour, 1, 2, 3, int64(0), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	added, err := storage.AddHourlyRollup(context.Background(), "42", notification)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !added {
+		t.Errorf("expected notification to be counted")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPurgeRollupMessages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	before := time.Date(2023, 12, 15, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("DELETE FROM hourly_stats_messages WHERE processed_at").
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	purged, err := storage.PurgeRollupMessages(context.Background(), before)
+	if err != nil || purged != 3 {
+		t.Errorf("expected 3 purged messages, got %d (%v)", purged, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAddHourlyRollupDuplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO hourly_stats_messages").
+		WithArgs("42").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	added, err := storage.AddHourlyRollup(context.Background(), "42", clickNotification())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if added {
+		t.Errorf("expected duplicate notification to be skipped")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func clickNotification() storage.Notification {
+	return storage.Notification{
+		TypeEvent:   storage.EventClick,
+		SlotID:      1,
+		BannerID:    2,
+		UsergroupID: 3,
+		DateTime:    time.Date(2023, 12, 15, 10, 56, 0, 0, time.UTC),
+	}
+}
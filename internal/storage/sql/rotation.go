@@ -0,0 +1,94 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+// withinCaps отбирает ротации, не исчерпавшие купленный объем. Дневной лимит доступен
+// пропорционально прошедшей доле суток, как в storage.Caps.DailyAllowance.
+const withinCaps = `
+	AND (r.max_impressions IS NULL OR r.served_impressions < r.max_impressions)
+	AND (r.max_clicks IS NULL OR r.served_clicks < r.max_clicks)
+	AND (r.max_daily_impressions IS NULL OR r.served_on IS DISTINCT FROM (NOW() AT TIME ZONE 'UTC')::date
+		OR r.today_impressions < GREATEST(CEIL(r.max_daily_impressions *
+			EXTRACT(EPOCH FROM (NOW() AT TIME ZONE 'UTC') - (NOW() AT TIME ZONE 'UTC')::date) / 86400), 1))`
+
+// countServed учитывает показы и переходы в объеме ротации и возвращает ограничения,
+// исчерпанные именно этими событиями. Если баннер уже убран из слота, ничего не учитывается.
+func countServed(ctx context.Context, tx *sql.Tx, slotID, bannerID, impressions, clicks int) ([]string, error) {
+	const query = `
+		UPDATE rotations
+		SET served_impressions = served_impressions + $3,
+			served_clicks = served_clicks + $4,
+			today_impressions = CASE WHEN served_on = (NOW() AT TIME ZONE 'UTC')::date
+				THEN today_impressions + $3 ELSE $3 END,
+			served_on = (NOW() AT TIME ZONE 'UTC')::date
+		WHERE slot_id = $1 AND banner_id = $2
+		RETURNING
+			COALESCE($3 > 0 AND served_impressions = max_impressions, false),
+			COALESCE($4 > 0 AND served_clicks = max_clicks, false),
+			COALESCE($3 > 0 AND today_impressions = max_daily_impressions, false);`
+
+	var impressionsCap, clicksCap, dailyCap bool
+	err := tx.QueryRowContext(ctx, query, slotID, bannerID, impressions, clicks).
+		Scan(&impressionsCap, &clicksCap, &dailyCap)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var reached []string
+	if impressionsCap {
+		reached = append(reached, storage.CapImpressions)
+	}
+	if clicksCap {
+		reached = append(reached, storage.CapClicks)
+	}
+	if dailyCap {
+		reached = append(reached, storage.CapDailyImpressions)
+	}
+	return reached, nil
+}
+
+// insertCapNotifications добавляет в outbox уведомления об исчерпанных ограничениях.
+func insertCapNotifications(ctx context.Context, tx *sql.Tx, event storage.Notification, caps []string) error {
+	for _, n := range storage.NewCapNotifications(event, caps) {
+		if err := insertOutbox(ctx, tx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nullInt64 превращает нулевое ограничение в NULL для запроса.
+func nullInt64(v int64) any {
+	if v == 0 {
+		return nil
+	}
+	return v
+}
+
+// lockSlotShares блокирует до конца транзакции изменение ротаций слотов, чтобы сумма
+// гарантированных долей проверялась и записывалась без гонки с другими транзакциями.
+// Слоты блокируются по возрастанию ID, чтобы транзакции не ждали друг друга по кругу.
+func lockSlotShares(ctx context.Context, tx *sql.Tx, slotIDs ...int) error {
+	const query = `SELECT pg_advisory_xact_lock($1);`
+
+	sort.Ints(slotIDs)
+	for i, slotID := range slotIDs {
+		if i > 0 && slotID == slotIDs[i-1] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, query, slotID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,435 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/lib/pq"
+)
+
+// errDryRun откатывает транзакцию пробного импорта.
+var errDryRun = errors.New("dry run")
+
+// ExportState возвращает снимок справочников, ротаций и счетчиков banner_stats, прочитанный
+// одной транзакцией. С кешем статистики приросты, еще не записанные в banner_stats, в снимок не попадают.
+func (s *Storage) ExportState(ctx context.Context) (*storage.State, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	state := &storage.State{}
+	err = exportNamed(ctx, tx, slotsTable, func(id int, name string, createdAt time.Time) {
+		state.Slots = append(state.Slots, storage.Slot{ID: id, Name: name, CreatedAt: createdAt})
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = exportNamed(ctx, tx, bannersTable, func(id int, name string, createdAt time.Time) {
+		state.Banners = append(state.Banners, storage.Banner{ID: id, Name: name, CreatedAt: createdAt})
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = exportNamed(ctx, tx, userGroupsTable, func(id int, name string, createdAt time.Time) {
+		group := storage.UserGroup{ID: id, Name: name, CreatedAt: createdAt}
+		state.UserGroups = append(state.UserGroups, storage.UserGroupState{UserGroup: group})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := exportFeatures(ctx, tx, state.UserGroups); err != nil {
+		return nil, err
+	}
+	if state.Rotations, err = exportRotations(ctx, tx); err != nil {
+		return nil, err
+	}
+	if state.Stats, err = exportStats(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func exportNamed(
+	ctx context.Context,
+	tx *sql.Tx,
+	table string,
+	add func(id int, name string, createdAt time.Time),
+) error {
+	query := fmt.Sprintf(`SELECT id, name, created_at FROM %s ORDER BY id;`, table)
+
+	return queryRows(ctx, tx, query, func(rows *sql.Rows) error {
+		var (
+			id        int
+			name      string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&id, &name, &createdAt); err != nil {
+			return err
+		}
+		add(id, name, createdAt)
+		return nil
+	})
+}
+
+// exportFeatures заполняет признаки групп, отсортированных по ID.
+func exportFeatures(ctx context.Context, tx *sql.Tx, groups []storage.UserGroupState) error {
+	const query = `SELECT usergroup_id, name, value FROM usergroup_features ORDER BY usergroup_id, name;`
+
+	index := make(map[int]int, len(groups))
+	for i, g := range groups {
+		index[g.ID] = i
+	}
+	return queryRows(ctx, tx, query, func(rows *sql.Rows) error {
+		var (
+			groupID int
+			name    string
+			value   float64
+		)
+		if err := rows.Scan(&groupID, &name, &value); err != nil {
+			return err
+		}
+		g := &groups[index[groupID]]
+		if g.Features == nil {
+			g.Features = make(storage.Features)
+		}
+		g.Features[name] = value
+		return nil
+	})
+}
+
+func exportRotations(ctx context.Context, tx *sql.Tx) ([]storage.RotationState, error) {
+	const query = `
+		SELECT slot_id, banner_id, starts_at, ends_at, paused,
+			max_impressions, max_clicks, max_daily_impressions, pinned, weight, min_share,
+			served_impressions, served_clicks, today_impressions, served_on, created_at
+		FROM rotations
+		ORDER BY slot_id, banner_id;`
+
+	rotations := make([]storage.RotationState, 0)
+	err := queryRows(ctx, tx, query, func(rows *sql.Rows) error {
+		var (
+			r                                   storage.RotationState
+			startsAt, endsAt, servedOn          sql.NullTime
+			maxImpressions, maxClicks, maxDaily sql.NullInt64
+		)
+		err := rows.Scan(&r.SlotID, &r.BannerID, &startsAt, &endsAt, &r.Paused,
+			&maxImpressions, &maxClicks, &maxDaily, &r.Settings.Pinned, &r.Settings.Weight, &r.Settings.MinShare,
+			&r.Served.Impressions, &r.Served.Clicks, &r.Served.DailyImpressions, &servedOn, &r.CreatedAt)
+		if err != nil {
+			return err
+		}
+		r.Settings.Flight = storage.Flight{StartsAt: startsAt.Time, EndsAt: endsAt.Time}
+		r.Settings.Caps = storage.Caps{
+			MaxImpressions:      maxImpressions.Int64,
+			MaxClicks:           maxClicks.Int64,
+			MaxDailyImpressions: maxDaily.Int64,
+		}
+		r.Served.Day = servedOn.Time
+		rotations = append(rotations, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rotations, nil
+}
+
+func exportStats(ctx context.Context, tx *sql.Tx) ([]storage.StatsState, error) {
+	const query = `
+		SELECT slot_id, banner_id, usergroup_id, impressions, clicks, decayed_impressions, decayed_clicks, decayed_at
+		FROM banner_stats
+		ORDER BY slot_id, banner_id, usergroup_id;`
+
+	stats := make([]storage.StatsState, 0)
+	err := queryRows(ctx, tx, query, func(rows *sql.Rows) error {
+		var st storage.StatsState
+		if err := rows.Scan(&st.SlotID, &st.BannerID, &st.UserGroupID, &st.Impressions, &st.Clicks,
+			&st.DecayedImpressions, &st.DecayedClicks, &st.DecayedAt); err != nil {
+			return err
+		}
+		stats = append(stats, st)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func queryRows(ctx context.Context, tx *sql.Tx, query string, scan func(rows *sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ImportState загружает снимок одной транзакцией с сохранением ID. Записи, которые уже есть
+// в хранилище, обрабатываются по opts.OnConflict; при пробном импорте транзакция откатывается.
+// Кеш статистики видит импортированные счетчики после очередной сверки с базой.
+func (s *Storage) ImportState(
+	ctx context.Context,
+	state *storage.State,
+	opts storage.ImportOptions,
+) (*storage.ImportResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := state.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &storage.ImportResult{}
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, slot := range state.Slots {
+			record := fmt.Sprintf("slot %d", slot.ID)
+			if _, err := importNamed(ctx, tx, opts, &result.Slots, record, slotsTable,
+				slot.ID, slot.Name, slot.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, banner := range state.Banners {
+			record := fmt.Sprintf("banner %d", banner.ID)
+			if _, err := importNamed(ctx, tx, opts, &result.Banners, record, bannersTable,
+				banner.ID, banner.Name, banner.CreatedAt); err != nil {
+				return err
+			}
+		}
+		for _, group := range state.UserGroups {
+			if err := importUserGroup(ctx, tx, opts, &result.UserGroups, group); err != nil {
+				return err
+			}
+		}
+		slotIDs := make([]int, 0, len(state.Rotations))
+		for _, r := range state.Rotations {
+			slotIDs = append(slotIDs, r.SlotID)
+		}
+		if err := lockSlotShares(ctx, tx, slotIDs...); err != nil {
+			return err
+		}
+		for _, r := range state.Rotations {
+			if err := importRotation(ctx, tx, opts, &result.Rotations, r); err != nil {
+				return err
+			}
+		}
+		if err := checkShares(ctx, tx); err != nil {
+			return err
+		}
+		for _, st := range state.Stats {
+			if err := importStats(ctx, tx, opts, &result.Stats, st); err != nil {
+				return err
+			}
+		}
+
+		if opts.DryRun {
+			return errDryRun
+		}
+		// setval не откатывается вместе с транзакцией, поэтому последовательности сдвигаются
+		// последним шагом, когда все записи вставлены, и не сдвигаются при пробном импорте
+		for _, table := range []string{slotsTable, bannersTable, userGroupsTable} {
+			if err := resetSequence(ctx, tx, table); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importRow вставляет запись запросом insert с ON CONFLICT DO NOTHING, а если запись уже есть,
+// поступает по политике opts: перезаписывает ее запросом update с теми же аргументами,
+// пропускает или прерывает импорт. Возвращает true, если запись записана.
+func importRow(
+	ctx context.Context,
+	tx *sql.Tx,
+	opts storage.ImportOptions,
+	counts *storage.ImportCounts,
+	record, insert, update string,
+	args ...any,
+) (bool, error) {
+	res, err := tx.ExecContext(ctx, insert, args...)
+	if err != nil {
+		return false, referenceError(err, record)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected > 0 {
+		counts.Count(true, false)
+		return true, nil
+	}
+
+	overwrite, err := opts.Resolve(record)
+	if err != nil {
+		return false, err
+	}
+	if overwrite {
+		if _, err := tx.ExecContext(ctx, update, args...); err != nil {
+			return false, err
+		}
+	}
+	counts.Count(false, overwrite)
+	return overwrite, nil
+}
+
+// referenceError превращает нарушение внешнего ключа в storage.ErrInvalidState.
+func referenceError(err error, record string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return fmt.Errorf("%w: %s references a missing slot, banner or usergroup", storage.ErrInvalidState, record)
+	}
+	return err
+}
+
+func importNamed(
+	ctx context.Context,
+	tx *sql.Tx,
+	opts storage.ImportOptions,
+	counts *storage.ImportCounts,
+	record, table string,
+	id int,
+	name string,
+	createdAt time.Time,
+) (bool, error) {
+	insert := fmt.Sprintf(`
+		INSERT INTO %s (id, name, created_at) VALUES ($1, $2, COALESCE($3::timestamp, NOW()))
+		ON CONFLICT (id) DO NOTHING;`, table)
+	update := fmt.Sprintf(`
+		UPDATE %s SET name = $2, created_at = COALESCE($3::timestamp, created_at)
+		WHERE id = $1;`, table)
+
+	return importRow(ctx, tx, opts, counts, record, insert, update, id, name, nullTime(createdAt))
+}
+
+// importUserGroup импортирует группу; признаки записанной группы заменяются признаками из снимка.
+func importUserGroup(
+	ctx context.Context,
+	tx *sql.Tx,
+	opts storage.ImportOptions,
+	counts *storage.ImportCounts,
+	group storage.UserGroupState,
+) error {
+	const (
+		deleteQuery = `DELETE FROM usergroup_features WHERE usergroup_id = $1;`
+		insertQuery = `INSERT INTO usergroup_features (usergroup_id, name, value) VALUES ($1, $2, $3);`
+	)
+
+	written, err := importNamed(ctx, tx, opts, counts, fmt.Sprintf("usergroup %d", group.ID), userGroupsTable,
+		group.ID, group.Name, group.CreatedAt)
+	if err != nil || !written {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, deleteQuery, group.ID); err != nil {
+		return err
+	}
+	for _, name := range sortedNames(group.Features) {
+		if _, err := tx.ExecContext(ctx, insertQuery, group.ID, name, group.Features[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetSequence продолжает автоинкремент таблицы после импортированных ID.
+func resetSequence(ctx context.Context, tx *sql.Tx, table string) error {
+	query := fmt.Sprintf(`
+		SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE(MAX(id), 1), MAX(id) IS NOT NULL)
+		FROM %[1]s;`, table)
+
+	_, err := tx.ExecContext(ctx, query)
+	return err
+}
+
+func importRotation(
+	ctx context.Context,
+	tx *sql.Tx,
+	opts storage.ImportOptions,
+	counts *storage.ImportCounts,
+	r storage.RotationState,
+) error {
+	const insert = `
+		INSERT INTO rotations
+		(slot_id, banner_id, starts_at, ends_at, paused, max_impressions, max_clicks, max_daily_impressions,
+			pinned, weight, min_share, served_impressions, served_clicks, today_impressions, served_on, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, COALESCE($16::timestamp, NOW()))
+		ON CONFLICT (slot_id, banner_id) DO NOTHING;`
+	const update = `
+		UPDATE rotations
+		SET starts_at = $3, ends_at = $4, paused = $5,
+			max_impressions = $6, max_clicks = $7, max_daily_impressions = $8,
+			pinned = $9, weight = $10, min_share = $11,
+			served_impressions = $12, served_clicks = $13, today_impressions = $14, served_on = $15,
+			created_at = COALESCE($16::timestamp, created_at)
+		WHERE slot_id = $1 AND banner_id = $2;`
+
+	settings, caps := r.Settings, r.Settings.Caps
+	_, err := importRow(ctx, tx, opts, counts, fmt.Sprintf("rotation %d/%d", r.SlotID, r.BannerID), insert, update,
+		r.SlotID, r.BannerID, nullTime(settings.Flight.StartsAt), nullTime(settings.Flight.EndsAt), r.Paused,
+		nullInt64(caps.MaxImpressions), nullInt64(caps.MaxClicks), nullInt64(caps.MaxDailyImpressions),
+		settings.Pinned, settings.Weight, settings.MinShare,
+		r.Served.Impressions, r.Served.Clicks, r.Served.DailyImpressions, nullTime(r.Served.Day), nullTime(r.CreatedAt))
+	return err
+}
+
+// checkShares проверяет, что после импорта гарантированные доли слотов не превышают 100%.
+func checkShares(ctx context.Context, tx *sql.Tx) error {
+	const query = `
+		SELECT slot_id FROM rotations
+		GROUP BY slot_id
+		HAVING SUM(min_share) > 1 + $1::float8
+		ORDER BY slot_id
+		LIMIT 1;`
+
+	var slotID int
+	err := tx.QueryRowContext(ctx, query, storage.ShareTolerance).Scan(&slotID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: slot %d", storage.ErrShareExceeded, slotID)
+}
+
+func importStats(
+	ctx context.Context,
+	tx *sql.Tx,
+	opts storage.ImportOptions,
+	counts *storage.ImportCounts,
+	st storage.StatsState,
+) error {
+	const insert = `
+		INSERT INTO banner_stats
+		(slot_id, banner_id, usergroup_id, impressions, clicks, decayed_impressions, decayed_clicks, decayed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8::timestamptz, NOW()))
+		ON CONFLICT (slot_id, banner_id, usergroup_id) DO NOTHING;`
+	const update = `
+		UPDATE banner_stats
+		SET impressions = $4, clicks = $5, decayed_impressions = $6, decayed_clicks = $7,
+			decayed_at = COALESCE($8::timestamptz, NOW())
+		WHERE slot_id = $1 AND banner_id = $2 AND usergroup_id = $3;`
+
+	record := fmt.Sprintf("stats %d/%d/%d", st.SlotID, st.BannerID, st.UserGroupID)
+	_, err := importRow(ctx, tx, opts, counts, record, insert, update,
+		st.SlotID, st.BannerID, st.UserGroupID, st.Impressions, st.Clicks,
+		st.DecayedImpressions, st.DecayedClicks, nullTime(st.DecayedAt))
+	return err
+}
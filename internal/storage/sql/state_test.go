@@ -0,0 +1,197 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	stor "github.com/dianapovarnitsina/banners-rotation/internal/storage"
+	"github.com/lib/pq"
+)
+
+func TestExportState(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	named := func() *sqlmock.Rows { return sqlmock.NewRows([]string{"id", "name", "created_at"}) }
+
+	// Все таблицы читаются одной транзакцией
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, name, created_at FROM slots").WillReturnRows(named().AddRow(1, "Main page", at))
+	mock.ExpectQuery("SELECT id, name, created_at FROM banners").WillReturnRows(named().AddRow(2, "Sale", at))
+	mock.ExpectQuery("SELECT id, name, created_at FROM usergroups").WillReturnRows(named().AddRow(3, "Students", at))
+	mock.ExpectQuery("SELECT usergroup_id, name, value FROM usergroup_features").
+		WillReturnRows(sqlmock.NewRows([]string{"usergroup_id", "name", "value"}).AddRow(3, "age", 0.5))
+	mock.ExpectQuery("SELECT (.+) FROM rotations").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"slot_id", "banner_id", "starts_at", "ends_at", "paused",
+			"max_impressions", "max_clicks", "max_daily_impressions", "pinned", "weight", "min_share",
+			"served_impressions", "served_clicks", "today_impressions", "served_on", "created_at",
+		}).AddRow(1, 2, nil, nil, true, 1000, nil, nil, false, 0.0, 0.2, 10, 1, 4, at, at))
+	mock.ExpectQuery("SELECT (.+) FROM banner_stats").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"slot_id", "banner_id", "usergroup_id", "impressions", "clicks",
+			"decayed_impressions", "decayed_clicks", "decayed_at",
+		}).AddRow(1, 2, 3, 10, 1, 7.5, 0.75, at))
+	mock.ExpectRollback()
+
+	state, err := storage.ExportState(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &stor.State{
+		Slots:   []stor.Slot{{ID: 1, Name: "Main page", CreatedAt: at}},
+		Banners: []stor.Banner{{ID: 2, Name: "Sale", CreatedAt: at}},
+		UserGroups: []stor.UserGroupState{{
+			UserGroup: stor.UserGroup{ID: 3, Name: "Students", CreatedAt: at},
+			Features:  stor.Features{"age": 0.5},
+		}},
+		Rotations: []stor.RotationState{{
+			SlotID:    1,
+			BannerID:  2,
+			Settings:  stor.RotationSettings{Caps: stor.Caps{MaxImpressions: 1000}, MinShare: 0.2},
+			Paused:    true,
+			Served:    stor.Served{Impressions: 10, Clicks: 1, DailyImpressions: 4, Day: at},
+			CreatedAt: at,
+		}},
+		Stats: []stor.StatsState{{
+			SlotID: 1, BannerID: 2, UserGroupID: 3, Impressions: 10, Clicks: 1,
+			DecayedImpressions: 7.5, DecayedClicks: 0.75, DecayedAt: at,
+		}},
+	}
+	if !reflect.DeepEqual(state, expected) {
+		t.Errorf("expected %+v, got %+v", expected, state)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestImportState(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	ctx := context.Background()
+	state := &stor.State{
+		Slots:     []stor.Slot{{ID: 4, Name: "Footer"}},
+		Rotations: []stor.RotationState{{SlotID: 4, BannerID: 1}},
+	}
+
+	// Существующая ротация перезаписывается, счетчики автоинкремента сдвигаются за импортированные ID
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO slots (.+) ON CONFLICT").
+		WithArgs(4, "Footer", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// Доли слотов импортируемых ротаций не меняются параллельно
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO rotations (.+) ON CONFLICT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE rotations").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT slot_id FROM rotations").
+		WithArgs(stor.ShareTolerance).
+		WillReturnRows(sqlmock.NewRows([]string{"slot_id"}))
+	// setval не откатывается, поэтому выполняется после всех вставок
+	for _, table := range []string{"slots", "banners", "usergroups"} {
+		mock.ExpectExec("SELECT setval\\(pg_get_serial_sequence\\('" + table + "'").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	result, err := storage.ImportState(ctx, state, stor.ImportOptions{OnConflict: stor.ConflictOverwrite})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := stor.ImportResult{Slots: stor.ImportCounts{Created: 1}, Rotations: stor.ImportCounts{Updated: 1}}
+	if *result != expected {
+		t.Errorf("expected %+v, got %+v", expected, *result)
+	}
+
+	// Пробный импорт откатывается и не трогает автоинкремент
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO slots (.+) ON CONFLICT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO rotations (.+) ON CONFLICT").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT slot_id FROM rotations").WillReturnRows(sqlmock.NewRows([]string{"slot_id"}))
+	mock.ExpectRollback()
+
+	result, err = storage.ImportState(ctx, state, stor.ImportOptions{OnConflict: stor.ConflictSkip, DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = stor.ImportResult{Slots: stor.ImportCounts{Skipped: 1}, Rotations: stor.ImportCounts{Created: 1}}
+	if *result != expected {
+		t.Errorf("expected %+v, got %+v", expected, *result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestImportStateErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	ctx := context.Background()
+
+	// Конфликт с политикой fail откатывает импорт
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO banners (.+) ON CONFLICT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err = storage.ImportState(ctx, &stor.State{Banners: []stor.Banner{{ID: 1, Name: "Sale"}}}, stor.ImportOptions{})
+	if !errors.Is(err, stor.ErrImportConflict) {
+		t.Errorf("expected ErrImportConflict, got: %v", err)
+	}
+
+	// Статистика удаленного слота нарушает внешний ключ
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT slot_id FROM rotations").WillReturnRows(sqlmock.NewRows([]string{"slot_id"}))
+	mock.ExpectExec("INSERT INTO banner_stats (.+) ON CONFLICT").
+		WillReturnError(&pq.Error{Code: "23503", Message: "violates foreign key constraint"})
+	mock.ExpectRollback()
+
+	state := &stor.State{Stats: []stor.StatsState{{SlotID: 9, BannerID: 1, UserGroupID: 1, Impressions: 1}}}
+	_, err = storage.ImportState(ctx, state, stor.ImportOptions{DryRun: true})
+	if !errors.Is(err, stor.ErrInvalidState) {
+		t.Errorf("expected ErrInvalidState, got: %v", err)
+	}
+
+	// Сумма гарантированных долей слота после импорта больше 100%
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT slot_id FROM rotations").
+		WillReturnRows(sqlmock.NewRows([]string{"slot_id"}).AddRow(2))
+	mock.ExpectRollback()
+
+	_, err = storage.ImportState(ctx, &stor.State{}, stor.ImportOptions{DryRun: true})
+	if !errors.Is(err, stor.ErrShareExceeded) {
+		t.Errorf("expected ErrShareExceeded, got: %v", err)
+	}
+
+	// Повторяющиеся ID отклоняются без обращения к базе
+	state = &stor.State{Slots: []stor.Slot{{ID: 1, Name: "A"}, {ID: 1, Name: "B"}}}
+	if _, err := storage.ImportState(ctx, state, stor.ImportOptions{}); !errors.Is(err, stor.ErrInvalidState) {
+		t.Errorf("expected ErrInvalidState, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,83 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+// BannerStats возвращает показы и клики баннеров, находящихся в ротации слота.
+// Без временного окна статистика берется из счетчиков banner_stats,
+// с окном - считается по таблицам событий. Время событий хранится с часовым поясом,
+// поэтому границы окна сравниваются как моменты времени, независимо от пояса сессии.
+func (s *Storage) BannerStats(ctx context.Context, filter storage.StatsFilter) ([]storage.BannerStatistics, error) {
+	const countersQuery = `
+		SELECT
+			r.banner_id,
+			COALESCE(SUM(bs.impressions), 0) AS impressions,
+			COALESCE(SUM(bs.clicks), 0) AS clicks
+		FROM rotations r
+		LEFT JOIN banner_stats bs
+			ON bs.slot_id = r.slot_id AND bs.banner_id = r.banner_id AND ($2 = 0 OR bs.usergroup_id = $2)
+		WHERE r.slot_id = $1
+		GROUP BY r.banner_id
+		ORDER BY r.banner_id;`
+
+	const windowQuery = `
+		SELECT
+			r.banner_id,
+			(SELECT COUNT(*) FROM impressions i
+				WHERE i.slot_id = r.slot_id AND i.banner_id = r.banner_id
+				AND ($2 = 0 OR i.usergroup_id = $2)
+				AND ($3::timestamptz IS NULL OR i.created_at >= $3)
+				AND ($4::timestamptz IS NULL OR i.created_at < $4)) AS impressions,
+			(SELECT COUNT(*) FROM clicks c
+				WHERE c.slot_id = r.slot_id AND c.banner_id = r.banner_id
+				AND ($2 = 0 OR c.usergroup_id = $2)
+				AND ($3::timestamptz IS NULL OR c.created_at >= $3)
+				AND ($4::timestamptz IS NULL OR c.created_at < $4)) AS clicks
+		FROM rotations r
+		WHERE r.slot_id = $1
+		ORDER BY r.banner_id;`
+
+	query := countersQuery
+	args := []any{filter.SlotID, filter.UserGroupID}
+	if filter.HasWindow() {
+		query = windowQuery
+		args = append(args, nullTime(filter.From), nullTime(filter.To))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make([]storage.BannerStatistics, 0)
+	for rows.Next() {
+		var bnr storage.BannerStatistics
+		if err := rows.Scan(&bnr.BannerID, &bnr.Impressions, &bnr.Clicks); err != nil {
+			return nil, err
+		}
+		stats = append(stats, bnr)
+	}
+
+	return stats, rows.Err()
+}
+
+// nullTime превращает нулевое время в NULL для запроса.
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// nullString превращает пустую строку в NULL для запроса.
+func nullString(v string) any {
+	if v == "" {
+		return nil
+	}
+	return v
+}
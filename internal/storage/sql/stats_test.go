@@ -0,0 +1,67 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	stor "github.com/dianapovarnitsina/banners-rotation/internal/storage"
+)
+
+func TestBannerStatsFromCounters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+
+	mock.ExpectQuery("FROM rotations r LEFT JOIN banner_stats").
+		WithArgs(1, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"banner_id", "impressions", "clicks"}).
+			AddRow(1, 100, 7).
+			AddRow(4, 50, 1))
+
+	stats, err := storage.BannerStats(context.Background(), stor.StatsFilter{SlotID: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(stats) != 2 || stats[0] != (stor.BannerStatistics{BannerID: 1, Impressions: 100, Clicks: 7}) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestBannerStatsInWindow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	from := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`FROM impressions i(.+)\$3::timestamptz(.+)FROM clicks c`).
+		WithArgs(1, 2, from, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"banner_id", "impressions", "clicks"}).AddRow(1, 10, 2))
+
+	stats, err := storage.BannerStats(context.Background(), stor.StatsFilter{SlotID: 1, UserGroupID: 2, From: from})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(stats) != 1 || stats[0].Clicks != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,230 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dianapovarnitsina/banners-rotation/internal/statscache"
+	"github.com/lib/pq"
+)
+
+var _ statscache.Store = (*Storage)(nil)
+
+// UseStatsCache переключает счетчики бандита на кеш: события увеличивают его после фиксации
+// транзакции, а выбор баннера берет из него счетчики вместо banner_stats. Записью приростов
+// в banner_stats занимается statscache.Flusher. Для окна статистики события по-прежнему
+// считаются по таблицам событий.
+func (s *Storage) UseStatsCache(cache *statscache.Cache) {
+	s.stats = cache
+}
+
+// SetOrigin задает идентификатор экземпляра, которым помечаются его уведомления в outbox.
+// По нему экземпляр отличает свои события от событий других экземпляров (см. statscache.Subscriber).
+func (s *Storage) SetOrigin(origin string) {
+	s.origin = origin
+}
+
+// LoadStats возвращает счетчики banner_stats и отметки записи экземпляров из одного снимка базы:
+// отметка экземпляра не может опередить его приросты.
+func (s *Storage) LoadStats(ctx context.Context) (statscache.Snapshot, error) {
+	const statsQuery = `
+		SELECT slot_id, banner_id, usergroup_id, impressions, clicks, decayed_impressions, decayed_clicks,
+			EXTRACT(EPOCH FROM NOW() - decayed_at)::float8 AS age
+		FROM banner_stats;`
+
+	const watermarksQuery = `SELECT origin, seq FROM stats_watermarks;`
+
+	snapshot := statscache.Snapshot{
+		Counters:   make(map[statscache.Key]statscache.Counters),
+		Watermarks: make(map[string]uint64),
+	}
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return snapshot, fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, statsQuery)
+	if err != nil {
+		return snapshot, err
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	for rows.Next() {
+		var (
+			key      statscache.Key
+			counters statscache.Counters
+			age      float64
+		)
+		err := rows.Scan(&key.SlotID, &key.BannerID, &key.UserGroupID, &counters.Impressions, &counters.Clicks,
+			&counters.DecayedImpressions, &counters.DecayedClicks, &age)
+		if err != nil {
+			return snapshot, err
+		}
+		// Возраст считается в базе, чтобы не зависеть от расхождения часов и часового пояса
+		counters.At = now.Add(-time.Duration(age * float64(time.Second)))
+		snapshot.Counters[key] = counters
+	}
+	if err := rows.Err(); err != nil {
+		return snapshot, err
+	}
+
+	watermarks, err := tx.QueryContext(ctx, watermarksQuery)
+	if err != nil {
+		return snapshot, err
+	}
+	defer watermarks.Close()
+
+	for watermarks.Next() {
+		var (
+			origin string
+			seq    int64
+		)
+		if err := watermarks.Scan(&origin, &seq); err != nil {
+			return snapshot, err
+		}
+		snapshot.Watermarks[origin] = uint64(seq)
+	}
+	return snapshot, watermarks.Err()
+}
+
+// FlushStats добавляет приросты к banner_stats одной транзакцией: по запросу на каждый период
+// полураспада, так как затухание старого значения зависит от слота. Тройки обновляются в порядке
+// ключа, чтобы записи нескольких экземпляров не блокировали друг друга. В той же транзакции
+// сохраняется отметка записи экземпляра, если он задан (см. SetOrigin), и удаляются отметки
+// экземпляров, которые давно ничего не записывали.
+func (s *Storage) FlushStats(ctx context.Context, deltas []statscache.Delta, seq uint64) error {
+	const query = `
+		INSERT INTO banner_stats AS bs
+		(slot_id, banner_id, usergroup_id, impressions, clicks, decayed_impressions, decayed_clicks, decayed_at)
+		SELECT d.slot_id, d.banner_id, d.usergroup_id, d.impressions, d.clicks,
+			d.decayed_impressions, d.decayed_clicks, NOW()
+		FROM unnest($1::int[], $2::int[], $3::int[], $4::bigint[], $5::bigint[], $6::float8[], $7::float8[])
+			AS d(slot_id, banner_id, usergroup_id, impressions, clicks, decayed_impressions, decayed_clicks)
+		JOIN slots ON slots.id = d.slot_id
+		JOIN banners ON banners.id = d.banner_id
+		JOIN usergroups ON usergroups.id = d.usergroup_id
+		ORDER BY d.slot_id, d.banner_id, d.usergroup_id
+		ON CONFLICT (slot_id, banner_id, usergroup_id) DO UPDATE
+		SET impressions = bs.impressions + EXCLUDED.impressions,
+			clicks = bs.clicks + EXCLUDED.clicks,
+			decayed_impressions = bs.decayed_impressions * ` + flushDecayFactor + ` + EXCLUDED.decayed_impressions,
+			decayed_clicks = bs.decayed_clicks * ` + flushDecayFactor + ` + EXCLUDED.decayed_clicks,
+			decayed_at = EXCLUDED.decayed_at;`
+
+	sort.Slice(deltas, func(i, j int) bool {
+		a, b := deltas[i].Key, deltas[j].Key
+		if a.SlotID != b.SlotID {
+			return a.SlotID < b.SlotID
+		}
+		if a.BannerID != b.BannerID {
+			return a.BannerID < b.BannerID
+		}
+		return a.UserGroupID < b.UserGroupID
+	})
+
+	now := time.Now()
+	batches := make(map[float64]*statsBatch)
+	halfLives := make([]float64, 0)
+	for _, d := range deltas {
+		decay := s.strategies.DecayForSlot(d.SlotID)
+		halfLife := decay.HalfLife.Seconds()
+		batch, ok := batches[halfLife]
+		if !ok {
+			batch = &statsBatch{}
+			batches[halfLife] = batch
+			halfLives = append(halfLives, halfLife)
+		}
+		decayedImpressions, decayedClicks := d.DecayedAt(now, decay)
+		batch.add(d, decayedImpressions, decayedClicks)
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, halfLife := range halfLives {
+			b := batches[halfLife]
+			_, err := tx.ExecContext(ctx, query,
+				pq.Array(b.slotIDs), pq.Array(b.bannerIDs), pq.Array(b.userGroupIDs),
+				pq.Array(b.impressions), pq.Array(b.clicks),
+				pq.Array(b.decayedImpressions), pq.Array(b.decayedClicks), halfLife)
+			if err != nil {
+				return err
+			}
+		}
+		if s.origin == "" || seq == 0 {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, watermarkQuery, s.origin, int64(seq)); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, expiredWatermarksQuery, watermarkTTL.Seconds())
+		return err
+	})
+}
+
+// watermarkTTL - сколько хранится отметка экземпляра после его последней записи. Экземпляр
+// с новым запуском получает новый идентификатор, поэтому старые отметки больше не нужны.
+const watermarkTTL = 24 * time.Hour
+
+const watermarkQuery = `
+	INSERT INTO stats_watermarks AS w (origin, seq, updated_at)
+	VALUES ($1, $2, NOW())
+	ON CONFLICT (origin) DO UPDATE
+	SET seq = GREATEST(w.seq, EXCLUDED.seq), updated_at = EXCLUDED.updated_at;`
+
+const expiredWatermarksQuery = `
+	DELETE FROM stats_watermarks WHERE updated_at < NOW() - make_interval(secs => $1);`
+
+// flushDecayFactor - множитель затухания сохраненного значения до момента записи; без периода полураспада - 1.
+const flushDecayFactor = `
+	CASE WHEN $8::float8 > 0
+		THEN power(0.5, GREATEST(EXTRACT(EPOCH FROM EXCLUDED.decayed_at - bs.decayed_at), 0)::float8 / $8)
+		ELSE 1
+	END`
+
+// statsBatch - приросты с одинаковым периодом полураспада в виде массивов для unnest.
+type statsBatch struct {
+	slotIDs, bannerIDs, userGroupIDs []int64
+	impressions, clicks              []int64
+	decayedImpressions               []float64
+	decayedClicks                    []float64
+}
+
+func (b *statsBatch) add(d statscache.Delta, decayedImpressions, decayedClicks float64) {
+	b.slotIDs = append(b.slotIDs, int64(d.SlotID))
+	b.bannerIDs = append(b.bannerIDs, int64(d.BannerID))
+	b.userGroupIDs = append(b.userGroupIDs, int64(d.UserGroupID))
+	b.impressions = append(b.impressions, d.Impressions)
+	b.clicks = append(b.clicks, d.Clicks)
+	b.decayedImpressions = append(b.decayedImpressions, decayedImpressions)
+	b.decayedClicks = append(b.decayedClicks, decayedClicks)
+}
+
+// reserveStats выдает номер событию в кеше статистики до начала транзакции; 0 - кеш выключен.
+// После транзакции номер передается в cacheStats или, если событие не записано, в releaseStats.
+func (s *Storage) reserveStats() uint64 {
+	if s.stats == nil {
+		return 0
+	}
+	return s.stats.Reserve()
+}
+
+// releaseStats освобождает номер незаписанного события.
+func (s *Storage) releaseStats(seq uint64) {
+	if s.stats == nil {
+		return
+	}
+	s.stats.Release(seq)
+}
+
+// cacheStats учитывает событие с номером seq в кеше статистики, если он включен.
+func (s *Storage) cacheStats(seq uint64, slotID, bannerID, userGroupID, impressions, clicks int) {
+	if s.stats == nil {
+		return
+	}
+	key := statscache.Key{SlotID: slotID, BannerID: bannerID, UserGroupID: userGroupID}
+	s.stats.AddReserved(seq, key, int64(impressions), int64(clicks), time.Now())
+}
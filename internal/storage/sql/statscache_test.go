@@ -0,0 +1,177 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/dianapovarnitsina/banners-rotation/internal/statscache"
+	"github.com/lib/pq"
+)
+
+func TestLoadStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+
+	// Счетчики и отметки читаются одним снимком
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM banner_stats").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"slot_id", "banner_id", "usergroup_id", "impressions", "clicks",
+			"decayed_impressions", "decayed_clicks", "age",
+		}).AddRow(1, 2, 3, 10, 1, 8.5, 0.5, 3600.0))
+	mock.ExpectQuery("SELECT origin, seq FROM stats_watermarks").
+		WillReturnRows(sqlmock.NewRows([]string{"origin", "seq"}).AddRow("instance-2", 42))
+	mock.ExpectRollback()
+
+	snapshot, err := storage.LoadStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if seq := snapshot.Watermarks["instance-2"]; seq != 42 {
+		t.Errorf("expected watermark 42, got %d", seq)
+	}
+	counters := snapshot.Counters[statscache.Key{SlotID: 1, BannerID: 2, UserGroupID: 3}]
+	if counters.Impressions != 10 || counters.Clicks != 1 || counters.DecayedImpressions != 8.5 {
+		t.Errorf("unexpected counters: %+v", counters)
+	}
+	if age := time.Since(counters.At); age < time.Hour || age > time.Hour+time.Minute {
+		t.Errorf("unexpected decayed_at age: %s", age)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestFlushStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	selector := multiarmedbandit.NewSelector(nil)
+	selector.SetSlotDecay(2, multiarmedbandit.Decay{HalfLife: time.Hour})
+	storage := &Storage{db: db, strategies: selector, origin: "instance-1"}
+	now := time.Now()
+
+	// Приросты слотов с разным периодом полураспада пишутся разными запросами одной транзакции
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO banner_stats AS bs (.+) FROM unnest").
+		WithArgs(pq.Array([]int64{1, 1}), pq.Array([]int64{1, 2}), pq.Array([]int64{3, 3}),
+			pq.Array([]int64{5, 1}), pq.Array([]int64{1, 0}),
+			pq.Array([]float64{5, 1}), pq.Array([]float64{1, 0}), 0.0).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO banner_stats AS bs (.+) FROM unnest").
+		WithArgs(pq.Array([]int64{2}), pq.Array([]int64{1}), pq.Array([]int64{3}),
+			pq.Array([]int64{2}), pq.Array([]int64{0}),
+			sqlmock.AnyArg(), pq.Array([]float64{0}), time.Hour.Seconds()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// Отметка записи экземпляра сохраняется вместе с приростами
+	mock.ExpectExec("INSERT INTO stats_watermarks").
+		WithArgs("instance-1", 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM stats_watermarks").
+		WithArgs(watermarkTTL.Seconds()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err = storage.FlushStats(context.Background(), []statscache.Delta{
+		{
+			Key:      statscache.Key{SlotID: 1, BannerID: 2, UserGroupID: 3},
+			Counters: statscache.Counters{Impressions: 1, DecayedImpressions: 1, At: now},
+		},
+		{
+			Key:      statscache.Key{SlotID: 2, BannerID: 1, UserGroupID: 3},
+			Counters: statscache.Counters{Impressions: 2, DecayedImpressions: 2, At: now},
+		},
+		{
+			Key: statscache.Key{SlotID: 1, BannerID: 1, UserGroupID: 3},
+			Counters: statscache.Counters{
+				Impressions: 5, Clicks: 1, DecayedImpressions: 5, DecayedClicks: 1, At: now,
+			},
+		},
+	}, 7)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPickBannerStatsCache(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	cache := statscache.New(0, nil)
+	storage := &Storage{db: db}
+	storage.UseStatsCache(cache)
+	storage.SetOrigin("instance-1")
+	ctx := context.Background()
+
+	// Счетчики берутся из кеша, из базы читаются только активные ротации
+	cache.Add(statscache.Key{SlotID: 2, BannerID: 1, UserGroupID: 3}, 100, 1, time.Now())
+	cache.Add(statscache.Key{SlotID: 2, BannerID: 2, UserGroupID: 3}, 100, 60, time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM rotations r WHERE").
+		WithArgs(2).
+		WillReturnRows(candidateRows().
+			AddRow(1, false, 0.0, 0.0, 0, 0.0, 0.0).
+			AddRow(2, false, 0.0, 0.0, 0, 0.0, 0.0))
+
+	bannerID, err := storage.PickBanner(ctx, 2, 3, nil)
+	if err != nil || bannerID != 2 {
+		t.Errorf("expected banner 2, got %d (%v)", bannerID, err)
+	}
+
+	// Показ увеличивает кеш после фиксации транзакции, а не banner_stats
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO impressions").
+		WithArgs(2, 1, 3, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slot_id", "banner_id", "usergroup_id", "created_at"}).
+			AddRow(1, 2, 1, 3, time.Now()))
+	mock.ExpectQuery("UPDATE rotations").
+		WithArgs(2, 1, 1, 0).
+		WillReturnRows(servedRows(false, false, false))
+	// Уведомление помечено экземпляром и номером события, чтобы другие экземпляры учли его в своих кешах
+	mock.ExpectExec("INSERT INTO outbox").
+		WithArgs(payloadContains(`"origin":"instance-1","seq":1`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if _, err := storage.ImpressBanner(ctx, 1, 2, 3); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if counters := cache.Get(statscache.Key{SlotID: 2, BannerID: 1, UserGroupID: 3}); counters.Impressions != 101 {
+		t.Errorf("expected 101 cached impressions, got %d", counters.Impressions)
+	}
+	if _, seq := cache.Drain(); seq != 1 {
+		t.Errorf("expected watermark 1, got %d", seq)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// payloadContains проверяет, что сериализованное уведомление содержит фрагмент.
+type payloadContains string
+
+func (p payloadContains) Match(v driver.Value) bool {
+	payload, ok := v.([]byte)
+	return ok && strings.Contains(string(payload), string(p))
+}
@@ -5,17 +5,34 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/dianapovarnitsina/banners-rotation/interfaces"
 	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
+	"github.com/dianapovarnitsina/banners-rotation/internal/statscache"
 	"github.com/dianapovarnitsina/banners-rotation/internal/storage"
 	_ "github.com/lib/pq" // Blank import for side effects
 	"github.com/pressly/goose/v3"
 )
 
-var errNoBannersForGivenSlot = errors.New("no banners for a given slot")
+var _ interfaces.Storage = (*Storage)(nil)
+
+// activeRotation отбирает ротации, которые сейчас не приостановлены, находятся в периоде показа
+// и не исчерпали купленный объем.
+const activeRotation = `
+	NOT r.paused
+	AND (r.starts_at IS NULL OR r.starts_at <= NOW())
+	AND (r.ends_at IS NULL OR r.ends_at > NOW())` + withinCaps
 
 type Storage struct {
-	db *sql.DB
+	db         *sql.DB
+	strategies *multiarmedbandit.Selector
+	stats      *statscache.Cache
+	origin     string
+}
+
+func New(strategies *multiarmedbandit.Selector) *Storage {
+	return &Storage{strategies: strategies}
 }
 
 func (s *Storage) Migrate(ctx context.Context, migrate string) (err error) {
@@ -52,15 +69,107 @@ func (s *Storage) Close(ctx context.Context) error {
 	return nil
 }
 
-func (s *Storage) AddBanner(ctx context.Context, bannerID, slotID int) error {
+// AddBanner добавляет баннер в ротацию слота на условиях settings. Если гарантированные доли
+// слота вместе с новой превысят 100%, возвращается storage.ErrShareExceeded.
+func (s *Storage) AddBanner(ctx context.Context, bannerID, slotID int, settings storage.RotationSettings) error {
 	const query = `
-		INSERT INTO rotations (slot_id, banner_id, created_at)
-		VALUES ($1, $2, NOW());
+		INSERT INTO rotations
+		(slot_id, banner_id, starts_at, ends_at, max_impressions, max_clicks, max_daily_impressions,
+			pinned, weight, min_share, created_at)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW()
+		WHERE (SELECT COALESCE(SUM(min_share), 0) FROM rotations WHERE slot_id = $1) + $10::float8 <= 1 + $11::float8;
 	`
-	_, err := s.db.ExecContext(ctx, query, slotID, bannerID)
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	flight, caps := settings.Flight, settings.Caps
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := lockSlotShares(ctx, tx, slotID); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, query, slotID, bannerID,
+			nullTime(flight.StartsAt), nullTime(flight.EndsAt),
+			nullInt64(caps.MaxImpressions), nullInt64(caps.MaxClicks), nullInt64(caps.MaxDailyImpressions),
+			settings.Pinned, settings.Weight, settings.MinShare, storage.ShareTolerance)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return storage.ErrShareExceeded
+		}
+		return nil
+	})
+}
+
+// UpdateRotation заменяет условия ротации баннера в слоте. Набранные показы и переходы, в том
+// числе за текущие сутки, сохраняются. Если баннер не в ротации слота, возвращается storage.ErrNotFound,
+// если гарантированные доли слота превысят 100% - storage.ErrShareExceeded.
+func (s *Storage) UpdateRotation(ctx context.Context, bannerID, slotID int, settings storage.RotationSettings) error {
+	const query = `
+		UPDATE rotations
+		SET starts_at = $3, ends_at = $4, max_impressions = $5, max_clicks = $6, max_daily_impressions = $7,
+			pinned = $8, weight = $9, min_share = $10
+		WHERE slot_id = $1 AND banner_id = $2
+			AND (SELECT COALESCE(SUM(min_share), 0) FROM rotations
+				WHERE slot_id = $1 AND banner_id <> $2) + $10::float8 <= 1 + $11::float8;`
+	const assignedQuery = `SELECT EXISTS (SELECT 1 FROM rotations WHERE slot_id = $1 AND banner_id = $2);`
+
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	flight, caps := settings.Flight, settings.Caps
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := lockSlotShares(ctx, tx, slotID); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, query, slotID, bannerID,
+			nullTime(flight.StartsAt), nullTime(flight.EndsAt),
+			nullInt64(caps.MaxImpressions), nullInt64(caps.MaxClicks), nullInt64(caps.MaxDailyImpressions),
+			settings.Pinned, settings.Weight, settings.MinShare, storage.ShareTolerance)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected > 0 {
+			return nil
+		}
+
+		var assigned bool
+		if err := tx.QueryRowContext(ctx, assignedQuery, slotID, bannerID).Scan(&assigned); err != nil {
+			return err
+		}
+		if !assigned {
+			return storage.ErrNotFound
+		}
+		return storage.ErrShareExceeded
+	})
+}
+
+// SetBannerPaused приостанавливает или возобновляет показ баннера в слоте.
+// Если баннер не в ротации слота, возвращается storage.ErrNotFound.
+func (s *Storage) SetBannerPaused(ctx context.Context, bannerID, slotID int, paused bool) error {
+	const query = `UPDATE rotations SET paused = $3 WHERE slot_id = $1 AND banner_id = $2;`
+
+	res, err := s.db.ExecContext(ctx, query, slotID, bannerID, paused)
 	if err != nil {
 		return err
 	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return storage.ErrNotFound
+	}
 	return nil
 }
 
@@ -75,75 +184,333 @@ func (s *Storage) RemoveBanner(ctx context.Context, bannerID, slotID int) error
 	return nil
 }
 
-func (s *Storage) ClickBanner(ctx context.Context, bannerID, slotID, userGroupID int) (*storage.Click, error) {
+// ClickBanner записывает переход, если он проходит проверки guard. Отклоненный переход
+// сохраняется в rejected_clicks, а вызывающему возвращается *storage.RejectedClickError.
+func (s *Storage) ClickBanner(
+	ctx context.Context,
+	attempt storage.ClickAttempt,
+	guard storage.ClickGuard,
+) (*storage.Click, error) {
 	const query = `
-		INSERT INTO clicks (slot_id, banner_id, usergroup_id, created_at) 
-		VALUES ($1, $2, $3, NOW())
+		INSERT INTO clicks (slot_id, banner_id, usergroup_id, impression_id, client_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
 		RETURNING id, slot_id, banner_id, usergroup_id, created_at;`
 
-	click := &storage.Click{}
-	err := s.db.QueryRowContext(ctx, query, slotID, bannerID, userGroupID).
-		Scan(&click.ID, &click.SlotID, &click.BannerID, &click.UserGroupID, &click.CreatedAt)
+	click := &storage.Click{ClientKey: attempt.ClientKey}
+	var reason string
+	seq := s.reserveStats()
+	err := s.withTx(ctx, func(tx *sql.Tx) (err error) {
+		var impressionID sql.NullInt64
+		reason, impressionID, err = checkClick(ctx, tx, attempt, guard)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			return insertRejectedClick(ctx, tx, attempt, impressionID, reason)
+		}
+		click.ImpressionID = int(impressionID.Int64)
+
+		err = tx.QueryRowContext(ctx, query, attempt.SlotID, attempt.BannerID, attempt.UserGroupID,
+			impressionID, nullString(attempt.ClientKey)).
+			Scan(&click.ID, &click.SlotID, &click.BannerID, &click.UserGroupID, &click.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if err := s.incrementStats(ctx, tx, attempt.SlotID, attempt.BannerID, attempt.UserGroupID, 0, 1); err != nil {
+			return err
+		}
+		if click.CapsReached, err = countServed(ctx, tx, attempt.SlotID, attempt.BannerID, 0, 1); err != nil {
+			return err
+		}
+		notification := storage.NewClickNotification(click)
+		notification.Origin = s.origin
+		notification.Seq = seq
+		if err := insertOutbox(ctx, tx, notification); err != nil {
+			return err
+		}
+		return insertCapNotifications(ctx, tx, notification, click.CapsReached)
+	})
 	if err != nil {
+		s.releaseStats(seq)
 		return nil, err
 	}
+	if reason != "" {
+		s.releaseStats(seq)
+		return nil, &storage.RejectedClickError{Reason: reason}
+	}
+	s.cacheStats(seq, attempt.SlotID, attempt.BannerID, attempt.UserGroupID, 0, 1)
 
 	return click, nil
 }
 
-func (s *Storage) PickBanner(ctx context.Context, slotID, usergroupID int) (*storage.Impress, int, error) {
-	const query = `
+// PickBanner выбирает баннер для показа в слоте, не предлагая баннеры из excluded. Показ не записывается:
+// его подтверждает RecordImpression, когда баннер действительно отрисован.
+func (s *Storage) PickBanner(ctx context.Context, slotID, usergroupID int, excluded map[int]struct{}) (int, error) {
+	banners, err := s.slotBanners(ctx, slotID, usergroupID)
+	if err != nil {
+		return 0, err
+	}
+	banners = multiarmedbandit.Exclude(banners, excluded)
+
+	if len(banners) == 0 {
+		return 0, storage.ErrNoActiveBanners
+	}
+	if err := s.attachContext(ctx, slotID, usergroupID, banners); err != nil {
+		return 0, err
+	}
+
+	return s.strategies.Pick(slotID, banners), nil
+}
+
+// PickBanners выбирает баннер для каждого слота страницы. Баннеры из excluded не выбираются
+// ни в одном слоте. При uniqueBanners баннер, выбранный для одного слота, не предлагается в следующих.
+// Для слота без доступных баннеров возвращается 0: остальные слоты страницы заполняются.
+func (s *Storage) PickBanners(
+	ctx context.Context,
+	slotIDs []int,
+	usergroupID int,
+	excluded map[int]struct{},
+	uniqueBanners bool,
+) ([]int, error) {
+	bannerIDs := make([]int, 0, len(slotIDs))
+	picked := make(map[int]struct{}, len(slotIDs))
+
+	for _, slotID := range slotIDs {
+		banners, err := s.slotBanners(ctx, slotID, usergroupID)
+		if err != nil {
+			return nil, err
+		}
+		banners = multiarmedbandit.Exclude(banners, excluded)
+		if uniqueBanners {
+			banners = multiarmedbandit.Exclude(banners, picked)
+		}
+		if len(banners) == 0 {
+			bannerIDs = append(bannerIDs, 0)
+			continue
+		}
+		if err := s.attachContext(ctx, slotID, usergroupID, banners); err != nil {
+			return nil, err
+		}
+
+		bannerID := s.strategies.Pick(slotID, banners)
+		picked[bannerID] = struct{}{}
+		bannerIDs = append(bannerIDs, bannerID)
+	}
+
+	return bannerIDs, nil
+}
+
+// RecordImpression записывает подтвержденный показ. Повторное подтверждение того же токена
+// возвращает storage.ErrAlreadyRecorded.
+func (s *Storage) RecordImpression(
+	ctx context.Context,
+	tokenID string,
+	bannerID, slotID, userGroupID int,
+) (*storage.Impress, error) {
+	var impress *storage.Impress
+	seq := s.reserveStats()
+	err := s.withTx(ctx, func(tx *sql.Tx) (err error) {
+		impress, err = s.insertImpression(ctx, tx, seq, tokenID, bannerID, slotID, userGroupID)
+		return err
+	})
+	if err != nil {
+		s.releaseStats(seq)
+		return nil, err
+	}
+	s.cacheStats(seq, slotID, bannerID, userGroupID, 1, 0)
+
+	return impress, nil
+}
+
+func (s *Storage) ImpressBanner(ctx context.Context, bannerID, slotID, userGroupID int) (*storage.Impress, error) {
+	return s.RecordImpression(ctx, "", bannerID, slotID, userGroupID)
+}
+
+// slotBanners возвращает активные баннеры слота с условиями показа, числом подтвержденных показов
+// и счетчиками для группы с учетом забывания статистики слота: затухающие счетчики приводятся
+// к текущему моменту, для окна события считаются по таблицам событий.
+func (s *Storage) slotBanners(ctx context.Context, slotID, usergroupID int) ([]multiarmedbandit.Banner, error) {
+	const countersQuery = `
 		SELECT
-			r.banner_id,
-			(SELECT COUNT(*) FROM impressions i WHERE i.banner_id = r.banner_id AND i.usergroup_id = $1) AS impressions,
-			(SELECT COUNT(*) FROM clicks c WHERE c.banner_id = r.banner_id AND c.usergroup_id = $1) AS clicks
+			r.banner_id, r.pinned, r.weight, r.min_share, r.served_impressions,
+			COALESCE(bs.impressions, 0) AS impressions,
+			COALESCE(bs.clicks, 0) AS clicks
 		FROM rotations r
-		WHERE r.slot_id = $2;`
+		LEFT JOIN banner_stats bs
+			ON bs.slot_id = r.slot_id AND bs.banner_id = r.banner_id AND bs.usergroup_id = $1
+		WHERE r.slot_id = $2 AND` + activeRotation + `;`
 
-	rows, err := s.db.QueryContext(ctx, query, usergroupID, slotID)
+	const halfLifeQuery = `
+		SELECT
+			r.banner_id, r.pinned, r.weight, r.min_share, r.served_impressions,
+			COALESCE(bs.decayed_impressions * f.factor, 0) AS impressions,
+			COALESCE(bs.decayed_clicks * f.factor, 0) AS clicks
+		FROM rotations r
+		LEFT JOIN banner_stats bs
+			ON bs.slot_id = r.slot_id AND bs.banner_id = r.banner_id AND bs.usergroup_id = $1
+		LEFT JOIN LATERAL (
+			SELECT power(0.5, GREATEST(EXTRACT(EPOCH FROM NOW() - bs.decayed_at), 0)::float8 / $3) AS factor
+		) f ON true
+		WHERE r.slot_id = $2 AND` + activeRotation + `;`
+
+	const windowQuery = `
+		SELECT
+			r.banner_id, r.pinned, r.weight, r.min_share, r.served_impressions,
+			(SELECT COUNT(*) FROM impressions i
+				WHERE i.slot_id = r.slot_id AND i.banner_id = r.banner_id AND i.usergroup_id = $1
+				AND i.created_at >= NOW() - make_interval(secs => $3)) AS impressions,
+			(SELECT COUNT(*) FROM clicks c
+				WHERE c.slot_id = r.slot_id AND c.banner_id = r.banner_id AND c.usergroup_id = $1
+				AND c.created_at >= NOW() - make_interval(secs => $3)) AS clicks
+		FROM rotations r
+		WHERE r.slot_id = $2 AND` + activeRotation + `;`
+
+	// Счетчики берутся из кеша статистики
+	const cachedQuery = `
+		SELECT
+			r.banner_id, r.pinned, r.weight, r.min_share, r.served_impressions,
+			0::float8 AS impressions,
+			0::float8 AS clicks
+		FROM rotations r
+		WHERE r.slot_id = $1 AND` + activeRotation + `;`
+
+	decay := s.strategies.DecayForSlot(slotID)
+	cached := s.stats != nil && decay.Window == 0
+
+	query := countersQuery
+	args := []any{usergroupID, slotID}
+	switch {
+	case cached:
+		query = cachedQuery
+		args = []any{slotID}
+	case decay.HalfLife > 0:
+		query = halfLifeQuery
+		args = append(args, decay.HalfLife.Seconds())
+	case decay.Window > 0:
+		query = windowQuery
+		args = append(args, decay.Window.Seconds())
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer rows.Close()
 
+	now := time.Now()
 	banners := make([]multiarmedbandit.Banner, 0)
 	for rows.Next() {
-		var bnr storage.BannerStatistics
-		if err := rows.Scan(&bnr.BannerID, &bnr.Impressions, &bnr.Clicks); err != nil {
-			return nil, 0, err
+		var bnr multiarmedbandit.Counts
+		err := rows.Scan(&bnr.BannerID, &bnr.Priority.Pinned, &bnr.Priority.Weight, &bnr.Priority.MinShare,
+			&bnr.Served, &bnr.Impressions, &bnr.Clicks)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			counters := s.stats.Get(statscache.Key{SlotID: slotID, BannerID: bnr.BannerID, UserGroupID: usergroupID})
+			bnr.Impressions, bnr.Clicks = float64(counters.Impressions), float64(counters.Clicks)
+			if decay.HalfLife > 0 {
+				bnr.Impressions, bnr.Clicks = counters.DecayedAt(now, decay)
+			}
 		}
 		banners = append(banners, &bnr)
 	}
 
-	if len(banners) == 0 {
-		return nil, 0, errNoBannersForGivenSlot
-	}
+	return banners, rows.Err()
+}
 
-	bannerID := multiarmedbandit.PickBanner(banners)
+// insertImpression записывает показ, увеличивает счетчики и объем ротации и добавляет уведомления в outbox.
+// Пустой tokenID означает показ без токена; seq - номер события в кеше статистики (см. reserveStats).
+func (s *Storage) insertImpression(
+	ctx context.Context,
+	tx *sql.Tx,
+	seq uint64,
+	tokenID string,
+	bannerID, slotID, userGroupID int,
+) (*storage.Impress, error) {
+	const query = `
+		INSERT INTO impressions
+		(slot_id, banner_id, usergroup_id, token_id, created_at) VALUES
+		($1, $2, $3, $4, NOW())
+		ON CONFLICT (token_id) DO NOTHING
+		RETURNING id, slot_id, banner_id, usergroup_id, created_at;`
 
-	impress, err := s.ImpressBanner(ctx, bannerID, slotID, usergroupID)
+	impress := &storage.Impress{TokenID: tokenID}
+	err := tx.QueryRowContext(ctx, query, slotID, bannerID, userGroupID, nullString(tokenID)).
+		Scan(&impress.ID, &impress.SlotID, &impress.BannerID, &impress.UserGroupID, &impress.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrAlreadyRecorded
+	}
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	if err := s.incrementStats(ctx, tx, slotID, bannerID, userGroupID, 1, 0); err != nil {
+		return nil, err
+	}
+	if impress.CapsReached, err = countServed(ctx, tx, slotID, bannerID, 1, 0); err != nil {
+		return nil, err
+	}
+	notification := storage.NewImpressNotification(impress)
+	notification.Origin = s.origin
+	notification.Seq = seq
+	if err := insertOutbox(ctx, tx, notification); err != nil {
+		return nil, err
+	}
+	if err := insertCapNotifications(ctx, tx, notification, impress.CapsReached); err != nil {
+		return nil, err
 	}
 
-	return impress, bannerID, nil
+	return impress, nil
 }
 
-func (s *Storage) ImpressBanner(ctx context.Context, bannerID, slotID, userGroupID int) (*storage.Impress, error) {
+// incrementStats увеличивает счетчики показов и кликов в banner_stats. Затухающие счетчики
+// перед увеличением приводятся к текущему моменту с периодом полураспада слота.
+// С кешем статистики счетчики увеличиваются в кеше после фиксации транзакции (см. cacheStats).
+func (s *Storage) incrementStats(
+	ctx context.Context,
+	tx *sql.Tx,
+	slotID, bannerID, userGroupID, impressions, clicks int,
+) error {
+	if s.stats != nil {
+		return nil
+	}
+
+	// Множитель затухания за время с последнего обновления; без периода полураспада - 1
+	const decayFactor = `
+		CASE WHEN $6::float8 > 0
+			THEN power(0.5, GREATEST(EXTRACT(EPOCH FROM EXCLUDED.decayed_at - banner_stats.decayed_at), 0)::float8 / $6)
+			ELSE 1
+		END`
+
 	const query = `
-		INSERT INTO impressions
-		(slot_id, banner_id, usergroup_id, created_at) VALUES
-		($1, $2, $3, NOW())
-		RETURNING id, slot_id, banner_id, usergroup_id, created_at;`
+		INSERT INTO banner_stats
+		(slot_id, banner_id, usergroup_id, impressions, clicks, decayed_impressions, decayed_clicks, decayed_at)
+		VALUES ($1, $2, $3, $4, $5, $4, $5, NOW())
+		ON CONFLICT (slot_id, banner_id, usergroup_id) DO UPDATE
+		SET impressions = banner_stats.impressions + EXCLUDED.impressions,
+			clicks = banner_stats.clicks + EXCLUDED.clicks,
+			decayed_impressions = banner_stats.decayed_impressions * ` + decayFactor + ` + EXCLUDED.impressions,
+			decayed_clicks = banner_stats.decayed_clicks * ` + decayFactor + ` + EXCLUDED.clicks,
+			decayed_at = EXCLUDED.decayed_at;`
+
+	halfLife := s.strategies.DecayForSlot(slotID).HalfLife.Seconds()
+	_, err := tx.ExecContext(ctx, query, slotID, bannerID, userGroupID, impressions, clicks, halfLife)
+	return err
+}
 
-	impress := &storage.Impress{}
-	err := s.db.QueryRowContext(ctx, query, slotID, bannerID, userGroupID).
-		Scan(&impress.ID, &impress.SlotID, &impress.BannerID, &impress.UserGroupID, &impress.CreatedAt)
+// withTx выполняет fn в транзакции, откатывая ее при ошибке.
+func (s *Storage) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
 	}
 
-	return impress, err
+	return tx.Commit()
 }
 
 func (s *Storage) IsBannerAssignedToSlot(ctx context.Context, bannerID, slotID int) (bool, error) {
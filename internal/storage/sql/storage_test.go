@@ -2,10 +2,13 @@ package sql
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dianapovarnitsina/banners-rotation/internal/multiarmedbandit"
 	stor "github.com/dianapovarnitsina/banners-rotation/internal/storage"
 )
 
@@ -19,20 +22,87 @@ func TestAddBanner(t *testing.T) {
 
 	storage := &Storage{db: db}
 
-	// Ожидаемый запрос
+	// Ожидаемый запрос: доли слота проверяются под блокировкой слота
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec("INSERT INTO rotations").
-		WithArgs(1, 2).
+		WithArgs(1, 2, nil, nil, nil, nil, nil, false, float64(0), float64(0), stor.ShareTolerance).
 		WillReturnResult(sqlmock.NewResult(1, 1)).
 		WillReturnError(nil)
+	mock.ExpectCommit()
 
 	ctx := context.Background()
 
 	// Тестируем AddBanner
-	err = storage.AddBanner(ctx, 2, 1)
+	err = storage.AddBanner(ctx, 2, 1, stor.RotationSettings{})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Баннер с периодом показа
+	startsAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(7 * 24 * time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO rotations").
+		WithArgs(1, 3, startsAt, endsAt, nil, nil, nil, false, float64(0), float64(0), stor.ShareTolerance).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = storage.AddBanner(ctx, 3, 1, stor.RotationSettings{Flight: stor.Flight{StartsAt: startsAt, EndsAt: endsAt}})
 	if err != nil {
 		t.Errorf("unexpected error: %s", err)
 	}
 
+	// Период, который заканчивается раньше начала, не записывается
+	err = storage.AddBanner(ctx, 3, 1, stor.RotationSettings{Flight: stor.Flight{StartsAt: endsAt, EndsAt: startsAt}})
+	if !errors.Is(err, stor.ErrInvalidFlight) {
+		t.Errorf("expected ErrInvalidFlight, got: %v", err)
+	}
+
+	// Баннер с купленным объемом
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO rotations").
+		WithArgs(1, 4, nil, nil, int64(1000), nil, int64(100), false, float64(0), float64(0), stor.ShareTolerance).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	caps := stor.Caps{MaxImpressions: 1000, MaxDailyImpressions: 100}
+	err = storage.AddBanner(ctx, 4, 1, stor.RotationSettings{Caps: caps})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err = storage.AddBanner(ctx, 4, 1, stor.RotationSettings{Caps: stor.Caps{MaxClicks: -1}})
+	if !errors.Is(err, stor.ErrInvalidCaps) {
+		t.Errorf("expected ErrInvalidCaps, got: %v", err)
+	}
+
+	// Гарантированные доли слота уже распределены: строка не вставляется
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO rotations").
+		WithArgs(1, 5, nil, nil, nil, nil, nil, false, float64(0), 0.5, stor.ShareTolerance).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = storage.AddBanner(ctx, 5, 1, stor.RotationSettings{MinShare: 0.5})
+	if !errors.Is(err, stor.ErrShareExceeded) {
+		t.Errorf("expected ErrShareExceeded, got: %v", err)
+	}
+
+	err = storage.AddBanner(ctx, 5, 1, stor.RotationSettings{MinShare: 1.5})
+	if !errors.Is(err, stor.ErrInvalidPriority) {
+		t.Errorf("expected ErrInvalidPriority, got: %v", err)
+	}
+
+	// Вес задается только закрепленному баннеру
+	err = storage.AddBanner(ctx, 5, 1, stor.RotationSettings{Weight: 2})
+	if !errors.Is(err, stor.ErrWeightNotPinned) {
+		t.Errorf("expected ErrWeightNotPinned, got: %v", err)
+	}
+
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
@@ -66,6 +136,97 @@ func TestRemoveBanner(t *testing.T) {
 	}
 }
 
+func TestUpdateRotation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	ctx := context.Background()
+
+	// Условия заменяются под блокировкой слота, счетчики ротации не трогаются
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE rotations SET starts_at (.+) banner_id <> \\$2").
+		WithArgs(1, 2, nil, nil, int64(2000), nil, nil, false, float64(0), 0.2, stor.ShareTolerance).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	settings := stor.RotationSettings{Caps: stor.Caps{MaxImpressions: 2000}, MinShare: 0.2}
+	if err := storage.UpdateRotation(ctx, 2, 1, settings); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Строка не обновлена: баннер в ротации, значит, превышены доли слота
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE rotations SET starts_at").
+		WithArgs(1, 2, nil, nil, nil, nil, nil, false, float64(0), 0.9, stor.ShareTolerance).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT EXISTS (.+) FROM rotations").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	err = storage.UpdateRotation(ctx, 2, 1, stor.RotationSettings{MinShare: 0.9})
+	if !errors.Is(err, stor.ErrShareExceeded) {
+		t.Errorf("expected ErrShareExceeded, got: %v", err)
+	}
+
+	// Баннер не в ротации слота
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE rotations SET starts_at").
+		WithArgs(1, 20, nil, nil, nil, nil, nil, false, float64(0), float64(0), stor.ShareTolerance).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT EXISTS (.+) FROM rotations").
+		WithArgs(1, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectRollback()
+
+	err = storage.UpdateRotation(ctx, 20, 1, stor.RotationSettings{})
+	if !errors.Is(err, stor.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSetBannerPaused(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+
+	mock.ExpectExec("UPDATE rotations SET paused").
+		WithArgs(1, 2, true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE rotations SET paused").
+		WithArgs(1, 20, false).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := storage.SetBannerPaused(context.Background(), 2, 1, true); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Баннер не в ротации слота
+	err = storage.SetBannerPaused(context.Background(), 20, 1, false)
+	if !errors.Is(err, stor.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestClickBanner(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -83,8 +244,9 @@ func TestClickBanner(t *testing.T) {
 		CreatedAt:   time.Now(),
 	}
 
+	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO clicks").
-		WithArgs(2, 3, 1).
+		WithArgs(2, 3, 1, nil, nil).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "slot_id", "banner_id", "usergroup_id", "created_at"}).
 				AddRow(
@@ -95,10 +257,21 @@ func TestClickBanner(t *testing.T) {
 					expectedClick.CreatedAt,
 				),
 		)
+	mock.ExpectExec("INSERT INTO banner_stats").
+		WithArgs(2, 3, 1, 0, 1, float64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE rotations").
+		WithArgs(2, 3, 0, 1).
+		WillReturnRows(servedRows(false, false, false))
+	payload, _ := stor.NewClickNotification(expectedClick).Marshal()
+	mock.ExpectExec("INSERT INTO outbox").
+		WithArgs(payload).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	ctx := context.Background()
 
-	click, err := storage.ClickBanner(ctx, 3, 2, 1)
+	click, err := storage.ClickBanner(ctx, stor.ClickAttempt{BannerID: 3, SlotID: 2, UserGroupID: 1}, stor.ClickGuard{})
 	if err != nil {
 		t.Errorf("unexpected error: %s", err)
 		return
@@ -109,7 +282,7 @@ func TestClickBanner(t *testing.T) {
 		return
 	}
 
-	if *click != *expectedClick {
+	if !reflect.DeepEqual(click, expectedClick) {
 		t.Errorf("unexpected values in Сlick")
 		return
 	}
@@ -136,8 +309,9 @@ func TestImpressBanner(t *testing.T) {
 		CreatedAt:   time.Now(),
 	}
 
+	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO impressions").
-		WithArgs(2, 3, 1).
+		WithArgs(2, 3, 1, nil).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "slot_id", "banner_id", "usergroup_id", "created_at"}).
 				AddRow(
@@ -148,6 +322,17 @@ func TestImpressBanner(t *testing.T) {
 					expectedImpress.CreatedAt,
 				),
 		)
+	mock.ExpectExec("INSERT INTO banner_stats").
+		WithArgs(2, 3, 1, 1, 0, float64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE rotations").
+		WithArgs(2, 3, 1, 0).
+		WillReturnRows(servedRows(false, false, false))
+	payload, _ := stor.NewImpressNotification(expectedImpress).Marshal()
+	mock.ExpectExec("INSERT INTO outbox").
+		WithArgs(payload).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	ctx := context.Background()
 
@@ -162,7 +347,7 @@ func TestImpressBanner(t *testing.T) {
 		return
 	}
 
-	if *impress != *expectedImpress {
+	if !reflect.DeepEqual(impress, expectedImpress) {
 		t.Errorf("unexpected values in Impress")
 		return
 	}
@@ -172,6 +357,149 @@ func TestImpressBanner(t *testing.T) {
 	}
 }
 
+func TestClickBannerWithImpressionToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	createdAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM impressions WHERE token_id").
+		WithArgs("token-1", 2, 3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectQuery("INSERT INTO clicks").
+		WithArgs(2, 3, 1, int64(7), nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slot_id", "banner_id", "usergroup_id", "created_at"}).
+			AddRow(1, 2, 3, 1, createdAt))
+	mock.ExpectExec("INSERT INTO banner_stats").
+		WithArgs(2, 3, 1, 0, 1, float64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE rotations").
+		WithArgs(2, 3, 0, 1).
+		WillReturnRows(servedRows(false, false, false))
+	mock.ExpectExec("INSERT INTO outbox").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	attempt := stor.ClickAttempt{BannerID: 3, SlotID: 2, UserGroupID: 1, ImpressionTokenID: "token-1"}
+	click, err := storage.ClickBanner(context.Background(), attempt, stor.ClickGuard{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if click.ImpressionID != 7 {
+		t.Errorf("expected click to reference impression 7, got %d", click.ImpressionID)
+	}
+
+	// Показ с таким токеном не подтверждался: переход записывается в журнал отклоненных
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM impressions WHERE token_id").
+		WithArgs("token-2", 2, 3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec("INSERT INTO rejected_clicks").
+		WithArgs(2, 3, 1, nil, nil, stor.RejectNoImpression).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	attempt.ImpressionTokenID = "token-2"
+	_, err = storage.ClickBanner(context.Background(), attempt, stor.ClickGuard{})
+	var rejected *stor.RejectedClickError
+	if !errors.As(err, &rejected) || rejected.Reason != stor.RejectNoImpression {
+		t.Errorf("expected rejection %q, got: %v", stor.RejectNoImpression, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestClickBannerGuard(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	guard := stor.ClickGuard{
+		ImpressionWindow:  time.Hour,
+		ClientWindow:      10 * time.Second,
+		RequireImpression: true,
+		ImpressionMaxAge:  time.Hour,
+	}
+	attempt := stor.ClickAttempt{BannerID: 3, SlotID: 2, UserGroupID: 1, ClientKey: "10.0.0.1"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").
+		WithArgs(2, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT EXISTS (.+) FROM clicks WHERE client_key").
+		WithArgs("10.0.0.1", 2, 3, float64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectExec("INSERT INTO rejected_clicks").
+		WithArgs(2, 3, 1, nil, "10.0.0.1", stor.RejectDuplicateClient).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	_, err = storage.ClickBanner(context.Background(), attempt, guard)
+	var rejected *stor.RejectedClickError
+	if !errors.As(err, &rejected) || rejected.Reason != stor.RejectDuplicateClient {
+		t.Errorf("expected rejection %q, got: %v", stor.RejectDuplicateClient, err)
+	}
+
+	// Без недавнего показа баннера в слоте для группы переход отклоняется
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").
+		WithArgs(2, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT EXISTS (.+) FROM clicks WHERE client_key").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("SELECT EXISTS (.+) FROM impressions (.+) created_at > NOW").
+		WithArgs(2, 3, 1, float64(3600)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec("INSERT INTO rejected_clicks").
+		WithArgs(2, 3, 1, nil, "10.0.0.1", stor.RejectNoImpression).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	_, err = storage.ClickBanner(context.Background(), attempt, guard)
+	if !errors.As(err, &rejected) || rejected.Reason != stor.RejectNoImpression {
+		t.Errorf("expected rejection %q, got: %v", stor.RejectNoImpression, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRecordImpressionDuplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO impressions (.+) ON CONFLICT").
+		WithArgs(2, 3, 1, "token-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slot_id", "banner_id", "usergroup_id", "created_at"}))
+	mock.ExpectRollback()
+
+	_, err = storage.RecordImpression(context.Background(), "token-1", 3, 2, 1)
+	if !errors.Is(err, stor.ErrAlreadyRecorded) {
+		t.Errorf("expected ErrAlreadyRecorded, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestPickBanner(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -185,58 +513,242 @@ func TestPickBanner(t *testing.T) {
 	expectedSlotID := 2
 	expectedUserGroupID := 3
 
-	rows := sqlmock.NewRows([]string{"banner_id", "impressions", "clicks"}).
-		AddRow(expectedBannerID, 10, 5) // Example values for simulating a banner
+	rows := candidateRows().
+		AddRow(expectedBannerID, false, 0.0, 0.0, 0, 10, 5) // Example values for simulating a banner
 
-	mock.ExpectQuery("SELECT").
+	// Показы и клики баннера считаются только в этом слоте
+	mock.ExpectQuery("SELECT (.+) FROM rotations r LEFT JOIN banner_stats bs ON bs.slot_id = r.slot_id").
 		WithArgs(expectedUserGroupID, expectedSlotID).
 		WillReturnRows(rows)
 
-	expectedImpress := &stor.Impress{
-		ID:          1,
-		SlotID:      expectedSlotID,
-		BannerID:    expectedBannerID,
-		UserGroupID: expectedUserGroupID,
-		CreatedAt:   time.Now(),
-	}
-
-	mock.ExpectQuery("INSERT INTO impressions").
-		WithArgs(expectedSlotID, expectedBannerID, expectedUserGroupID).
-		WillReturnRows(
-			sqlmock.NewRows([]string{"id", "slot_id", "banner_id", "usergroup_id", "created_at"}).
-				AddRow(
-					expectedImpress.ID,
-					expectedImpress.SlotID,
-					expectedImpress.BannerID,
-					expectedImpress.UserGroupID,
-					expectedImpress.CreatedAt,
-				),
-		)
-
 	ctx := context.Background()
 
-	impress, bannerID, err := storage.PickBanner(ctx, expectedSlotID, expectedUserGroupID)
+	// Выбор баннера не записывает показ
+	bannerID, err := storage.PickBanner(ctx, expectedSlotID, expectedUserGroupID, nil)
 	if err != nil {
 		t.Errorf("unexpected error: %s", err)
 		return
 	}
 
-	if impress == nil {
-		t.Errorf("expected a non-nil Impress object")
+	if bannerID != expectedBannerID {
+		t.Errorf("unexpected BannerID")
 		return
 	}
 
-	if impress.ID != expectedImpress.ID || impress.BannerID != expectedImpress.BannerID {
-		t.Errorf("unexpected values in Impress")
-		return
+	// Закрепленный баннер показывается, даже если бандит выбрал бы другой
+	mock.ExpectQuery("SELECT (.+) FROM rotations r LEFT JOIN banner_stats").
+		WithArgs(expectedUserGroupID, expectedSlotID).
+		WillReturnRows(candidateRows().
+			AddRow(1, false, 0.0, 0.0, 0, 100, 90).
+			AddRow(2, true, 0.0, 0.0, 0, 100, 1))
+
+	bannerID, err = storage.PickBanner(ctx, expectedSlotID, expectedUserGroupID, nil)
+	if err != nil || bannerID != 2 {
+		t.Errorf("expected pinned banner 2, got %d (%v)", bannerID, err)
 	}
 
-	if bannerID != expectedBannerID {
-		t.Errorf("unexpected BannerID")
-		return
+	// Единственный баннер слота исключен, например ограничением частоты показов
+	mock.ExpectQuery("SELECT (.+) FROM rotations r LEFT JOIN banner_stats").
+		WithArgs(expectedUserGroupID, expectedSlotID).
+		WillReturnRows(candidateRows().AddRow(expectedBannerID, false, 0.0, 0.0, 0, 10, 5))
+
+	_, err = storage.PickBanner(ctx, expectedSlotID, expectedUserGroupID, map[int]struct{}{expectedBannerID: {}})
+	if !errors.Is(err, stor.ErrNoActiveBanners) {
+		t.Errorf("expected stor.ErrNoActiveBanners, got: %v", err)
 	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("unmet expectations: %s", err)
 	}
 }
+
+func TestPickBannerDecay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	selector := multiarmedbandit.NewSelector(nil)
+	selector.SetSlotDecay(1, multiarmedbandit.Decay{HalfLife: time.Hour})
+	selector.SetSlotDecay(2, multiarmedbandit.Decay{Window: 24 * time.Hour})
+	storage := &Storage{db: db, strategies: selector}
+
+	// Затухающие счетчики дробные
+	mock.ExpectQuery("SELECT (.+)decayed_impressions(.+) FROM rotations").
+		WithArgs(3, 1, float64(3600)).
+		WillReturnRows(candidateRows().
+			AddRow(1, false, 0.0, 0.0, 0, 100.5, 0.25).
+			AddRow(2, false, 0.0, 0.0, 0, 10.0, 5.5))
+	mock.ExpectQuery("SELECT (.+) FROM impressions i (.+) make_interval").
+		WithArgs(3, 2, float64(86400)).
+		WillReturnRows(candidateRows().AddRow(4, false, 0.0, 0.0, 0, 1, 1))
+
+	bannerID, err := storage.PickBanner(context.Background(), 1, 3, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if bannerID != 2 {
+		t.Errorf("expected banner 2 with the best recent CTR, got %d", bannerID)
+	}
+
+	bannerID, err = storage.PickBanner(context.Background(), 2, 3, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if bannerID != 4 {
+		t.Errorf("expected banner 4, got %d", bannerID)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPickBannerColdStart(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	selector := multiarmedbandit.NewSelector(nil)
+	selector.SetDefaultColdStart(multiarmedbandit.ColdStart{MinImpressions: 100})
+	storage := &Storage{db: db, strategies: selector}
+
+	// Баннер 2 показан в слоте меньше обязательного числа раз, поэтому получает долю выборов,
+	// хотя стратегия по CTR в группе всегда выбрала бы баннер 1
+	const picks = 30
+	explored := 0
+	for i := 0; i < picks; i++ {
+		mock.ExpectQuery("SELECT (.+) FROM rotations r LEFT JOIN banner_stats").
+			WithArgs(3, 2).
+			WillReturnRows(candidateRows().
+				AddRow(1, false, 0.0, 0.0, 5000, 10, 5).
+				AddRow(2, false, 0.0, 0.0, 40, 20, 0))
+
+		bannerID, err := storage.PickBanner(context.Background(), 2, 3, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if bannerID == 2 {
+			explored++
+		}
+	}
+	if explored == 0 || explored == picks {
+		t.Errorf("expected exploring banner 2 in a share of picks, got %d of %d", explored, picks)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %s", err)
+	}
+}
+
+func TestPickBanners(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+
+	mock.ExpectQuery("SELECT (.+) FROM rotations r LEFT JOIN banner_stats").
+		WithArgs(3, 1).
+		WillReturnRows(candidateRows().AddRow(1, false, 0.0, 0.0, 0, 0, 0))
+	// Баннер 1 уже выбран для слота 1 и исключается из кандидатов слота 2
+	mock.ExpectQuery("SELECT (.+) FROM rotations r LEFT JOIN banner_stats").
+		WithArgs(3, 2).
+		WillReturnRows(candidateRows().
+			AddRow(1, false, 0.0, 0.0, 0, 0, 0).
+			AddRow(2, false, 0.0, 0.0, 0, 100, 1))
+
+	bannerIDs, err := storage.PickBanners(context.Background(), []int{1, 2}, 3, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(bannerIDs) != 2 || bannerIDs[0] != 1 || bannerIDs[1] != 2 {
+		t.Errorf("unexpected banners: %v", bannerIDs)
+	}
+
+	// Для второго слота не осталось баннеров: он остается пустым, первый заполняется
+	mock.ExpectQuery("SELECT (.+) FROM rotations r LEFT JOIN banner_stats").
+		WithArgs(3, 1).
+		WillReturnRows(candidateRows().AddRow(1, false, 0.0, 0.0, 0, 0, 0))
+	mock.ExpectQuery("SELECT (.+) FROM rotations r LEFT JOIN banner_stats").
+		WithArgs(3, 2).
+		WillReturnRows(candidateRows().AddRow(1, false, 0.0, 0.0, 0, 0, 0))
+
+	bannerIDs, err = storage.PickBanners(context.Background(), []int{1, 2}, 3, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(bannerIDs) != 2 || bannerIDs[0] != 1 || bannerIDs[1] != 0 {
+		t.Errorf("unexpected banners: %v", bannerIDs)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRecordImpressionCap(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %s", err)
+	}
+	defer db.Close()
+
+	storage := &Storage{db: db}
+	createdAt := time.Now()
+
+	impress := &stor.Impress{ID: 1, SlotID: 2, BannerID: 3, UserGroupID: 1, CreatedAt: createdAt, TokenID: "token-1"}
+	notification := stor.NewImpressNotification(impress)
+	payload, _ := notification.Marshal()
+	capPayload, _ := stor.NewCapNotifications(notification, []string{stor.CapDailyImpressions})[0].Marshal()
+
+	// Показ исчерпал дневной объем: уведомление о нем записывается вслед за уведомлением о показе
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO impressions").
+		WithArgs(2, 3, 1, "token-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slot_id", "banner_id", "usergroup_id", "created_at"}).
+			AddRow(1, 2, 3, 1, createdAt))
+	mock.ExpectExec("INSERT INTO banner_stats").
+		WithArgs(2, 3, 1, 1, 0, float64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE rotations").
+		WithArgs(2, 3, 1, 0).
+		WillReturnRows(servedRows(false, false, true))
+	mock.ExpectExec("INSERT INTO outbox").
+		WithArgs(payload).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox").
+		WithArgs(capPayload).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	recorded, err := storage.RecordImpression(context.Background(), "token-1", 3, 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(recorded.CapsReached, []string{stor.CapDailyImpressions}) {
+		t.Errorf("unexpected caps reached: %v", recorded.CapsReached)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// servedRows - ответ countServed: исчерпаны ли общий объем показов, переходов и дневной объем.
+func servedRows(impressions, clicks, daily bool) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"impressions", "clicks", "daily_impressions"}).AddRow(impressions, clicks, daily)
+}
+
+// candidateRows - ответ slotBanners: баннер, условия его показа, подтвержденные показы и счетчики.
+func candidateRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{
+		"banner_id", "pinned", "weight", "min_share", "served_impressions", "impressions", "clicks",
+	})
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidState      = errors.New("invalid state")
+	ErrImportConflict    = errors.New("imported record conflicts with existing data")
+	ErrInvalidOnConflict = errors.New("conflict policy must be skip, overwrite or fail")
+)
+
+// Политики импорта записи, которая уже есть в хранилище (тот же ID или тот же ключ ротации и статистики).
+const (
+	ConflictSkip      = "skip"
+	ConflictOverwrite = "overwrite"
+	ConflictFail      = "fail"
+)
+
+// State - снимок слотов, баннеров, групп, ротаций и накопленной статистики для переноса
+// между окружениями. Записи ссылаются друг на друга по ID, поэтому ID сохраняются при импорте.
+// События (показы и переходы) в снимок не входят: статистика слотов со скользящим окном
+// после импорта начинается заново.
+type State struct {
+	Slots      []Slot
+	Banners    []Banner
+	UserGroups []UserGroupState
+	Rotations  []RotationState
+	Stats      []StatsState
+}
+
+// UserGroupState - группа вместе с признаками для контекстной стратегии.
+type UserGroupState struct {
+	UserGroup
+	Features Features
+}
+
+// RotationState - участие баннера в ротации слота с набранным объемом.
+type RotationState struct {
+	SlotID    int
+	BannerID  int
+	Settings  RotationSettings
+	Paused    bool
+	Served    Served
+	CreatedAt time.Time
+}
+
+// StatsState - счетчики тройки слот-баннер-группа. Затухающие значения приведены к DecayedAt.
+type StatsState struct {
+	SlotID             int
+	BannerID           int
+	UserGroupID        int
+	Impressions        int64
+	Clicks             int64
+	DecayedImpressions float64
+	DecayedClicks      float64
+	DecayedAt          time.Time
+}
+
+// Validate проверяет записи снимка без учета данных хранилища: ID, повторы ключей,
+// условия ротаций и признаки групп.
+func (s *State) Validate() error {
+	if err := validateIDs("slot", len(s.Slots), func(i int) int { return s.Slots[i].ID }); err != nil {
+		return err
+	}
+	if err := validateIDs("banner", len(s.Banners), func(i int) int { return s.Banners[i].ID }); err != nil {
+		return err
+	}
+	if err := validateIDs("usergroup", len(s.UserGroups), func(i int) int { return s.UserGroups[i].ID }); err != nil {
+		return err
+	}
+	for _, g := range s.UserGroups {
+		if err := g.Features.Validate(); err != nil {
+			return fmt.Errorf("%w: usergroup %d: %w", ErrInvalidState, g.ID, err)
+		}
+	}
+
+	rotations := make(map[[2]int]struct{}, len(s.Rotations))
+	for _, r := range s.Rotations {
+		key := [2]int{r.SlotID, r.BannerID}
+		if _, ok := rotations[key]; ok {
+			return fmt.Errorf("%w: duplicate rotation %d/%d", ErrInvalidState, r.SlotID, r.BannerID)
+		}
+		rotations[key] = struct{}{}
+		if err := r.Settings.Validate(); err != nil {
+			return fmt.Errorf("%w: rotation %d/%d: %w", ErrInvalidState, r.SlotID, r.BannerID, err)
+		}
+	}
+
+	stats := make(map[[3]int]struct{}, len(s.Stats))
+	for _, st := range s.Stats {
+		key := [3]int{st.SlotID, st.BannerID, st.UserGroupID}
+		if _, ok := stats[key]; ok {
+			return fmt.Errorf("%w: duplicate stats %d/%d/%d", ErrInvalidState, st.SlotID, st.BannerID, st.UserGroupID)
+		}
+		stats[key] = struct{}{}
+		if st.Impressions < 0 || st.Clicks < 0 || st.DecayedImpressions < 0 || st.DecayedClicks < 0 {
+			return fmt.Errorf("%w: stats %d/%d/%d: negative counters",
+				ErrInvalidState, st.SlotID, st.BannerID, st.UserGroupID)
+		}
+	}
+	return nil
+}
+
+func validateIDs(kind string, n int, id func(i int) int) error {
+	seen := make(map[int]struct{}, n)
+	for i := 0; i < n; i++ {
+		if id(i) <= 0 {
+			return fmt.Errorf("%w: %s id must be positive, got %d", ErrInvalidState, kind, id(i))
+		}
+		if _, ok := seen[id(i)]; ok {
+			return fmt.Errorf("%w: duplicate %s %d", ErrInvalidState, kind, id(i))
+		}
+		seen[id(i)] = struct{}{}
+	}
+	return nil
+}
+
+// ImportOptions - режим импорта снимка.
+type ImportOptions struct {
+	// OnConflict - что делать с записью, которая уже есть в хранилище; пусто - ConflictFail.
+	OnConflict string
+	// DryRun - проверить снимок и посчитать изменения, ничего не записывая.
+	DryRun bool
+}
+
+// Validate проверяет политику конфликтов.
+func (o ImportOptions) Validate() error {
+	switch o.OnConflict {
+	case "", ConflictSkip, ConflictOverwrite, ConflictFail:
+		return nil
+	default:
+		return ErrInvalidOnConflict
+	}
+}
+
+// Resolve решает судьбу записи record, которая уже есть в хранилище: true - перезаписать,
+// false - пропустить. При ConflictFail возвращается ErrImportConflict.
+func (o ImportOptions) Resolve(record string) (bool, error) {
+	switch o.OnConflict {
+	case ConflictSkip:
+		return false, nil
+	case ConflictOverwrite:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: %s", ErrImportConflict, record)
+	}
+}
+
+// ImportCounts - число созданных, перезаписанных и пропущенных записей одного вида.
+type ImportCounts struct {
+	Created int
+	Updated int
+	Skipped int
+}
+
+// Count учитывает запись: created - записи не было, иначе overwrite решает между
+// перезаписью и пропуском.
+func (c *ImportCounts) Count(created, overwrite bool) {
+	switch {
+	case created:
+		c.Created++
+	case overwrite:
+		c.Updated++
+	default:
+		c.Skipped++
+	}
+}
+
+// ImportResult - итог импорта снимка по видам записей.
+type ImportResult struct {
+	Slots      ImportCounts
+	Banners    ImportCounts
+	UserGroups ImportCounts
+	Rotations  ImportCounts
+	Stats      ImportCounts
+}
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Статистика баннера ведется в рамках слота: все выборки идут по (slot_id, banner_id, usergroup_id).
+-- Существующие события уже хранят slot_id, поэтому данные не переносятся, а только индексируются.
+CREATE INDEX IF NOT EXISTS impressions_slot_banner_usergroup_idx
+    ON impressions (slot_id, banner_id, usergroup_id);
+
+CREATE INDEX IF NOT EXISTS clicks_slot_banner_usergroup_idx
+    ON clicks (slot_id, banner_id, usergroup_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS clicks_slot_banner_usergroup_idx;
+DROP INDEX IF EXISTS impressions_slot_banner_usergroup_idx;
+-- +goose StatementEnd
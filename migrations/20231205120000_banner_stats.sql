@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS banner_stats
+(
+    slot_id      int    not null constraint banner_stats_slots_id_fk references slots on update cascade on delete cascade,
+    banner_id    int    not null constraint banner_stats_banners_id_fk references banners on update cascade on delete cascade,
+    usergroup_id int    not null constraint banner_stats_usergroups_id_fk references usergroups on update cascade on delete cascade,
+    impressions  bigint not null default 0,
+    clicks       bigint not null default 0,
+    constraint banner_stats_pk
+    primary key (slot_id, banner_id, usergroup_id)
+);
+
+-- Заполняем счетчики по уже накопленным событиям
+INSERT INTO banner_stats (slot_id, banner_id, usergroup_id, impressions, clicks)
+SELECT slot_id, banner_id, usergroup_id, SUM(impressions), SUM(clicks)
+FROM (
+    SELECT slot_id, banner_id, usergroup_id, COUNT(*) AS impressions, 0 AS clicks
+    FROM impressions
+    GROUP BY slot_id, banner_id, usergroup_id
+    UNION ALL
+    SELECT slot_id, banner_id, usergroup_id, 0 AS impressions, COUNT(*) AS clicks
+    FROM clicks
+    GROUP BY slot_id, banner_id, usergroup_id
+) AS events
+GROUP BY slot_id, banner_id, usergroup_id
+ON CONFLICT (slot_id, banner_id, usergroup_id) DO UPDATE
+SET impressions = EXCLUDED.impressions,
+    clicks      = EXCLUDED.clicks;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS banner_stats;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Почасовые агрегаты уведомлений для отчетов. Внешних ключей нет: событие может прийти
+-- из очереди уже после удаления слота или баннера, и его не должно быть нужно отбрасывать.
+CREATE TABLE IF NOT EXISTS hourly_stats
+(
+    hour         timestamptz not null,
+    slot_id      int         not null,
+    banner_id    int         not null,
+    usergroup_id int         not null,
+    impressions  bigint      not null default 0,
+    clicks       bigint      not null default 0,
+    constraint hourly_stats_pk
+    primary key (hour, slot_id, banner_id, usergroup_id)
+);
+
+-- Идентификаторы уже учтенных сообщений: повторная доставка не увеличивает счетчики
+CREATE TABLE IF NOT EXISTS hourly_stats_messages
+(
+    message_id   text        not null
+    constraint hourly_stats_messages_pk
+    primary key,
+    processed_at timestamptz not null default now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS hourly_stats_messages;
+DROP TABLE IF EXISTS hourly_stats;
+-- +goose StatementEnd
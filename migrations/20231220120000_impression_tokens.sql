@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Показ подтверждается токеном, выданным при выборе баннера; один токен - один показ
+ALTER TABLE impressions ADD COLUMN IF NOT EXISTS token_id text;
+ALTER TABLE impressions ADD CONSTRAINT impressions_token_id_key UNIQUE (token_id);
+
+-- Переход, пришедший с токеном, ссылается на подтвержденный показ
+ALTER TABLE clicks ADD COLUMN IF NOT EXISTS impression_id int
+    constraint clicks_impressions_id_fk references impressions on delete set null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE clicks DROP COLUMN IF EXISTS impression_id;
+ALTER TABLE impressions DROP CONSTRAINT IF EXISTS impressions_token_id_key;
+ALTER TABLE impressions DROP COLUMN IF EXISTS token_id;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ключ клиента (пользователь или IP) для отсечения повторных переходов
+ALTER TABLE clicks ADD COLUMN IF NOT EXISTS client_key text;
+
+CREATE INDEX IF NOT EXISTS clicks_client_slot_banner_idx
+    ON clicks (client_key, slot_id, banner_id, created_at)
+    WHERE client_key IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS clicks_impression_idx
+    ON clicks (impression_id, created_at)
+    WHERE impression_id IS NOT NULL;
+
+-- Отклоненные переходы для аудита. Внешних ключей нет: журнал хранится и после удаления
+-- слота, баннера или показа.
+CREATE TABLE IF NOT EXISTS rejected_clicks
+(
+    id            serial    constraint rejected_clicks_pk primary key,
+    slot_id       int       not null,
+    banner_id     int       not null,
+    usergroup_id  int       not null,
+    impression_id int,
+    client_key    text,
+    reason        text      not null,
+    created_at    timestamp not null
+);
+
+CREATE INDEX IF NOT EXISTS rejected_clicks_created_at_idx
+    ON rejected_clicks (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rejected_clicks;
+DROP INDEX IF EXISTS clicks_impression_idx;
+DROP INDEX IF EXISTS clicks_client_slot_banner_idx;
+ALTER TABLE clicks DROP COLUMN IF EXISTS client_key;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Счетчики с экспоненциальным затуханием: значение приведено к моменту decayed_at
+-- и при каждом событии умножается на 2^(-прошедшее время / период полураспада слота)
+ALTER TABLE banner_stats
+    ADD COLUMN IF NOT EXISTS decayed_impressions double precision not null default 0,
+    ADD COLUMN IF NOT EXISTS decayed_clicks      double precision not null default 0,
+    ADD COLUMN IF NOT EXISTS decayed_at          timestamptz      not null default now();
+
+UPDATE banner_stats
+SET decayed_impressions = impressions,
+    decayed_clicks      = clicks;
+
+-- Выборка событий за скользящее окно
+CREATE INDEX IF NOT EXISTS impressions_slot_usergroup_created_idx
+    ON impressions (slot_id, usergroup_id, created_at);
+
+CREATE INDEX IF NOT EXISTS clicks_slot_usergroup_created_idx
+    ON clicks (slot_id, usergroup_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS clicks_slot_usergroup_created_idx;
+DROP INDEX IF EXISTS impressions_slot_usergroup_created_idx;
+
+ALTER TABLE banner_stats
+    DROP COLUMN IF EXISTS decayed_at,
+    DROP COLUMN IF EXISTS decayed_clicks,
+    DROP COLUMN IF EXISTS decayed_impressions;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Период показа баннера в слоте и приостановка ротации. Статистика баннера при паузе сохраняется.
+ALTER TABLE rotations
+    ADD COLUMN IF NOT EXISTS starts_at timestamptz,
+    ADD COLUMN IF NOT EXISTS ends_at   timestamptz,
+    ADD COLUMN IF NOT EXISTS paused    boolean not null default false;
+
+ALTER TABLE rotations ADD CONSTRAINT rotations_flight_check
+    CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE rotations DROP CONSTRAINT IF EXISTS rotations_flight_check;
+
+ALTER TABLE rotations
+    DROP COLUMN IF EXISTS paused,
+    DROP COLUMN IF EXISTS ends_at,
+    DROP COLUMN IF EXISTS starts_at;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Купленный объем баннера в слоте (NULL - без ограничения) и набранные показы и переходы.
+-- today_impressions считаются за сутки served_on (UTC).
+ALTER TABLE rotations
+    ADD COLUMN IF NOT EXISTS max_impressions       bigint,
+    ADD COLUMN IF NOT EXISTS max_clicks            bigint,
+    ADD COLUMN IF NOT EXISTS max_daily_impressions bigint,
+    ADD COLUMN IF NOT EXISTS served_impressions    bigint not null default 0,
+    ADD COLUMN IF NOT EXISTS served_clicks         bigint not null default 0,
+    ADD COLUMN IF NOT EXISTS today_impressions     bigint not null default 0,
+    ADD COLUMN IF NOT EXISTS served_on             date;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE rotations
+    DROP COLUMN IF EXISTS served_on,
+    DROP COLUMN IF EXISTS today_impressions,
+    DROP COLUMN IF EXISTS served_clicks,
+    DROP COLUMN IF EXISTS served_impressions,
+    DROP COLUMN IF EXISTS max_daily_impressions,
+    DROP COLUMN IF EXISTS max_clicks,
+    DROP COLUMN IF EXISTS max_impressions;
+-- +goose StatementEnd
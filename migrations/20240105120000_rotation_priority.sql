@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Бизнес-приоритет баннера в слоте: закрепленные баннеры делят весь трафик по весу (0 - вес 1),
+-- гарантированные доли разыгрываются до выбора бандитом.
+ALTER TABLE rotations
+    ADD COLUMN IF NOT EXISTS pinned    boolean          not null default false,
+    ADD COLUMN IF NOT EXISTS weight    double precision not null default 0,
+    ADD COLUMN IF NOT EXISTS min_share double precision not null default 0;
+
+ALTER TABLE rotations ADD CONSTRAINT rotations_priority_check
+    CHECK (weight >= 0 AND min_share >= 0 AND min_share <= 1);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE rotations DROP CONSTRAINT IF EXISTS rotations_priority_check;
+
+ALTER TABLE rotations
+    DROP COLUMN IF EXISTS min_share,
+    DROP COLUMN IF EXISTS weight,
+    DROP COLUMN IF EXISTS pinned;
+-- +goose StatementEnd
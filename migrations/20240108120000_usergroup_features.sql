@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Признаки групп пользователей (возрастной диапазон, пол, интересы) для контекстной стратегии.
+CREATE TABLE IF NOT EXISTS usergroup_features
+(
+    usergroup_id int              not null references usergroups (id) on delete cascade,
+    name         varchar          not null,
+    value        double precision not null,
+    constraint usergroup_features_pk primary key (usergroup_id, name)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS usergroup_features;
+-- +goose StatementEnd
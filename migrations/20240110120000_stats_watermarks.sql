@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Отметки записи экземпляров с кешем статистики: все события экземпляра с номером не больше seq
+-- уже учтены в banner_stats.
+CREATE TABLE IF NOT EXISTS stats_watermarks
+(
+    origin     varchar     not null primary key,
+    seq        bigint      not null,
+    updated_at timestamptz not null
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stats_watermarks;
+-- +goose StatementEnd
@@ -0,0 +1,8 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Отправленные уведомления теперь удаляются сразу; удаляем накопленные ранее.
+DELETE FROM outbox WHERE sent_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- Удаленные уведомления уже отправлены, восстанавливать нечего.
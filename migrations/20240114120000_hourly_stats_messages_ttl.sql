@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Отметки сообщений старше срока хранения периодически удаляет stats-consumer.
+CREATE INDEX IF NOT EXISTS hourly_stats_messages_processed_idx ON hourly_stats_messages (processed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS hourly_stats_messages_processed_idx;
+-- +goose StatementEnd
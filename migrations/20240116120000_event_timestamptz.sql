@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Время событий записывалось через NOW() в поясе сессии: прежние значения переводятся в моменты
+-- времени в том же поясе, а окна статистики больше не зависят от пояса сессии и клиента.
+ALTER TABLE impressions ALTER COLUMN created_at TYPE timestamptz;
+ALTER TABLE clicks ALTER COLUMN created_at TYPE timestamptz;
+ALTER TABLE rejected_clicks ALTER COLUMN created_at TYPE timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE rejected_clicks ALTER COLUMN created_at TYPE timestamp;
+ALTER TABLE clicks ALTER COLUMN created_at TYPE timestamp;
+ALTER TABLE impressions ALTER COLUMN created_at TYPE timestamp;
+-- +goose StatementEnd
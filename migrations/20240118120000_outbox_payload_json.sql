@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- jsonb переупорядочивает ключи и меняет пробелы, и в очередь уходили не те байты, что записал
+-- сервис. json хранит текст уведомления как есть и по-прежнему проверяет, что это JSON.
+ALTER TABLE outbox ALTER COLUMN payload TYPE json USING payload::json;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox ALTER COLUMN payload TYPE jsonb USING payload::jsonb;
+-- +goose StatementEnd
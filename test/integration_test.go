@@ -7,9 +7,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/dianapovarnitsina/banners-rotation/internal/rmq"
 	"github.com/dianapovarnitsina/banners-rotation/internal/server/pb"
@@ -18,6 +21,7 @@ import (
 	"github.com/streadway/amqp"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -247,6 +251,49 @@ func (s *BannerSuite) TestBanner_ClickBanner_Success() {
 	s.Equal(jsonString, body)
 }
 
+func (s *BannerSuite) TestBanner_ClickBanner_Duplicate() {
+	req := &pb.ClickBannerRequest{
+		SlotId:      2,
+		BannerId:    2,
+		UsergroupId: 2,
+	}
+	// Ключ клиента - адрес теста, поэтому отклоненные переходы считаются по слоту, баннеру и группе
+	beforeRejected := s.getCountRecordInRejectedClicksTable(req.SlotId, req.BannerId, req.UsergroupId)
+
+	_, err := s.client.ClickBanner(s.ctx, req)
+	s.Require().NoError(err)
+
+	// Уведомление из RMQ забираем, чтобы оно не попало в другие тесты
+	if msg, ok := <-s.msgs; ok {
+		msg.Ack(true)
+	}
+
+	beforeCount := s.getCountRecordInClicksTable(req.SlotId, req.BannerId, req.UsergroupId)
+	_, err = s.client.ClickBanner(s.ctx, req)
+	s.Equal(codes.AlreadyExists, status.Code(err))
+	s.Equal(beforeCount, s.getCountRecordInClicksTable(req.SlotId, req.BannerId, req.UsergroupId))
+	s.Equal(beforeRejected+1, s.getCountRecordInRejectedClicksTable(req.SlotId, req.BannerId, req.UsergroupId))
+}
+
+func (s *BannerSuite) TestBanner_PauseBanner() {
+	_, err := s.client.PauseBanner(s.ctx, &pb.PauseBannerRequest{SlotId: 3, BannerId: 3})
+	s.Require().NoError(err)
+	defer func() {
+		_, err := s.client.ResumeBanner(s.ctx, &pb.ResumeBannerRequest{SlotId: 3, BannerId: 3})
+		s.Require().NoError(err)
+	}()
+
+	// Приостановленный баннер не выбирается
+	for i := 0; i < 5; i++ {
+		resp, err := s.client.PickBanner(s.ctx, &pb.PickBannerRequest{SlotId: 3, UsergroupId: 1})
+		s.Require().NoError(err)
+		s.NotEqual(int32(3), resp.BannerId)
+	}
+
+	_, err = s.client.PauseBanner(s.ctx, &pb.PauseBannerRequest{SlotId: 3, BannerId: 9})
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
 func (s *BannerSuite) TestBanner_PickBanner() {
 
 	req := &pb.PickBannerRequest{
@@ -258,6 +305,18 @@ func (s *BannerSuite) TestBanner_PickBanner() {
 	s.Require().NoError(err)
 	s.NotNil(resp)
 	s.Equal("Banner picked successfully", resp.Message)
+	s.NotEmpty(resp.ImpressionToken)
+
+	// Выбор баннера еще не показ: запись появляется только после подтверждения
+	before := s.getCountRecordInImpressionsTable(req.SlotId, resp.BannerId, req.UsergroupId)
+
+	recorded, err := s.client.RecordImpression(s.ctx, &pb.RecordImpressionRequest{ImpressionToken: resp.ImpressionToken})
+	s.Require().NoError(err)
+	s.Equal("Impression recorded successfully", recorded.Message)
+	s.Equal(before+1, s.getCountRecordInImpressionsTable(req.SlotId, resp.BannerId, req.UsergroupId))
+
+	_, err = s.client.RecordImpression(s.ctx, &pb.RecordImpressionRequest{ImpressionToken: resp.ImpressionToken})
+	s.Equal(codes.AlreadyExists, status.Code(err))
 
 	impression, err := s.getRecordInImpressionsTable(req.SlotId, resp.BannerId, req.UsergroupId)
 	s.Require().NoError(err)
@@ -278,6 +337,233 @@ func (s *BannerSuite) TestBanner_PickBanner() {
 	s.Equal(jsonString, body)
 }
 
+func (s *BannerSuite) TestBanner_SlotLifecycle() {
+	created, err := s.client.CreateSlot(s.ctx, &pb.CreateSlotRequest{Name: "Integration slot"})
+	s.Require().NoError(err)
+	s.Equal("Integration slot", created.Slot.Name)
+
+	updated, err := s.client.UpdateSlot(s.ctx, &pb.UpdateSlotRequest{Id: created.Slot.Id, Name: "Renamed slot"})
+	s.Require().NoError(err)
+	s.Equal("Renamed slot", updated.Slot.Name)
+
+	got, err := s.client.GetSlot(s.ctx, &pb.GetSlotRequest{Id: created.Slot.Id})
+	s.Require().NoError(err)
+	s.Equal("Renamed slot", got.Slot.Name)
+
+	_, err = s.client.DeleteSlot(s.ctx, &pb.DeleteSlotRequest{Id: created.Slot.Id})
+	s.Require().NoError(err)
+
+	_, err = s.client.GetSlot(s.ctx, &pb.GetSlotRequest{Id: created.Slot.Id})
+	s.Require().Error(err)
+	s.Equal(codes.NotFound, status.Code(err))
+
+	_, err = s.client.CreateSlot(s.ctx, &pb.CreateSlotRequest{Name: " "})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *BannerSuite) TestBanner_StreamEvents() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	stream, err := s.client.StreamEvents(ctx, &pb.StreamEventsRequest{SlotId: 2, TypeEvent: storage.EventImpress})
+	s.Require().NoError(err)
+	// Заголовки приходят после регистрации подписки на сервере
+	_, err = stream.Header()
+	s.Require().NoError(err)
+
+	resp, err := s.client.PickBanner(s.ctx, &pb.PickBannerRequest{SlotId: 2, UsergroupId: 3})
+	s.Require().NoError(err)
+	_, err = s.client.RecordImpression(s.ctx, &pb.RecordImpressionRequest{ImpressionToken: resp.ImpressionToken})
+	s.Require().NoError(err)
+
+	event, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(storage.EventImpress, event.TypeEvent)
+	s.Equal(int32(2), event.SlotId)
+	s.Equal(resp.BannerId, event.BannerId)
+	s.Equal(int32(3), event.UsergroupId)
+
+	// Уведомление из RMQ забираем, чтобы оно не попало в другие тесты
+	if msg, ok := <-s.msgs; ok {
+		msg.Ack(true)
+	}
+}
+
+func (s *BannerSuite) TestBanner_BannerCaps() {
+	_, err := s.client.AddBanner(s.ctx, &pb.AddBannerRequest{SlotId: 2, BannerId: 9, MaxImpressions: 1})
+	s.Require().NoError(err)
+	defer s.removeRecord(2, 9)
+	for _, bannerID := range []int32{2, 5} {
+		_, err := s.client.PauseBanner(s.ctx, &pb.PauseBannerRequest{SlotId: 2, BannerId: bannerID})
+		s.Require().NoError(err)
+		defer func(bannerID int32) {
+			_, err := s.client.ResumeBanner(s.ctx, &pb.ResumeBannerRequest{SlotId: 2, BannerId: bannerID})
+			s.Require().NoError(err)
+		}(bannerID)
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	stream, err := s.client.StreamEvents(ctx, &pb.StreamEventsRequest{SlotId: 2, TypeEvent: storage.EventCapReached})
+	s.Require().NoError(err)
+	_, err = stream.Header()
+	s.Require().NoError(err)
+
+	resp, err := s.client.PickBanner(s.ctx, &pb.PickBannerRequest{SlotId: 2, UsergroupId: 1})
+	s.Require().NoError(err)
+	s.Equal(int32(9), resp.BannerId)
+	_, err = s.client.RecordImpression(s.ctx, &pb.RecordImpressionRequest{ImpressionToken: resp.ImpressionToken})
+	s.Require().NoError(err)
+
+	event, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(int32(9), event.BannerId)
+	s.Equal(storage.CapImpressions, event.Cap)
+
+	// Купленный объем исчерпан, других активных баннеров в слоте нет
+	_, err = s.client.PickBanner(s.ctx, &pb.PickBannerRequest{SlotId: 2, UsergroupId: 1})
+	s.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// Докупленный объем считается вместе с уже открученным
+	_, err = s.client.UpdateRotation(s.ctx, &pb.UpdateRotationRequest{SlotId: 2, BannerId: 9, MaxImpressions: 2})
+	s.Require().NoError(err)
+	resp, err = s.client.PickBanner(s.ctx, &pb.PickBannerRequest{SlotId: 2, UsergroupId: 1})
+	s.Require().NoError(err)
+	s.Equal(int32(9), resp.BannerId)
+	_, err = s.client.UpdateRotation(s.ctx, &pb.UpdateRotationRequest{SlotId: 2, BannerId: 9, MaxImpressions: 1})
+	s.Require().NoError(err)
+	_, err = s.client.PickBanner(s.ctx, &pb.PickBannerRequest{SlotId: 2, UsergroupId: 1})
+	s.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// Уведомления о показе и об исчерпании объема забираем из RMQ
+	for i := 0; i < 2; i++ {
+		if msg, ok := <-s.msgs; ok {
+			msg.Ack(true)
+		}
+	}
+}
+
+func (s *BannerSuite) TestBanner_FrequencyCap() {
+	// В слоте 3 два баннера, каждый показывается пользователю не больше 3 раз за сутки
+	userKey := fmt.Sprintf("user-%d", time.Now().UnixNano())
+	views := make(map[int32]int)
+	for i := 0; i < 6; i++ {
+		resp, err := s.client.PickBanner(s.ctx, &pb.PickBannerRequest{SlotId: 3, UsergroupId: 1, UserKey: userKey})
+		s.Require().NoError(err)
+		views[resp.BannerId]++
+		s.LessOrEqual(views[resp.BannerId], 3)
+
+		_, err = s.client.RecordImpression(s.ctx, &pb.RecordImpressionRequest{ImpressionToken: resp.ImpressionToken})
+		s.Require().NoError(err)
+		if msg, ok := <-s.msgs; ok {
+			msg.Ack(true)
+		}
+	}
+
+	_, err := s.client.PickBanner(s.ctx, &pb.PickBannerRequest{SlotId: 3, UsergroupId: 1, UserKey: userKey})
+	s.Equal(codes.FailedPrecondition, status.Code(err))
+
+	_, err = s.client.PickBanner(s.ctx, &pb.PickBannerRequest{SlotId: 3, UsergroupId: 1})
+	s.Require().NoError(err)
+}
+
+func (s *BannerSuite) TestBanner_PinnedBanner() {
+	_, err := s.client.AddBanner(s.ctx, &pb.AddBannerRequest{SlotId: 1, BannerId: 10, Pinned: true})
+	s.Require().NoError(err)
+	defer s.removeRecord(1, 10)
+
+	for i := 0; i < 5; i++ {
+		resp, err := s.client.PickBanner(s.ctx, &pb.PickBannerRequest{SlotId: 1, UsergroupId: 1})
+		s.Require().NoError(err)
+		s.Equal(int32(10), resp.BannerId)
+	}
+
+	// Гарантированные доли слота не превышают 100%
+	_, err = s.client.AddBanner(s.ctx, &pb.AddBannerRequest{SlotId: 1, BannerId: 9, MinShare: 0.8})
+	s.Require().NoError(err)
+	defer s.removeRecord(1, 9)
+	_, err = s.client.AddBanner(s.ctx, &pb.AddBannerRequest{SlotId: 1, BannerId: 8, MinShare: 0.5})
+	s.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (s *BannerSuite) TestBanner_ConcurrentShares() {
+	// Параллельные добавления проверяют доли слота по очереди: проходит только одно
+	bannerIDs := []int32{7, 8, 9}
+	errs := make(chan error, len(bannerIDs))
+	for _, bannerID := range bannerIDs {
+		defer s.removeRecord(3, bannerID)
+		go func(bannerID int32) {
+			_, err := s.client.AddBanner(s.ctx, &pb.AddBannerRequest{SlotId: 3, BannerId: bannerID, MinShare: 0.6})
+			errs <- err
+		}(bannerID)
+	}
+
+	added := 0
+	for range bannerIDs {
+		err := <-errs
+		if err == nil {
+			added++
+			continue
+		}
+		s.Equal(codes.FailedPrecondition, status.Code(err))
+	}
+	s.Equal(1, added)
+}
+
+func (s *BannerSuite) TestBanner_UserGroupFeatures() {
+	features := map[string]float64{"age_18_24": 1, "interest_sport": 0.5}
+	_, err := s.client.SetUserGroupFeatures(s.ctx, &pb.SetUserGroupFeaturesRequest{UsergroupId: 3, Features: features})
+	s.Require().NoError(err)
+	defer func() {
+		_, err := s.db.ExecContext(s.ctx, "DELETE FROM usergroup_features WHERE usergroup_id = 3;")
+		s.Require().NoError(err)
+	}()
+
+	resp, err := s.client.GetUserGroupFeatures(s.ctx, &pb.GetUserGroupFeaturesRequest{UsergroupId: 3})
+	s.Require().NoError(err)
+	s.Equal(features, resp.Features)
+
+	_, err = s.client.GetUserGroupFeatures(s.ctx, &pb.GetUserGroupFeaturesRequest{UsergroupId: 100})
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *BannerSuite) TestBanner_ExportImportState() {
+	export, err := s.client.ExportState(s.ctx, &pb.ExportStateRequest{Format: "jsonl"})
+	s.Require().NoError(err)
+	var data []byte
+	for {
+		chunk, err := export.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		s.Require().NoError(err)
+		data = append(data, chunk.Data...)
+	}
+	s.Contains(string(data), `"type":"slot"`)
+
+	importState := func(onConflict string) (*pb.ImportStateResponse, error) {
+		stream, err := s.client.ImportState(s.ctx)
+		s.Require().NoError(err)
+		req := &pb.ImportStateRequest{Format: "jsonl", OnConflict: onConflict, DryRun: true, Data: data}
+		s.Require().NoError(stream.Send(req))
+		return stream.CloseAndRecv()
+	}
+
+	// Снимок самого себя: все записи уже есть и пропускаются
+	resp, err := importState("skip")
+	s.Require().NoError(err)
+	s.True(resp.DryRun)
+	s.Zero(resp.Slots.Created)
+	s.NotZero(resp.Slots.Skipped)
+	s.NotZero(resp.Rotations.Skipped)
+
+	_, err = importState("fail")
+	s.Equal(codes.AlreadyExists, status.Code(err))
+
+	_, err = importState("replace")
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
 func (s *BannerSuite) checkingRecordInRotationsTable(slotID, bannerID int32) {
 	query := `SELECT COUNT(*) FROM rotations WHERE slot_id = $1 AND banner_id = $2;`
 	var count int
@@ -303,8 +589,18 @@ func (s *BannerSuite) getCountRecordInClicksTable(slotID, bannerID, userGroupID
 	return count
 }
 
+func (s *BannerSuite) getCountRecordInRejectedClicksTable(slotID, bannerID, userGroupID int32) int {
+	query := `SELECT COUNT(*) FROM rejected_clicks WHERE slot_id = $1 AND banner_id = $2 AND usergroup_id = $3;`
+	var count int
+	err := s.db.QueryRow(query, slotID, bannerID, userGroupID).Scan(&count)
+	s.Require().NoError(err)
+	return count
+}
+
 func (s *BannerSuite) getRecordInClicksTable(slotID, bannerID, userGroupID int32) (*storage.Click, error) {
-	query := `SELECT * FROM clicks WHERE slot_id = $1 AND banner_id = $2 AND usergroup_id = $3;`
+	query := `
+	SELECT id, slot_id, banner_id, usergroup_id, created_at
+	FROM clicks WHERE slot_id = $1 AND banner_id = $2 AND usergroup_id = $3;`
 	row := s.db.QueryRow(query, slotID, bannerID, userGroupID)
 
 	click := &storage.Click{}
@@ -318,7 +614,8 @@ func (s *BannerSuite) getRecordInClicksTable(slotID, bannerID, userGroupID int32
 
 func (s *BannerSuite) getRecordInImpressionsTable(slotID, bannerID, userGroupID int32) (*storage.Impress, error) {
 	query := `
-	SELECT * FROM impressions WHERE slot_id = $1 AND banner_id = $2 AND usergroup_id = $3 
+	SELECT id, slot_id, banner_id, usergroup_id, created_at
+	FROM impressions WHERE slot_id = $1 AND banner_id = $2 AND usergroup_id = $3
 	ORDER BY created_at desc 
 	LIMIT 1;
 	`
@@ -333,6 +630,14 @@ func (s *BannerSuite) getRecordInImpressionsTable(slotID, bannerID, userGroupID
 	return impress, nil
 }
 
+func (s *BannerSuite) getCountRecordInImpressionsTable(slotID, bannerID, userGroupID int32) int {
+	query := `SELECT COUNT(*) FROM impressions WHERE slot_id = $1 AND banner_id = $2 AND usergroup_id = $3;`
+	var count int
+	err := s.db.QueryRow(query, slotID, bannerID, userGroupID).Scan(&count)
+	s.Require().NoError(err)
+	return count
+}
+
 func (s *BannerSuite) removeRecord(slotID, bannerID int32) {
 	query := `DELETE FROM rotations WHERE slot_id = $1 and banner_id = $2;`
 	_, err := s.db.Exec(query, slotID, bannerID)